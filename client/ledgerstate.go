@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+const (
+	routeAddresses      = "ledgerstate/addresses/"
+	routeAddressHistory = "/history"
+	routeColors         = "ledgerstate/colors/"
+
+	routeTransactionValidation = "ledgerstate/transactions/validate"
+)
+
+// GetAddressHistory is the handler for the /ledgerstate/addresses/:address/history endpoint. It returns a page of at
+// most limit Transactions that credit or debit the given address (ordered from the newest to the oldest one) that
+// starts after the given cursor. An empty cursor requests the first page and a limit of 0 uses the node's default.
+func (api *GoShimmerAPI) GetAddressHistory(base58EncodedAddress string, cursor string, limit int) (*jsonmodels.GetAddressHistoryResponse, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	route := routeAddresses + base58EncodedAddress + routeAddressHistory
+	if len(query) != 0 {
+		route += "?" + query.Encode()
+	}
+
+	res := &jsonmodels.GetAddressHistoryResponse{}
+	if err := api.do(http.MethodGet, route, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetColor is the handler for the /ledgerstate/colors/:color endpoint. It returns the supply of the given Color and the
+// metadata that was attached to its minting transaction.
+func (api *GoShimmerAPI) GetColor(base58EncodedColor string) (*jsonmodels.GetColorResponse, error) {
+	res := &jsonmodels.GetColorResponse{}
+	if err := api.do(http.MethodGet, routeColors+base58EncodedColor, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ValidateTransaction runs the given marshaled Transaction through all checks of the node without issuing it and
+// returns the results of the individual checks.
+func (api *GoShimmerAPI) ValidateTransaction(txBytes []byte) (*jsonmodels.PostTransactionValidationResponse, error) {
+	res := &jsonmodels.PostTransactionValidationResponse{}
+	if err := api.do(http.MethodPost, routeTransactionValidation, &jsonmodels.PostTransactionValidationRequest{TransactionBytes: txBytes}, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
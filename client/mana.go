@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	webapi_mana "github.com/iotaledger/goshimmer/plugins/webapi/mana"
 )
@@ -13,11 +14,13 @@ const (
 	routeGetManaPercentile        = "mana/percentile"
 	routeGetOnlineAccessMana      = "mana/access/online"
 	routeGetOnlineConsensusMana   = "mana/consensus/online"
+	routeGetActiveConsensusMana   = "mana/consensus/active"
 	routeGetNHighestAccessMana    = "mana/access/nhighest"
 	routeGetNHighestConsensusMana = "mana/consensus/nhighest"
 	routePending                  = "mana/pending"
 	routePastConsensusVector      = "mana/consensus/past"
 	routePastConsensusEventLogs   = "mana/consensus/logs"
+	routeGetManaHistory           = "mana/history"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
@@ -106,6 +109,16 @@ func (api *GoShimmerAPI) GetOnlineConsensusMana() (*webapi_mana.GetOnlineRespons
 	return res, nil
 }
 
+// GetActiveConsensusMana returns the sorted list of consensus mana of the nodes that recently issued messages.
+func (api *GoShimmerAPI) GetActiveConsensusMana() (*webapi_mana.GetActiveConsensusResponse, error) {
+	res := &webapi_mana.GetActiveConsensusResponse{}
+	if err := api.do(http.MethodGet, routeGetActiveConsensusMana,
+		nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetNHighestAccessMana returns the N highest access mana holders in the network, sorted in descending order.
 func (api *GoShimmerAPI) GetNHighestAccessMana(n int) (*webapi_mana.GetNHighestResponse, error) {
 	res := &webapi_mana.GetNHighestResponse{}
@@ -166,3 +179,19 @@ func (api *GoShimmerAPI) GetConsensusEventLogs(nodeIDs []string) (*webapi_mana.G
 	}
 	return res, nil
 }
+
+// GetManaHistory returns the effective mana of the given type of the nodeIDs specified, sampled every interval between
+// startTime and endTime (unix timestamps). If no nodeIDs are specified, the history of all nodes is returned.
+func (api *GoShimmerAPI) GetManaHistory(manaType string, nodeIDs []string, startTime, endTime int64, interval time.Duration) (*webapi_mana.GetManaHistoryResponse, error) {
+	res := &webapi_mana.GetManaHistoryResponse{}
+	if err := api.do(http.MethodGet, routeGetManaHistory, &webapi_mana.GetManaHistoryRequest{
+		NodeIDs:   nodeIDs,
+		ManaType:  manaType,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Interval:  int64(interval / time.Second),
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
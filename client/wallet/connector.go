@@ -10,6 +10,7 @@ import (
 // locally on a server or it can connect remotely using the web API.
 type Connector interface {
 	UnspentOutputs(addresses ...address.Address) (unspentOutputs map[address.Address]map[ledgerstate.OutputID]*Output, err error)
+	TransactionHistory(addresses ...address.Address) (history TransactionHistory, err error)
 	SendTransaction(transaction *ledgerstate.Transaction) (err error)
 	RequestFaucetFunds(address address.Address) (err error)
 	GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error)
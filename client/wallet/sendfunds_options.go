@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"errors"
+	"time"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -62,6 +63,27 @@ func Remainder(addr address.Address) SendFundsOption {
 	}
 }
 
+// TimeLock is an option for the SendFunds call that prevents the destinations from spending the sent funds before the
+// given time.
+func TimeLock(until time.Time) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		options.TimeLock = until
+
+		return nil
+	}
+}
+
+// FallbackOptions is an option for the SendFunds call that allows the given fallback address to reclaim the sent funds
+// if the destinations did not spend them before the given deadline.
+func FallbackOptions(addr address.Address, deadline time.Time) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		options.FallbackAddress = addr
+		options.FallbackDeadline = deadline
+
+		return nil
+	}
+}
+
 // AccessManaPledgeID is an option for SendFunds call that defines the nodeID to pledge access mana to.
 func AccessManaPledgeID(nodeID string) SendFundsOption {
 	return func(options *sendFundsOptions) error {
@@ -78,12 +100,54 @@ func ConsensusManaPledgeID(nodeID string) SendFundsOption {
 	}
 }
 
+// BurnAssets is an option for the SendFunds call that uncolors the given amount of tokens of the given Color back to
+// IOTA, which permanently destroys the colored supply. The resulting IOTA are sent to the remainder address.
+func BurnAssets(color ledgerstate.Color, amount uint64) SendFundsOption {
+	if color == ledgerstate.ColorIOTA || color == ledgerstate.ColorMint {
+		return optionError(errors.New("only colored tokens can be burned"))
+	}
+
+	if amount == 0 {
+		return optionError(errors.New("the amount of burned tokens needs to be larger than 0"))
+	}
+
+	return func(options *sendFundsOptions) error {
+		if options.BurnedFunds == nil {
+			options.BurnedFunds = make(map[ledgerstate.Color]uint64)
+		}
+
+		options.BurnedFunds[color] += amount
+
+		return nil
+	}
+}
+
+// AssetMetadata is an option for the SendFunds call that attaches the given metadata to the transaction so that the
+// node can index it for the Colors that are minted by the transaction.
+func AssetMetadata(assetMetadata *ledgerstate.AssetMetadata) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		options.AssetMetadata = assetMetadata
+
+		return nil
+	}
+}
+
 // sendFundsOptions is a struct that is used to aggregate the optional parameters provided in the SendFunds call.
 type sendFundsOptions struct {
 	Destinations          map[address.Address]map[ledgerstate.Color]uint64
 	RemainderAddress      address.Address
 	AccessManaPledgeID    string
 	ConsensusManaPledgeID string
+	TimeLock              time.Time
+	FallbackAddress       address.Address
+	FallbackDeadline      time.Time
+	BurnedFunds           map[ledgerstate.Color]uint64
+	AssetMetadata         *ledgerstate.AssetMetadata
+}
+
+// lockedDestinations returns true if the funds that are sent to the destinations are subject to additional conditions.
+func (s *sendFundsOptions) lockedDestinations() bool {
+	return !s.TimeLock.IsZero() || !s.FallbackDeadline.IsZero()
 }
 
 // buildSendFundsOptions is a utility function that constructs the sendFundsOptions.
@@ -99,11 +163,21 @@ func buildSendFundsOptions(options ...SendFundsOption) (result *sendFundsOptions
 	}
 
 	// sanitize parameters
-	if len(result.Destinations) == 0 {
+	if len(result.Destinations) == 0 && len(result.BurnedFunds) == 0 {
 		err = errors.New("you need to provide at least one Destination for a valid transfer to be issued")
 
 		return
 	}
+	if len(result.BurnedFunds) != 0 && result.lockedDestinations() {
+		err = errors.New("burning assets can not be combined with locked destinations")
+
+		return
+	}
+	if !result.FallbackDeadline.IsZero() && !result.FallbackDeadline.After(result.TimeLock) {
+		err = errors.New("the fallback deadline needs to be after the timelock")
+
+		return
+	}
 
 	return
 }
@@ -0,0 +1,39 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// TransactionHistoryEntry represents a Transaction that credits or debits at least one of the addresses of the wallet.
+type TransactionHistoryEntry struct {
+	TransactionID     ledgerstate.TransactionID
+	Timestamp         time.Time
+	InclusionState    InclusionState
+	CreditedAddresses []address.Address
+	DebitedAddresses  []address.Address
+}
+
+// Credited returns true if the Transaction creates at least one output on the addresses of the wallet.
+func (t *TransactionHistoryEntry) Credited() bool {
+	return len(t.CreditedAddresses) != 0
+}
+
+// Debited returns true if the Transaction consumes at least one output of the addresses of the wallet.
+func (t *TransactionHistoryEntry) Debited() bool {
+	return len(t.DebitedAddresses) != 0
+}
+
+// TransactionHistory represents the list of Transactions that credit or debit the addresses of the wallet ordered from
+// the newest to the oldest Transaction.
+type TransactionHistory []*TransactionHistoryEntry
+
+// Sort sorts the TransactionHistory from the newest to the oldest Transaction.
+func (t TransactionHistory) Sort() {
+	sort.Slice(t, func(i, j int) bool {
+		return ledgerstate.NewAddressHistoryCursor(t[i].Timestamp, t[i].TransactionID).Before(ledgerstate.NewAddressHistoryCursor(t[j].Timestamp, t[j].TransactionID))
+	})
+}
@@ -120,6 +120,9 @@ func (wallet *Wallet) SendFunds(options ...SendFundsOption) (tx *ledgerstate.Tra
 	inputs, consumedFunds := wallet.buildInputs(consumedOutputs)
 	outputs := wallet.buildOutputs(sendFundsOptions, consumedFunds)
 	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), accessPledgeNodeID, consensusPledgeNodeID, inputs, outputs)
+	if sendFundsOptions.AssetMetadata != nil {
+		txEssence.SetPayload(sendFundsOptions.AssetMetadata)
+	}
 	outputsByID := consumedOutputs.OutputsByID()
 
 	unlockBlocks := make([]ledgerstate.UnlockBlock, len(inputs))
@@ -175,6 +178,7 @@ func (wallet *Wallet) CreateAsset(asset Asset) (assetColor ledgerstate.Color, er
 
 	tx, err := wallet.SendFunds(
 		Destination(wallet.ReceiveAddress(), asset.Amount, ledgerstate.ColorMint),
+		AssetMetadata(ledgerstate.NewAssetMetadata(asset.Name, asset.Symbol, uint8(asset.Precision), asset.Amount)),
 	)
 	if err != nil {
 		return
@@ -203,6 +207,11 @@ func (wallet *Wallet) CreateAsset(asset Asset) (assetColor ledgerstate.Color, er
 	return
 }
 
+// DestroyAssets burns the given amount of colored tokens by uncoloring them back to IOTA.
+func (wallet *Wallet) DestroyAssets(color ledgerstate.Color, amount uint64) (tx *ledgerstate.Transaction, err error) {
+	return wallet.SendFunds(BurnAssets(color, amount))
+}
+
 // AssetRegistry return the internal AssetRegistry instance of the wallet.
 func (wallet *Wallet) AssetRegistry() *AssetRegistry {
 	return wallet.assetRegistry
@@ -228,6 +237,12 @@ func (wallet *Wallet) UnspentOutputs() map[address.Address]map[ledgerstate.Outpu
 	return wallet.unspentOutputManager.UnspentOutputs()
 }
 
+// TransactionHistory returns the Transactions that credit or debit the addresses of the wallet ordered from the
+// newest to the oldest Transaction. It requires the connected node to index the address history.
+func (wallet *Wallet) TransactionHistory() (history TransactionHistory, err error) {
+	return wallet.connector.TransactionHistory(wallet.addressManager.Addresses()...)
+}
+
 // RequestFaucetFunds requests some funds from the faucet for testing purposes.
 func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error) {
 	if len(waitForConfirmation) == 0 || !waitForConfirmation[0] {
@@ -350,6 +365,9 @@ func (wallet *Wallet) determineOutputsToConsume(sendFundsOptions *sendFundsOptio
 			requiredFunds[color] += amount
 		}
 	}
+	for color, amount := range sendFundsOptions.BurnedFunds {
+		requiredFunds[color] += amount
+	}
 
 	// refresh balances so we get the latest changes
 	if err = wallet.unspentOutputManager.Refresh(); err != nil {
@@ -466,8 +484,18 @@ func (wallet *Wallet) buildOutputs(sendFundsOptions *sendFundsOptions, consumedF
 		}
 	}
 
-	// build outputs for remainder
-	if len(consumedFunds) != 0 {
+	// uncolor the burned funds so they end up in the remainder
+	for color, amount := range sendFundsOptions.BurnedFunds {
+		consumedFunds[color] -= amount
+		if consumedFunds[color] == 0 {
+			delete(consumedFunds, color)
+		}
+
+		consumedFunds[ledgerstate.ColorIOTA] += amount
+	}
+
+	// build outputs for remainder (the remainder of locked destinations needs to stay spendable by us)
+	if len(consumedFunds) != 0 && !sendFundsOptions.lockedDestinations() {
 		if _, addressExists := outputsByColor[sendFundsOptions.RemainderAddress]; !addressExists {
 			outputsByColor[sendFundsOptions.RemainderAddress] = make(map[ledgerstate.Color]uint64)
 		}
@@ -481,9 +509,20 @@ func (wallet *Wallet) buildOutputs(sendFundsOptions *sendFundsOptions, consumedF
 	var outputsSlice []ledgerstate.Output
 	for addr, outputs := range outputsByColor {
 		coloredBalances := ledgerstate.NewColoredBalances(outputs)
-		output := ledgerstate.NewSigLockedColoredOutput(coloredBalances, addr.Address())
+		if !sendFundsOptions.lockedDestinations() {
+			outputsSlice = append(outputsSlice, ledgerstate.NewSigLockedColoredOutput(coloredBalances, addr.Address()))
+			continue
+		}
+
+		output := ledgerstate.NewExtendedLockedOutput(coloredBalances, addr.Address()).WithTimeLock(sendFundsOptions.TimeLock)
+		if !sendFundsOptions.FallbackDeadline.IsZero() {
+			output = output.WithFallbackOptions(sendFundsOptions.FallbackAddress.Address(), sendFundsOptions.FallbackDeadline)
+		}
 		outputsSlice = append(outputsSlice, output)
 	}
+	if len(consumedFunds) != 0 && sendFundsOptions.lockedDestinations() {
+		outputsSlice = append(outputsSlice, ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(consumedFunds), sendFundsOptions.RemainderAddress.Address()))
+	}
 	outputs = ledgerstate.NewOutputs(outputsSlice...)
 
 	return
@@ -3,17 +3,20 @@ package wallet
 import (
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/hive.go/bitmask"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	walletaddr "github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
 )
 
 func TestWallet_SendFunds(t *testing.T) {
@@ -77,6 +80,87 @@ func TestWallet_SendFunds(t *testing.T) {
 			},
 		},
 
+		// test if a time locked transfer with fallback options can be created
+		{
+			name: "timeLockedTransfer",
+			parameters: []SendFundsOption{
+				Destination(receiverSeed.Address(0), 1999),
+				TimeLock(time.Unix(1000, 0)),
+				FallbackOptions(senderSeed.Address(1), time.Unix(2000, 0)),
+			},
+			validator: func(t *testing.T, tx *ledgerstate.Transaction, err error) {
+				assert.False(t, tx == nil, "there should be a transaction created")
+				assert.Nil(t, err)
+
+				lockedOutputs := 0
+				for _, output := range tx.Essence().Outputs() {
+					extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput)
+					if !isExtendedLockedOutput {
+						assert.NotEqual(t, receiverSeed.Address(0).Address().Bytes(), output.Address().Bytes(), "the remainder should not be locked")
+						continue
+					}
+
+					lockedOutputs++
+					assert.Equal(t, receiverSeed.Address(0).Address().Bytes(), extendedLockedOutput.Address().Bytes())
+					assert.Equal(t, senderSeed.Address(1).Address().Bytes(), extendedLockedOutput.FallbackAddress().Bytes())
+					assert.True(t, extendedLockedOutput.TimeLock().Equal(time.Unix(1000, 0)))
+					assert.True(t, extendedLockedOutput.FallbackDeadline().Equal(time.Unix(2000, 0)))
+				}
+				assert.Equal(t, 1, lockedOutputs)
+			},
+		},
+
+		// test if a fallback deadline before the timelock triggers an error
+		{
+			name: "invalidFallbackDeadline",
+			parameters: []SendFundsOption{
+				Destination(receiverSeed.Address(0), 1999),
+				TimeLock(time.Unix(2000, 0)),
+				FallbackOptions(senderSeed.Address(1), time.Unix(1000, 0)),
+			},
+			validator: func(t *testing.T, tx *ledgerstate.Transaction, err error) {
+				assert.True(t, tx == nil, "the transaction should be nil")
+				assert.Error(t, err)
+				assert.Equal(t, "the fallback deadline needs to be after the timelock", err.Error(), "the error message is wrong")
+			},
+		},
+
+		// test if colored coins can be burned by uncoloring them back to IOTA
+		{
+			name: "burnAssets",
+			parameters: []SendFundsOption{
+				BurnAssets(ledgerstate.Color{3}, 1000),
+			},
+			validator: func(t *testing.T, tx *ledgerstate.Transaction, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, tx)
+
+				createdBalances := make(map[ledgerstate.Color]uint64)
+				for _, output := range tx.Essence().Outputs() {
+					output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+						createdBalances[color] += balance
+						return true
+					})
+				}
+				assert.Equal(t, uint64(338), createdBalances[ledgerstate.Color{3}])
+				assert.Equal(t, uint64(1337+663+1000), createdBalances[ledgerstate.ColorIOTA])
+			},
+		},
+
+		// test if burning assets with locked destinations triggers an error
+		{
+			name: "burnAssetsWithLockedDestinations",
+			parameters: []SendFundsOption{
+				Destination(receiverSeed.Address(0), 1999),
+				TimeLock(time.Unix(1000, 0)),
+				BurnAssets(ledgerstate.Color{3}, 1000),
+			},
+			validator: func(t *testing.T, tx *ledgerstate.Transaction, err error) {
+				assert.True(t, tx == nil, "the transaction should be nil")
+				assert.Error(t, err)
+			},
+		},
+
 		// test if a valid transaction having a colored coin can be created
 		{
 			name: "validColoredTransfer",
@@ -88,6 +172,28 @@ func TestWallet_SendFunds(t *testing.T) {
 				assert.Nil(t, err)
 			},
 		},
+
+		// test if the metadata of an asset is attached to the minting transaction
+		{
+			name: "assetMetadata",
+			parameters: []SendFundsOption{
+				Destination(receiverSeed.Address(0), 1200, ledgerstate.ColorMint),
+				AssetMetadata(ledgerstate.NewAssetMetadata("Test", "TST", 2, 1200)),
+			},
+			validator: func(t *testing.T, tx *ledgerstate.Transaction, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, tx)
+
+				restoredTransaction, _, err := ledgerstate.TransactionFromBytes(tx.Bytes())
+				require.NoError(t, err)
+				assetMetadata, isAssetMetadata := restoredTransaction.Essence().Payload().(*ledgerstate.AssetMetadata)
+				require.True(t, isAssetMetadata)
+				assert.Equal(t, "Test", assetMetadata.Name())
+				assert.Equal(t, "TST", assetMetadata.Symbol())
+				assert.Equal(t, uint8(2), assetMetadata.Precision())
+				assert.Equal(t, uint64(1200), assetMetadata.Supply())
+			},
+		},
 	}
 
 	// execute sub-tests and hand in the results to the validator function
@@ -181,6 +287,10 @@ func (connector *mockConnector) RequestFaucetFunds(addr walletaddr.Address) (err
 	return
 }
 
+func (connector *mockConnector) TransactionHistory(addresses ...walletaddr.Address) (history TransactionHistory, err error) {
+	return
+}
+
 func (connector *mockConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
 	// mark outputs as spent
 	//for _, input := range tx.Essence().Inputs() {
@@ -256,3 +366,39 @@ func (connector *mockConnector) UnspentOutputs(addresses ...walletaddr.Address)
 
 	return
 }
+
+func TestUnlockableNow(t *testing.T) {
+	seed := walletseed.NewSeed()
+	recipient, fallback := seed.Address(0), seed.Address(1)
+
+	now := time.Now()
+	timelock := now.Add(time.Hour)
+	deadline := now.Add(2 * time.Hour)
+	balances := ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100})
+	output := webapi_value.OutputID{
+		Type:    ledgerstate.ExtendedLockedOutputType,
+		Address: recipient.Address().Base58(),
+		Conditions: &webapi_value.OutputConditions{
+			FallbackAddress:  fallback.Address().Base58(),
+			FallbackDeadline: deadline,
+			TimeLock:         timelock,
+		},
+	}
+
+	for _, testCase := range []struct {
+		addr       address.Address
+		now        time.Time
+		unlockable bool
+	}{
+		{recipient, now, false},
+		{fallback, now, false},
+		{recipient, timelock, true},
+		{fallback, timelock, false},
+		{recipient, deadline, false},
+		{fallback, deadline, true},
+	} {
+		unlockable, err := unlockableNow(output, balances, testCase.addr, testCase.now)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.unlockable, unlockable)
+	}
+}
@@ -1,10 +1,13 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
 )
 
 // WebConnector implements a connector that uses the web API to connect to a node to implement the required functions
@@ -59,6 +62,7 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 	}
 
 	// build result
+	now := time.Now()
 	unspentOutputs = make(map[address.Address]map[ledgerstate.OutputID]*Output)
 	for _, unspentOutput := range response.UnspentOutputs {
 		// lookup wallet address from raw address
@@ -85,6 +89,17 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 			}
 			balances := ledgerstate.NewColoredBalances(balancesByColor)
 
+			// skip outputs that are timelocked or that can only be unlocked by the fallback address at the moment
+			unlockable, unlockableErr := unlockableNow(output, balances, addr, now)
+			if unlockableErr != nil {
+				err = unlockableErr
+
+				return
+			}
+			if !unlockable {
+				continue
+			}
+
 			// build output
 			walletOutput := &Output{
 				Address:  addr,
@@ -113,6 +128,86 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 	return
 }
 
+// unlockableNow returns true if the given address is able to unlock the given output at the given time. The node
+// returns ExtendedLockedOutputs for both their address and their fallback address, so the conditions of the output
+// decide which of them can currently spend it.
+func unlockableNow(output webapi_value.OutputID, balances *ledgerstate.ColoredBalances, addr address.Address, now time.Time) (unlockable bool, err error) {
+	if output.Type != ledgerstate.ExtendedLockedOutputType || output.Conditions == nil {
+		return true, nil
+	}
+
+	outputAddress, err := ledgerstate.AddressFromBase58EncodedString(output.Address)
+	if err != nil {
+		return false, err
+	}
+	extendedLockedOutput := ledgerstate.NewExtendedLockedOutput(balances, outputAddress).WithTimeLock(output.Conditions.TimeLock)
+	if output.Conditions.FallbackAddress != "" {
+		fallbackAddress, fallbackErr := ledgerstate.AddressFromBase58EncodedString(output.Conditions.FallbackAddress)
+		if fallbackErr != nil {
+			return false, fallbackErr
+		}
+		extendedLockedOutput = extendedLockedOutput.WithFallbackOptions(fallbackAddress, output.Conditions.FallbackDeadline)
+	}
+
+	unlockAddress := extendedLockedOutput.UnlockAddressNow(now)
+
+	return unlockAddress != nil && unlockAddress.Array() == addr.Address().Array(), nil
+}
+
+// TransactionHistory returns the Transactions that credit or debit the given addresses by paging through the address
+// history of the node.
+func (webConnector WebConnector) TransactionHistory(addresses ...address.Address) (history TransactionHistory, err error) {
+	entriesByTransactionID := make(map[ledgerstate.TransactionID]*TransactionHistoryEntry)
+	for _, addr := range addresses {
+		cursor := ""
+		for {
+			response, requestErr := webConnector.client.GetAddressHistory(addr.Address().Base58(), cursor, 0)
+			if requestErr != nil {
+				err = requestErr
+
+				return
+			}
+
+			for _, transaction := range response.Transactions {
+				transactionID, parseErr := ledgerstate.TransactionIDFromBase58(transaction.TransactionID)
+				if parseErr != nil {
+					err = parseErr
+
+					return
+				}
+
+				entry, entryExists := entriesByTransactionID[transactionID]
+				if !entryExists {
+					entry = &TransactionHistoryEntry{
+						TransactionID: transactionID,
+						Timestamp:     time.Unix(transaction.Timestamp, 0),
+						InclusionState: InclusionState{
+							Confirmed: transaction.InclusionState == ledgerstate.Confirmed.String(),
+							Rejected:  transaction.InclusionState == ledgerstate.Rejected.String(),
+						},
+					}
+					entriesByTransactionID[transactionID] = entry
+					history = append(history, entry)
+				}
+				if transaction.Credited {
+					entry.CreditedAddresses = append(entry.CreditedAddresses, addr)
+				}
+				if transaction.Debited {
+					entry.DebitedAddresses = append(entry.DebitedAddresses, addr)
+				}
+			}
+
+			if response.NextCursor == "" {
+				break
+			}
+			cursor = response.NextCursor
+		}
+	}
+	history.Sort()
+
+	return
+}
+
 // SendTransaction sends a new transaction to the network.
 func (webConnector WebConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
 	_, err = webConnector.client.SendTransaction(tx.Bytes())
@@ -96,27 +96,48 @@ func (f *ConsensusMechanism) Evaluate(messageID tangle.MessageID) {
 	f.onPayloadOpinionFormed(messageID, true)
 }
 
-// EvaluateTimestamp evaluates the honesty of the timestamp of the given Message.
+// EvaluateTimestamp evaluates the honesty of the timestamp of the given Message. If the level of knowledge about the
+// timestamp is too low to decide locally, the timestamp is handed over to the voter.
 func (f *ConsensusMechanism) EvaluateTimestamp(messageID tangle.MessageID) {
 	f.storage.StoreMessageMetadata(NewMessageMetadata(messageID))
-	f.storage.StoreTimestampOpinion(&TimestampOpinion{
+
+	timestampOpinion := &TimestampOpinion{
 		MessageID: messageID,
 		Value:     voter.Like,
 		LoK:       Two,
+	}
+	f.tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		f.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+			timestampOpinion = TimestampQuality(messageID, message.IssuingTime(), messageMetadata.ReceivedTime())
+		})
 	})
+	f.storage.StoreTimestampOpinion(timestampOpinion)
 
-	f.setEligibility(messageID)
-
-	f.setTimestampOpinionDone(messageID)
-
-	if f.messageDone(messageID) {
-		f.tangle.Utils.WalkMessageID(f.createMessageOpinion, tangle.MessageIDs{messageID}, true)
+	if timestampOpinion.LoK == One {
+		f.Events.Vote.Trigger(messageID.String(), vote.TimestampType, timestampOpinion.Value)
+		return
 	}
+
+	f.onTimestampOpinionFormed(messageID)
 }
 
 // ProcessVote allows an external voter to hand in the results of the voting process.
 func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
-	if ev.Ctx.Type == vote.ConflictType {
+	switch ev.Ctx.Type {
+	case vote.TimestampType:
+		messageID, err := tangle.NewMessageID(ev.ID)
+		if err != nil {
+			f.Events.Error.Trigger(err)
+			return
+		}
+
+		f.storage.StoreTimestampOpinion(&TimestampOpinion{
+			MessageID: messageID,
+			Value:     ev.Opinion,
+			LoK:       Two,
+		})
+		f.onTimestampOpinionFormed(messageID)
+	case vote.ConflictType:
 		transactionID, err := ledgerstate.TransactionIDFromBase58(ev.ID)
 		if err != nil {
 			f.Events.Error.Trigger(err)
@@ -135,6 +156,41 @@ func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
 	}
 }
 
+// ProcessVoteFailure allows an external voter to report that the voting on the given object could not be started or did
+// not finalize. The timestamp opinion falls back to the opinion of the voting (the local one if the voting could not
+// be started) with the highest level of knowledge, so the opinion of the Message is still formed.
+func (f *ConsensusMechanism) ProcessVoteFailure(ev *vote.OpinionEvent) {
+	if ev.Ctx.Type != vote.TimestampType {
+		return
+	}
+
+	messageID, err := tangle.NewMessageID(ev.ID)
+	if err != nil {
+		f.Events.Error.Trigger(err)
+		return
+	}
+
+	f.storage.StoreTimestampOpinion(&TimestampOpinion{
+		MessageID: messageID,
+		Value:     ev.Opinion,
+		LoK:       Three,
+	})
+	f.onTimestampOpinionFormed(messageID)
+}
+
+// TimestampOpinion returns the TimestampOpinion of the given Message (or nil if no opinion was formed, yet).
+func (f *ConsensusMechanism) TimestampOpinion(messageID tangle.MessageID) (timestampOpinion *TimestampOpinion) {
+	f.storage.TimestampOpinion(messageID).Consume(func(storedTimestampOpinion *TimestampOpinion) {
+		timestampOpinion = &TimestampOpinion{
+			MessageID: storedTimestampOpinion.MessageID,
+			Value:     storedTimestampOpinion.Value,
+			LoK:       storedTimestampOpinion.LoK,
+		}
+	})
+
+	return
+}
+
 // TransactionOpinionEssence returns the opinion essence of a given transactionID.
 func (f *ConsensusMechanism) TransactionOpinionEssence(transactionID ledgerstate.TransactionID) (opinion OpinionEssence) {
 	opinion = f.storage.OpinionEssence(transactionID)
@@ -209,7 +265,7 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 			if newOpinion.liked {
 				liked = voter.Like
 			}
-			f.Events.Vote.Trigger(transactionID.Base58(), liked)
+			f.Events.Vote.Trigger(transactionID.Base58(), vote.ConflictType, liked)
 			return
 
 		default:
@@ -241,13 +297,13 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 					opinion.SetLiked(true)
 					opinion.SetLevelOfKnowledge(One)
 					// trigger voting for this transactionID
-					f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
+					f.Events.Vote.Trigger(transactionID.Base58(), vote.ConflictType, voter.Like)
 					return
 				}
 				opinion.SetLevelOfKnowledge(One)
 				opinion.SetLiked(false)
 				// trigger voting for this transactionID
-				f.Events.Vote.Trigger(transactionID.Base58(), voter.Dislike)
+				f.Events.Vote.Trigger(transactionID.Base58(), vote.ConflictType, voter.Dislike)
 				return
 			}
 			opinion.SetLevelOfKnowledge(One)
@@ -261,7 +317,7 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 					opinion.SetLiked(true)
 					if f.tangle.LedgerState.TransactionConflicting(transactionID) {
 						// trigger voting for this transactionID
-						f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
+						f.Events.Vote.Trigger(transactionID.Base58(), vote.ConflictType, voter.Like)
 						return
 					}
 					opinion.SetLevelOfKnowledge(Two)
@@ -276,6 +332,17 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 	}, timestamp.Add(LikedThreshold))
 }
 
+// onTimestampOpinionFormed updates the eligibility of the given Message and marks its timestamp opinion as formed.
+func (f *ConsensusMechanism) onTimestampOpinionFormed(messageID tangle.MessageID) {
+	f.setEligibility(messageID)
+
+	f.setTimestampOpinionDone(messageID)
+
+	if f.messageDone(messageID) {
+		f.tangle.Utils.WalkMessageID(f.createMessageOpinion, tangle.MessageIDs{messageID}, true)
+	}
+}
+
 func (f *ConsensusMechanism) onPayloadOpinionFormed(messageID tangle.MessageID, liked bool) {
 	// set BranchLiked and BranchFinalized if this payload was a conflict
 	f.tangle.Utils.ComputeIfTransaction(messageID, func(transactionID ledgerstate.TransactionID) {
@@ -426,12 +493,12 @@ type ConsensusMechanismEvents struct {
 	// Error gets called when FCOB faces an error.
 	Error *events.Event
 
-	// Vote gets called when FCOB needs to vote.
+	// Vote gets called when FCOB needs to vote on a conflict or a timestamp.
 	Vote *events.Event
 }
 
 func voteEventHandler(handler interface{}, params ...interface{}) {
-	handler.(func(id string, initOpn voter.Opinion))(params[0].(string), params[1].(voter.Opinion))
+	handler.(func(id string, objectType vote.ObjectType, initOpn voter.Opinion))(params[0].(string), params[1].(vote.ObjectType), params[2].(voter.Opinion))
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
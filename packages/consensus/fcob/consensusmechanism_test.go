@@ -57,7 +57,7 @@ func TestOpinionFormer_Scenario2(t *testing.T) {
 		},
 	}
 
-	testTangle.LedgerState.LoadSnapshot(snapshot)
+	testTangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 
 	messages := make(map[string]*tangle.Message)
 	transactions := make(map[string]*ledgerstate.Transaction)
@@ -135,7 +135,7 @@ func TestOpinionFormer_Scenario2(t *testing.T) {
 	transactionLiked[transactions["6"].ID()] = true
 	transactionLiked[transactions["8"].ID()] = false
 
-	consensusProvider.Events.Vote.Attach(events.NewClosure(func(transactionID string, initialOpinion opinion.Opinion) {
+	consensusProvider.Events.Vote.Attach(events.NewClosure(func(transactionID string, _ vote.ObjectType, initialOpinion opinion.Opinion) {
 		t.Log("Voting requested for:", transactionID)
 		txID, err := ledgerstate.TransactionIDFromBase58(transactionID)
 		require.NoError(t, err)
@@ -224,7 +224,7 @@ func TestOpinionFormer(t *testing.T) {
 		},
 	}
 
-	testTangle.LedgerState.LoadSnapshot(snapshot)
+	testTangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 
 	input := ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
 	output := ledgerstate.NewSigLockedSingleOutput(10000, wallets[0].address)
@@ -265,7 +265,7 @@ func TestOpinionFormer(t *testing.T) {
 		wg.Done()
 	}))
 
-	consensusProvider.Events.Vote.Attach(events.NewClosure(func(transactionID string, initialOpinion opinion.Opinion) {
+	consensusProvider.Events.Vote.Attach(events.NewClosure(func(transactionID string, _ vote.ObjectType, initialOpinion opinion.Opinion) {
 		t.Log("Voting requested for:", transactionID)
 		consensusProvider.ProcessVote(&vote.OpinionEvent{
 			ID:      transactionID,
@@ -289,6 +289,98 @@ func TestOpinionFormer(t *testing.T) {
 	t.Log("Waiting shutdown..")
 }
 
+func TestTimestampVoting(t *testing.T) {
+	TimestampWindow = 1 * time.Minute
+	GratuitousNetworkDelay = 15 * time.Second
+
+	consensusProvider := NewConsensusMechanism()
+
+	testTangle := tangle.New(tangle.Consensus(consensusProvider))
+	defer testTangle.Shutdown()
+	testTangle.Setup()
+
+	// the timestamp of the message is too close to the edge of the timestamp window to decide locally
+	message := tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, []tangle.MessageID{}, time.Now().Add(-TimestampWindow-GratuitousNetworkDelay/2), ed25519.PublicKey{}, nextSequenceNumber(), payload.NewGenericDataPayload([]byte("A")), 0, ed25519.Signature{})
+
+	votes := make(chan opinion.Opinion, 1)
+	consensusProvider.Events.Vote.Attach(events.NewClosure(func(id string, objectType vote.ObjectType, initialOpinion opinion.Opinion) {
+		assert.Equal(t, vote.TimestampType, objectType)
+		assert.Equal(t, message.ID().String(), id)
+		votes <- initialOpinion
+	}))
+
+	opinionFormed := make(chan tangle.MessageID, 1)
+	testTangle.ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		opinionFormed <- messageID
+	}))
+
+	testTangle.Storage.StoreMessage(message)
+
+	select {
+	case initialOpinion := <-votes:
+		assert.Equal(t, opinion.Dislike, initialOpinion)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timestamp vote was not triggered")
+	}
+	assert.Equal(t, &TimestampOpinion{MessageID: message.ID(), Value: opinion.Dislike, LoK: One}, consensusProvider.TimestampOpinion(message.ID()))
+	assert.False(t, testTangle.ConsensusManager.MessageEligible(message.ID()))
+
+	// the message opinion is only formed after the voting finalized
+	consensusProvider.ProcessVote(&vote.OpinionEvent{
+		ID:      message.ID().String(),
+		Opinion: opinion.Like,
+		Ctx:     vote.Context{Type: vote.TimestampType},
+	})
+
+	select {
+	case messageID := <-opinionFormed:
+		assert.Equal(t, message.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message opinion was not formed")
+	}
+	assert.Equal(t, &TimestampOpinion{MessageID: message.ID(), Value: opinion.Like, LoK: Two}, consensusProvider.TimestampOpinion(message.ID()))
+	assert.True(t, testTangle.ConsensusManager.MessageEligible(message.ID()))
+}
+
+func TestTimestampVoting_Failure(t *testing.T) {
+	TimestampWindow = 1 * time.Minute
+	GratuitousNetworkDelay = 15 * time.Second
+
+	consensusProvider := NewConsensusMechanism()
+
+	testTangle := tangle.New(tangle.Consensus(consensusProvider))
+	defer testTangle.Shutdown()
+	testTangle.Setup()
+
+	message := tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, []tangle.MessageID{}, time.Now().Add(-TimestampWindow-GratuitousNetworkDelay/2), ed25519.PublicKey{}, nextSequenceNumber(), payload.NewGenericDataPayload([]byte("A")), 0, ed25519.Signature{})
+
+	// the voter fails to start the voting
+	consensusProvider.Events.Vote.Attach(events.NewClosure(func(id string, objectType vote.ObjectType, initialOpinion opinion.Opinion) {
+		consensusProvider.ProcessVoteFailure(&vote.OpinionEvent{
+			ID:      id,
+			Opinion: initialOpinion,
+			Ctx:     vote.Context{Type: objectType},
+		})
+	}))
+
+	opinionFormed := make(chan tangle.MessageID, 1)
+	testTangle.ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		opinionFormed <- messageID
+	}))
+
+	testTangle.Storage.StoreMessage(message)
+
+	// the message opinion is formed with the local timestamp opinion
+	select {
+	case messageID := <-opinionFormed:
+		assert.Equal(t, message.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message opinion was not formed")
+	}
+	assert.Equal(t, &TimestampOpinion{MessageID: message.ID(), Value: opinion.Dislike, LoK: Three}, consensusProvider.TimestampOpinion(message.ID()))
+	assert.False(t, testTangle.ConsensusManager.MessageEligible(message.ID()))
+}
+
 func TestDeriveOpinion(t *testing.T) {
 	now := time.Now()
 
@@ -1,26 +1,38 @@
 package database
 
 import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
 	"github.com/iotaledger/hive.go/kvstore"
-	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	badgerstore "github.com/iotaledger/hive.go/kvstore/badger"
 )
 
 type memDB struct {
-	kvstore.KVStore
+	*badger.DB
 }
 
-// NewMemDB returns a new in-memory (not persisted) DB object.
+// NewMemDB returns a new in-memory (not persisted) DB object. It is backed by an in-memory badger instance, so that its
+// stores iterate in key order like the ones of a persisting DB.
 func NewMemDB() (DB, error) {
-	return &memDB{KVStore: mapdb.NewMapDB()}, nil
+	opts := badger.DefaultOptions("").WithInMemory(true)
+	opts.Logger = nil
+	opts.EventLogging = false
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open in-memory DB: %w", err)
+	}
+
+	return &memDB{DB: db}, nil
 }
 
 func (db *memDB) NewStore() kvstore.KVStore {
-	return db.KVStore
+	return badgerstore.New(db.DB)
 }
 
 func (db *memDB) Close() error {
-	db.KVStore = nil
-	return nil
+	return db.DB.Close()
 }
 
 func (db *memDB) RequiresGC() bool {
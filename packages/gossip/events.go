@@ -15,6 +15,8 @@ type Events struct {
 	NeighborRemoved *events.Event
 	// Fired when a new message was received via the gossip protocol.
 	MessageReceived *events.Event
+	// Fired when a response to a sync request was received (after its messages triggered MessageReceived).
+	SyncResponseReceived *events.Event
 }
 
 // MessageReceivedEvent holds data about a message received event.
@@ -25,6 +27,20 @@ type MessageReceivedEvent struct {
 	Peer *peer.Peer
 }
 
+// SyncResponseReceivedEvent holds data about a sync response received event.
+type SyncResponseReceivedEvent struct {
+	// The id of the message that is synced.
+	Target []byte
+	// The total number of messages that the neighbor sends for the target.
+	Total int
+	// The number of messages in this response.
+	Count int
+	// The offset of the next page of the past cone that needs to be requested (0 if there is no next page).
+	Next int
+	// The sender of the response.
+	Peer *peer.Peer
+}
+
 func peerAndErrorCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*peer.Peer, error))(params[0].(*peer.Peer), params[1].(error))
 }
@@ -36,3 +52,7 @@ func neighborCaller(handler interface{}, params ...interface{}) {
 func messageReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*MessageReceivedEvent))(params[0].(*MessageReceivedEvent))
 }
+
+func syncResponseReceived(handler interface{}, params ...interface{}) {
+	handler.(func(*SyncResponseReceivedEvent))(params[0].(*SyncResponseReceivedEvent))
+}
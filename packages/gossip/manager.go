@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/workerpool"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
@@ -21,6 +22,14 @@ import (
 const (
 	// maxPacketSize defines the maximum packet size allowed for gossip and bufferedconn.
 	maxPacketSize = 65 * 1024
+
+	// maxMessageRequestBatchSize defines the maximum number of message ids in a MessageRequestBatch. Larger batches are
+	// truncated, so that a single packet can not trigger an unbounded amount of lookups.
+	maxMessageRequestBatchSize = 100
+
+	// maxSyncResponseMessages defines the maximum number of messages that are sent in response to a single sync request.
+	// The rest of the past cone needs to be requested with the returned continuation cursor.
+	maxSyncResponseMessages = 500
 )
 
 var (
@@ -34,10 +43,16 @@ var (
 // LoadMessageFunc defines a function that returns the message for the given id.
 type LoadMessageFunc func(messageId tangle.MessageID) ([]byte, error)
 
+// LoadPastConeFunc defines a function that returns at most limit messages starting at the given offset of the past cone
+// of the given target that are not in the past cone of the given known messages (in topological order) together with
+// the total size of that past cone.
+type LoadPastConeFunc func(target tangle.MessageID, knownMessageIDs tangle.MessageIDs, offset int, limit int) (messages [][]byte, total int, err error)
+
 // The Manager handles the connected neighbors.
 type Manager struct {
 	local           *peer.Local
 	loadMessageFunc LoadMessageFunc
+	options         *ManagerOptions
 	log             *logger.Logger
 	events          Events
 
@@ -54,29 +69,45 @@ type Manager struct {
 }
 
 // NewManager creates a new Manager.
-func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger, options ...ManagerOption) *Manager {
 	m := &Manager{
 		local:           local,
 		loadMessageFunc: f,
+		options:         newManagerOptions(options),
 		log:             log,
 		events: Events{
-			ConnectionFailed: events.NewEvent(peerAndErrorCaller),
-			NeighborAdded:    events.NewEvent(neighborCaller),
-			NeighborRemoved:  events.NewEvent(neighborCaller),
-			MessageReceived:  events.NewEvent(messageReceived),
+			ConnectionFailed:     events.NewEvent(peerAndErrorCaller),
+			NeighborAdded:        events.NewEvent(neighborCaller),
+			NeighborRemoved:      events.NewEvent(neighborCaller),
+			MessageReceived:      events.NewEvent(messageReceived),
+			SyncResponseReceived: events.NewEvent(syncResponseReceived),
 		},
 		srv:       nil,
 		neighbors: make(map[identity.ID]*Neighbor),
 	}
 
 	m.messageWorkerPool = workerpool.New(func(task workerpool.Task) {
-		m.processPacketMessage(task.Param(0).([]byte), task.Param(1).(*Neighbor))
+		switch data := task.Param(0).([]byte); pb.PacketType(data[0]) {
+		case pb.PacketMessageBatch:
+			m.processPacketMessageBatch(data, task.Param(1).(*Neighbor))
+		case pb.PacketSyncResponse:
+			m.processSyncResponse(data, task.Param(1).(*Neighbor))
+		default:
+			m.processPacketMessage(data, task.Param(1).(*Neighbor))
+		}
 
 		task.Return(nil)
 	}, workerpool.WorkerCount(messageWorkerCount), workerpool.QueueSize(messageWorkerQueueSize))
 
 	m.messageRequestWorkerPool = workerpool.New(func(task workerpool.Task) {
-		m.processMessageRequest(task.Param(0).([]byte), task.Param(1).(*Neighbor))
+		switch data := task.Param(0).([]byte); pb.PacketType(data[0]) {
+		case pb.PacketMessageRequestBatch:
+			m.processMessageRequestBatch(data, task.Param(1).(*Neighbor))
+		case pb.PacketSyncRequest:
+			m.processSyncRequest(data, task.Param(1).(*Neighbor))
+		default:
+			m.processMessageRequest(data, task.Param(1).(*Neighbor))
+		}
 
 		task.Return(nil)
 	}, workerpool.WorkerCount(messageRequestWorkerCount), workerpool.QueueSize(messageRequestWorkerQueueSize))
@@ -170,6 +201,52 @@ func (m *Manager) RequestMessage(messageID []byte, to ...identity.ID) {
 	m.send(marshal(msgReq), to...)
 }
 
+// RequestMessages requests the messages with the given ids from the neighbors. Neighbors that support batching receive
+// the ids in as few packets as possible while all other neighbors receive a separate request for every id (preceded by
+// an empty batch request that probes for the support of batching and that is ignored by older nodes).
+// If no peer is provided, all neighbors are queried.
+func (m *Manager) RequestMessages(messageIDs [][]byte, to ...identity.ID) {
+	if len(messageIDs) == 0 {
+		return
+	}
+
+	var batchPackets, singlePackets [][]byte
+	for _, nbr := range m.getNeighbors(to...) {
+		if nbr.SupportsBatching() {
+			if batchPackets == nil {
+				batchPackets = marshalRequestBatches(messageIDs)
+			}
+			m.write(nbr, batchPackets...)
+			continue
+		}
+
+		if singlePackets == nil {
+			singlePackets = make([][]byte, 0, len(messageIDs)+1)
+			singlePackets = append(singlePackets, marshal(&pb.MessageRequestBatch{}))
+			for _, messageID := range messageIDs {
+				singlePackets = append(singlePackets, marshal(&pb.MessageRequest{Id: messageID}))
+			}
+		}
+		m.write(nbr, singlePackets...)
+	}
+}
+
+// RequestSync asks the given neighbor for the messages in the past cone of the target that are not in the past cone of
+// the known messages, starting at the given offset. The messages are received in topological order and every received
+// SyncResponse triggers the SyncResponseReceived event. The neighbor only sends a page of the past cone and the last
+// response of a page contains the offset of the next page (neighbors drop requests that are sent more often than once
+// every SyncRequestInterval).
+func (m *Manager) RequestSync(target []byte, knownMessageIDs [][]byte, offset uint32, to identity.ID) error {
+	neighbors := m.getNeighborsByID([]identity.ID{to})
+	if len(neighbors) == 0 {
+		return ErrUnknownNeighbor
+	}
+
+	m.write(neighbors[0], marshal(&pb.SyncRequest{Target: target, Known: knownMessageIDs, Offset: offset}))
+
+	return nil
+}
+
 // SendMessage adds the given message the send queue of the neighbors.
 // The actual send then happens asynchronously. If no peer is provided, it is send to all neighbors.
 func (m *Manager) SendMessage(msgData []byte, to ...identity.ID) {
@@ -214,7 +291,13 @@ func (m *Manager) send(b []byte, to ...identity.ID) {
 	neighbors := m.getNeighbors(to...)
 
 	for _, nbr := range neighbors {
-		if _, err := nbr.Write(b); err != nil {
+		m.write(nbr, b)
+	}
+}
+
+func (m *Manager) write(nbr *Neighbor, packets ...[]byte) {
+	for _, packet := range packets {
+		if _, err := nbr.Write(packet); err != nil {
 			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
 		}
 	}
@@ -262,11 +345,11 @@ func (m *Manager) handlePacket(data []byte, nbr *Neighbor) error {
 	}
 
 	switch pb.PacketType(data[0]) {
-	case pb.PacketMessage:
+	case pb.PacketMessage, pb.PacketMessageBatch, pb.PacketSyncResponse:
 		if _, added := m.messageWorkerPool.TrySubmit(data, nbr); !added {
 			return fmt.Errorf("messageWorkerPool full: packet message discarded")
 		}
-	case pb.PacketMessageRequest:
+	case pb.PacketMessageRequest, pb.PacketMessageRequestBatch, pb.PacketSyncRequest:
 		if _, added := m.messageRequestWorkerPool.TrySubmit(data, nbr); !added {
 			return fmt.Errorf("messageRequestWorkerPool full: message request discarded")
 		}
@@ -291,6 +374,41 @@ func marshal(packet pb.Packet) []byte {
 	return append([]byte{byte(packetType)}, data...)
 }
 
+// marshalRequestBatches marshals the given ids into as few MessageRequestBatch packets as possible.
+func marshalRequestBatches(messageIDs [][]byte) (packets [][]byte) {
+	for start := 0; start < len(messageIDs); start += maxMessageRequestBatchSize {
+		end := start + maxMessageRequestBatchSize
+		if end > len(messageIDs) {
+			end = len(messageIDs)
+		}
+		for _, batch := range splitIntoBatches(messageIDs[start:end], 1) {
+			packets = append(packets, marshal(&pb.MessageRequestBatch{Ids: batch}))
+		}
+	}
+	return
+}
+
+// splitIntoBatches splits the given elements into batches whose marshaled packets do not exceed the maxPacketSize. The
+// headerSize contains the size of the packet type and of all fields except the repeated elements.
+func splitIntoBatches(elements [][]byte, headerSize int) (batches [][][]byte) {
+	batchSize := headerSize
+	batchStart := 0
+	for i, element := range elements {
+		elementSize := protowire.SizeTag(1) + protowire.SizeBytes(len(element))
+		if batchSize+elementSize > maxPacketSize && i > batchStart {
+			batches = append(batches, elements[batchStart:i])
+			batchSize = headerSize
+			batchStart = i
+		}
+		batchSize += elementSize
+	}
+	if batchStart < len(elements) {
+		batches = append(batches, elements[batchStart:])
+	}
+
+	return
+}
+
 // MessageWorkerPoolStatus returns the name and the load of the workerpool.
 func (m *Manager) MessageWorkerPoolStatus() (name string, load int) {
 	return "messageWorkerPool", m.messageWorkerPool.GetPendingQueueSize()
@@ -328,3 +446,169 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 	// send the loaded message directly to the neighbor
 	_, _ = nbr.Write(marshal(&pb.Message{Data: msgBytes}))
 }
+
+func (m *Manager) processPacketMessageBatch(data []byte, nbr *Neighbor) {
+	nbr.batchingSupported.Store(true)
+
+	packet := new(pb.MessageBatch)
+	if err := proto.Unmarshal(data[1:], packet); err != nil {
+		m.log.Debugw("error processing packet", "err", err)
+		return
+	}
+
+	for _, messageData := range packet.GetData() {
+		m.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: messageData, Peer: nbr.Peer})
+	}
+}
+
+func (m *Manager) processMessageRequestBatch(data []byte, nbr *Neighbor) {
+	nbr.batchingSupported.Store(true)
+
+	packet := new(pb.MessageRequestBatch)
+	if err := proto.Unmarshal(data[1:], packet); err != nil {
+		m.log.Debugw("invalid packet", "err", err)
+		return
+	}
+
+	// answer the probe for the support of batching
+	if len(packet.GetIds()) == 0 {
+		_, _ = nbr.Write(marshal(&pb.MessageBatch{}))
+		return
+	}
+
+	ids := packet.GetIds()
+	if len(ids) > maxMessageRequestBatchSize {
+		m.log.Debugw("truncating message request batch", "peer-id", nbr.ID(), "size", len(ids))
+		ids = ids[:maxMessageRequestBatchSize]
+	}
+
+	messages := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		msgID, _, err := tangle.MessageIDFromBytes(id)
+		if err != nil {
+			m.log.Debugw("invalid message id:", "err", err)
+			continue
+		}
+
+		msgBytes, err := m.loadMessageFunc(msgID)
+		if err != nil {
+			m.log.Debugw("error loading message", "msg-id", msgID, "err", err)
+			continue
+		}
+		messages = append(messages, msgBytes)
+	}
+
+	// send the loaded messages directly to the neighbor
+	for _, batch := range splitIntoBatches(messages, 1) {
+		_, _ = nbr.Write(marshal(&pb.MessageBatch{Data: batch}))
+	}
+}
+
+func (m *Manager) processSyncRequest(data []byte, nbr *Neighbor) {
+	if !nbr.allowSyncRequest() {
+		m.log.Debugw("dropping sync request that exceeds the rate limit", "peer-id", nbr.ID())
+		return
+	}
+
+	packet := new(pb.SyncRequest)
+	if err := proto.Unmarshal(data[1:], packet); err != nil {
+		m.log.Debugw("invalid packet", "err", err)
+		return
+	}
+
+	var pastCone [][]byte
+	var total int
+	if target, _, err := tangle.MessageIDFromBytes(packet.GetTarget()); err != nil {
+		m.log.Debugw("invalid message id:", "err", err)
+	} else if m.options.loadPastConeFunc != nil {
+		knownMessageIDs := make(tangle.MessageIDs, 0, len(packet.GetKnown()))
+		for _, id := range packet.GetKnown() {
+			if knownMessageID, _, err := tangle.MessageIDFromBytes(id); err == nil {
+				knownMessageIDs = append(knownMessageIDs, knownMessageID)
+			}
+		}
+
+		if pastCone, total, err = m.options.loadPastConeFunc(target, knownMessageIDs, int(packet.GetOffset()), maxSyncResponseMessages); err != nil {
+			m.log.Debugw("error loading past cone", "msg-id", target, "err", err)
+			pastCone, total = nil, 0
+		}
+	}
+
+	// an empty response tells the neighbor that there is nothing (more) to sync
+	if len(pastCone) == 0 {
+		_, _ = nbr.Write(marshal(&pb.SyncResponse{Target: packet.GetTarget(), Total: uint32(total), Offset: packet.GetOffset()}))
+		return
+	}
+
+	// the last response of the page contains the offset of the next page if the past cone is not complete yet
+	var next uint32
+	if end := int(packet.GetOffset()) + len(pastCone); end < total {
+		next = uint32(end)
+	}
+
+	// the target, the total, the offset and the next offset are repeated in every response
+	headerSize := 1 + protowire.SizeTag(1) + protowire.SizeBytes(len(packet.GetTarget())) + 3*(protowire.SizeTag(2)+protowire.SizeVarint(uint64(total)))
+	offset := packet.GetOffset()
+	batches := splitIntoBatches(pastCone, headerSize)
+	for i, batch := range batches {
+		response := &pb.SyncResponse{Target: packet.GetTarget(), Total: uint32(total), Data: batch, Offset: offset}
+		if i == len(batches)-1 {
+			response.Next = next
+		}
+		if _, err := nbr.Write(marshal(response)); err != nil {
+			m.log.Debugw("error sending sync response", "peer-id", nbr.ID(), "err", err)
+			return
+		}
+		offset += uint32(len(batch))
+	}
+}
+
+func (m *Manager) processSyncResponse(data []byte, nbr *Neighbor) {
+	packet := new(pb.SyncResponse)
+	if err := proto.Unmarshal(data[1:], packet); err != nil {
+		m.log.Debugw("error processing packet", "err", err)
+		return
+	}
+
+	for _, messageData := range packet.GetData() {
+		m.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: messageData, Peer: nbr.Peer})
+	}
+
+	m.events.SyncResponseReceived.Trigger(&SyncResponseReceivedEvent{
+		Target: packet.GetTarget(),
+		Total:  int(packet.GetTotal()),
+		Count:  len(packet.GetData()),
+		Next:   int(packet.GetNext()),
+		Peer:   nbr.Peer,
+	})
+}
+
+// region ManagerOptions ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManagerOptions is a container for the optional parameters of the Manager.
+type ManagerOptions struct {
+	loadPastConeFunc LoadPastConeFunc
+}
+
+func newManagerOptions(optionalOptions []ManagerOption) *ManagerOptions {
+	result := &ManagerOptions{}
+
+	for _, optionalOption := range optionalOptions {
+		optionalOption(result)
+	}
+
+	return result
+}
+
+// ManagerOption is a function which inits an option.
+type ManagerOption func(*ManagerOptions)
+
+// LoadPastCone creates an option which sets the function that is used to answer the sync requests of neighbors
+// (sync requests are answered with empty responses if it is not set).
+func LoadPastCone(f LoadPastConeFunc) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.loadPastConeFunc = f
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
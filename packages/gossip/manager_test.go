@@ -358,6 +358,178 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestMessageRequestBatch(t *testing.T) {
+	mgrA, closeA, peerA := newMockedManager(t, "A")
+	mgrB, closeB, peerB := newMockedManager(t, "B")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(peerB)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(peerA)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	// the first request is sent as single requests together with the probe for the support of batching
+	mgrA.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerB}).Times(4)
+
+	id1, id2 := tangle.MessageID{1}, tangle.MessageID{2}
+	mgrA.RequestMessages([][]byte{id1[:], id2[:]})
+	require.Eventually(t, func() bool {
+		return len(mgrA.AllNeighbors()) == 1 && mgrA.AllNeighbors()[0].SupportsBatching()
+	}, time.Second, graceTime)
+
+	// mgrA should eventually receive both messages in a single batch
+	mgrA.RequestMessages([][]byte{id1[:], id2[:]})
+	time.Sleep(graceTime)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
+func TestSplitIntoBatches(t *testing.T) {
+	elementSize := maxPacketSize / 4
+	elements := make([][]byte, 10)
+	for i := range elements {
+		elements[i] = make([]byte, elementSize)
+	}
+
+	batches := splitIntoBatches(elements, 1)
+	require.Len(t, batches, 4)
+	for _, batch := range batches {
+		packet := marshal(&pb.MessageBatch{Data: batch})
+		assert.LessOrEqual(t, len(packet), maxPacketSize)
+	}
+	assert.Len(t, batches[3], 1)
+
+	// elements that exceed the packet size on their own are still returned in a separate batch
+	assert.Len(t, splitIntoBatches([][]byte{make([]byte, maxPacketSize), {1}}, 1), 2)
+	assert.Empty(t, splitIntoBatches(nil, 1))
+}
+
+func TestMarshalRequestBatches(t *testing.T) {
+	messageIDs := make([][]byte, 2*maxMessageRequestBatchSize+1)
+	for i := range messageIDs {
+		messageID := tangle.MessageID{byte(i)}
+		messageIDs[i] = messageID[:]
+	}
+
+	packets := marshalRequestBatches(messageIDs)
+	require.Len(t, packets, 3)
+	for i, expectedSize := range []int{maxMessageRequestBatchSize, maxMessageRequestBatchSize, 1} {
+		packet := new(pb.MessageRequestBatch)
+		require.NoError(t, proto.Unmarshal(packets[i][1:], packet))
+		assert.Len(t, packet.GetIds(), expectedSize)
+	}
+}
+
+func TestSync(t *testing.T) {
+	// the past cone does not fit into a single packet nor into a single page
+	pastCone := [][]byte{make([]byte, maxPacketSize/2), make([]byte, maxPacketSize/2)}
+	for len(pastCone) <= maxSyncResponseMessages {
+		pastCone = append(pastCone, testMessageData)
+	}
+	target := tangle.MessageID{1}
+
+	mgrA, closeA, peerA := newMockedManager(t, "A")
+	mgrB, closeB, peerB := newMockedManager(t, "B", LoadPastCone(func(messageID tangle.MessageID, knownMessageIDs tangle.MessageIDs, offset int, limit int) ([][]byte, int, error) {
+		assert.Equal(t, target, messageID)
+		assert.Equal(t, tangle.MessageIDs{{2}}, knownMessageIDs)
+		page := pastCone[offset:]
+		if len(page) > limit {
+			page = page[:limit]
+		}
+		return page, len(pastCone), nil
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(peerB)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(peerA)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	known := tangle.MessageID{2}
+	var responsesMutex sync.Mutex
+	var received int
+	mgrA.Events().SyncResponseReceived.Attach(events.NewClosure(func(ev *SyncResponseReceivedEvent) {
+		responsesMutex.Lock()
+		defer responsesMutex.Unlock()
+
+		assert.Equal(t, target.Bytes(), ev.Target)
+		assert.Equal(t, len(pastCone), ev.Total)
+		assert.Equal(t, peerB, ev.Peer)
+		received += ev.Count
+
+		// the rest of the past cone is requested with the continuation cursor
+		if ev.Next != 0 {
+			assert.Equal(t, maxSyncResponseMessages, ev.Next)
+			assert.NoError(t, mgrA.RequestSync(target.Bytes(), [][]byte{known.Bytes()}, uint32(ev.Next), peerB.ID()))
+		}
+	}))
+
+	// mgrA should eventually receive the whole past cone
+	mgrA.On("messageReceived", mock.Anything).Times(len(pastCone))
+
+	require.NoError(t, mgrA.RequestSync(target.Bytes(), [][]byte{known.Bytes()}, 0, peerB.ID()))
+	require.Eventually(t, func() bool {
+		responsesMutex.Lock()
+		defer responsesMutex.Unlock()
+
+		return received == len(pastCone)
+	}, time.Second, graceTime)
+
+	assert.ErrorIs(t, mgrB.RequestSync(target.Bytes(), nil, 0, mgrB.local.ID()), ErrUnknownNeighbor)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
 func TestDropNeighbor(t *testing.T) {
 	mgrA, closeA, peerA := newTestManager(t, "A")
 	defer closeA()
@@ -424,7 +596,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Peer) {
+func newTestManager(t require.TestingT, name string, options ...ManagerOption) (*Manager, func(), *peer.Peer) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -442,7 +614,7 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	srv := server.ServeTCP(local, lis, l)
 
 	// start the actual gossipping
-	mgr := NewManager(local, loadTestMessage, l)
+	mgr := NewManager(local, loadTestMessage, l, options...)
 	mgr.Start(srv)
 
 	detach := func() {
@@ -453,8 +625,8 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	return mgr, detach, local.Peer
 }
 
-func newMockedManager(t *testing.T, name string) (*mockedManager, func(), *peer.Peer) {
-	mgr, detach, p := newTestManager(t, name)
+func newMockedManager(t *testing.T, name string, options ...ManagerOption) (*mockedManager, func(), *peer.Peer) {
+	mgr, detach, p := newTestManager(t, name, options...)
 	return mockManager(t, mgr), detach, p
 }
 
@@ -18,6 +18,11 @@ const (
 	neighborQueueSize        = 5000
 	maxNumReadErrors         = 10
 	droppedMessagesThreshold = 1000
+
+	// SyncRequestInterval defines the interval in which a neighbor regains the permission to send a sync request.
+	SyncRequestInterval = 100 * time.Millisecond
+	// maxSyncRequestBurst defines the number of sync requests a neighbor can send at once before being rate limited.
+	maxSyncRequestBurst = 10
 )
 
 // Neighbor describes the established gossip connection to another peer.
@@ -29,6 +34,14 @@ type Neighbor struct {
 	queue           chan []byte
 	messagesDropped atomic.Int32
 
+	// batchingSupported is set as soon as the neighbor sent a batch packet (older nodes do not support batching).
+	batchingSupported atomic.Bool
+
+	// the sync requests that the neighbor is allowed to send (refilled every SyncRequestInterval)
+	syncRequestTokens     float64
+	syncRequestLastRefill time.Time
+	syncRequestMutex      sync.Mutex
+
 	wg             sync.WaitGroup
 	closing        chan struct{}
 	disconnectOnce sync.Once
@@ -56,6 +69,8 @@ func NewNeighbor(peer *peer.Peer, conn net.Conn, log *logger.Logger) *Neighbor {
 		queue:                 make(chan []byte, neighborQueueSize),
 		closing:               make(chan struct{}),
 		connectionEstablished: time.Now(),
+		syncRequestTokens:     maxSyncRequestBurst,
+		syncRequestLastRefill: time.Now(),
 	}
 }
 
@@ -83,6 +98,31 @@ func (n *Neighbor) Close() error {
 	return err
 }
 
+// SupportsBatching returns true if the neighbor is known to understand the batched request and response packets.
+func (n *Neighbor) SupportsBatching() bool {
+	return n.batchingSupported.Load()
+}
+
+// allowSyncRequest returns true if the neighbor did not exceed the rate limit of its sync requests and consumes one of
+// its allowed requests.
+func (n *Neighbor) allowSyncRequest() bool {
+	n.syncRequestMutex.Lock()
+	defer n.syncRequestMutex.Unlock()
+
+	now := time.Now()
+	n.syncRequestTokens += float64(now.Sub(n.syncRequestLastRefill)) / float64(SyncRequestInterval)
+	if n.syncRequestTokens > maxSyncRequestBurst {
+		n.syncRequestTokens = maxSyncRequestBurst
+	}
+	n.syncRequestLastRefill = now
+
+	if n.syncRequestTokens < 1 {
+		return false
+	}
+	n.syncRequestTokens--
+	return true
+}
+
 // IsOutbound returns true if the neighbor is an outbound neighbor.
 func (n *Neighbor) IsOutbound() bool {
 	return GetAddress(n.Peer) == n.RemoteAddr().String()
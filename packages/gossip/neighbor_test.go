@@ -120,6 +120,21 @@ func TestNeighborParallelWrite(t *testing.T) {
 	assert.Eventually(t, done, time.Second, 10*time.Millisecond)
 }
 
+func TestNeighborAllowSyncRequest(t *testing.T) {
+	a, _, teardown := newPipe()
+	defer teardown()
+
+	n := newTestNeighbor("A", a)
+	for i := 0; i < maxSyncRequestBurst; i++ {
+		assert.True(t, n.allowSyncRequest())
+	}
+	assert.False(t, n.allowSyncRequest())
+
+	time.Sleep(SyncRequestInterval)
+	assert.True(t, n.allowSyncRequest())
+	assert.False(t, n.allowSyncRequest())
+}
+
 func newTestNeighbor(name string, conn net.Conn) *Neighbor {
 	return NewNeighbor(newTestPeer(name, conn), conn, log.Named(name))
 }
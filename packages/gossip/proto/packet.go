@@ -11,6 +11,10 @@ type PacketType uint
 const (
 	PacketMessage PacketType = 20 + iota
 	PacketMessageRequest
+	PacketMessageBatch
+	PacketMessageRequestBatch
+	PacketSyncRequest
+	PacketSyncResponse
 )
 
 // Packet extends the proto.Message interface with additional util functions.
@@ -34,3 +38,27 @@ func (m *MessageRequest) Name() string { return "message_request" }
 
 // Type returns the packet type id of the message request packet.
 func (m *MessageRequest) Type() PacketType { return PacketMessageRequest }
+
+// Name returns the name of the message batch packet.
+func (m *MessageBatch) Name() string { return "message_batch" }
+
+// Type returns the packet type id of the message batch packet.
+func (m *MessageBatch) Type() PacketType { return PacketMessageBatch }
+
+// Name returns the name of the message request batch packet.
+func (m *MessageRequestBatch) Name() string { return "message_request_batch" }
+
+// Type returns the packet type id of the message request batch packet.
+func (m *MessageRequestBatch) Type() PacketType { return PacketMessageRequestBatch }
+
+// Name returns the name of the sync request packet.
+func (m *SyncRequest) Name() string { return "sync_request" }
+
+// Type returns the packet type id of the sync request packet.
+func (m *SyncRequest) Type() PacketType { return PacketSyncRequest }
+
+// Name returns the name of the sync response packet.
+func (m *SyncResponse) Name() string { return "sync_response" }
+
+// Type returns the packet type id of the sync response packet.
+func (m *SyncResponse) Type() PacketType { return PacketSyncResponse }
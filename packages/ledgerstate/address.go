@@ -1,6 +1,9 @@
 package ledgerstate
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -19,6 +22,12 @@ const (
 
 	// BLSAddressType represents an Address secured by the BLS signature scheme.
 	BLSAddressType
+
+	// AliasAddressType represents an Address that is controlled by the current state of an AliasOutput.
+	AliasAddressType
+
+	// ThresholdAddressType represents an Address secured by a threshold of ED25519 signatures of a set of public keys.
+	ThresholdAddressType
 )
 
 // AddressLength contains the length of an address (type length = 1, digest length = 32).
@@ -32,6 +41,8 @@ func (a AddressType) String() string {
 	return [...]string{
 		"AddressTypeED25519",
 		"AddressTypeBLS",
+		"AddressTypeAlias",
+		"AddressTypeThreshold",
 	}[a]
 }
 
@@ -104,6 +115,10 @@ func AddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address Addre
 		return ED25519AddressFromMarshalUtil(marshalUtil)
 	case BLSAddressType:
 		return BLSAddressFromMarshalUtil(marshalUtil)
+	case AliasAddressType:
+		return AliasAddressFromMarshalUtil(marshalUtil)
+	case ThresholdAddressType:
+		return ThresholdAddressFromMarshalUtil(marshalUtil)
 	default:
 		err = xerrors.Errorf("unsupported address type (%X): %w", addressType, cerrors.ErrParseBytesFailed)
 		return
@@ -341,3 +356,281 @@ func (b *BLSAddress) String() string {
 var _ Address = &BLSAddress{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasAddress /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasAddress represents the stable identity of an alias chain. It is derived from the OutputID of the AliasOutput that
+// created the chain and can only be unlocked by unlocking the current AliasOutput of the chain in the same Transaction.
+type AliasAddress struct {
+	digest []byte
+}
+
+// NewAliasAddress creates a new AliasAddress from the given data (the bytes of the OutputID of the origin AliasOutput).
+func NewAliasAddress(data []byte) *AliasAddress {
+	digest := blake2b.Sum256(data)
+
+	return &AliasAddress{
+		digest: digest[:],
+	}
+}
+
+// AliasAddressFromBytes unmarshals an AliasAddress from a sequence of bytes.
+func AliasAddressFromBytes(bytes []byte) (address *AliasAddress, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if address, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasAddress from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasAddressFromBase58EncodedString creates an AliasAddress from a base58 encoded string.
+func AliasAddressFromBase58EncodedString(base58String string) (address *AliasAddress, err error) {
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = xerrors.Errorf("error while decoding base58 encoded AliasAddress (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if address, _, err = AliasAddressFromBytes(bytes); err != nil {
+		err = xerrors.Errorf("failed to parse AliasAddress from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AliasAddressFromMarshalUtil parses an AliasAddress from the given MarshalUtil.
+func AliasAddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address *AliasAddress, err error) {
+	addressType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("error parsing AddressType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if AddressType(addressType) != AliasAddressType {
+		err = xerrors.Errorf("invalid AddressType (%X): %w", addressType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	address = &AliasAddress{}
+	if address.digest, err = marshalUtil.ReadBytes(32); err != nil {
+		err = xerrors.Errorf("error parsing digest (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Type returns the AddressType of the Address.
+func (a *AliasAddress) Type() AddressType {
+	return AliasAddressType
+}
+
+// Digest returns the hashed version of the OutputID of the origin AliasOutput.
+func (a *AliasAddress) Digest() []byte {
+	return a.digest
+}
+
+// Clone creates a copy of the Address.
+func (a *AliasAddress) Clone() Address {
+	clonedDigest := make([]byte, len(a.digest))
+	copy(clonedDigest, a.digest)
+
+	return &AliasAddress{
+		digest: clonedDigest,
+	}
+}
+
+// Bytes returns a marshaled version of the Address.
+func (a *AliasAddress) Bytes() []byte {
+	return byteutils.ConcatBytes([]byte{byte(AliasAddressType)}, a.digest)
+}
+
+// Array returns an array of bytes that contains the marshaled version of the Address.
+func (a *AliasAddress) Array() (array [AddressLength]byte) {
+	copy(array[:], a.Bytes())
+
+	return
+}
+
+// Base58 returns a base58 encoded version of the Address.
+func (a *AliasAddress) Base58() string {
+	return base58.Encode(a.Bytes())
+}
+
+// String returns a human readable version of the addresses for debug purposes.
+func (a *AliasAddress) String() string {
+	return stringify.Struct("AliasAddress",
+		stringify.StructField("Digest", a.Digest()),
+		stringify.StructField("Base58", a.Base58()),
+	)
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ Address = &AliasAddress{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ThresholdAddress /////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaxThresholdAddressKeys defines the maximum amount of public keys that a ThresholdAddress can commit to.
+const MaxThresholdAddressKeys = 32
+
+// ThresholdAddress represents an Address that is secured by m-of-n ED25519 signatures. It commits to the threshold and
+// the sorted set of public keys, which need to be revealed in the MultiSignatureUnlockBlock that unlocks it.
+type ThresholdAddress struct {
+	digest []byte
+}
+
+// NewThresholdAddress creates a new ThresholdAddress that requires threshold signatures of the given public keys.
+func NewThresholdAddress(threshold uint8, publicKeys []ed25519.PublicKey) (address *ThresholdAddress, err error) {
+	sortedPublicKeys, err := sortThresholdPublicKeys(threshold, publicKeys)
+	if err != nil {
+		return
+	}
+
+	address = &ThresholdAddress{
+		digest: thresholdAddressDigest(threshold, sortedPublicKeys),
+	}
+
+	return
+}
+
+// ThresholdAddressFromBytes unmarshals a ThresholdAddress from a sequence of bytes.
+func ThresholdAddressFromBytes(bytes []byte) (address *ThresholdAddress, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if address, err = ThresholdAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ThresholdAddress from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ThresholdAddressFromBase58EncodedString creates a ThresholdAddress from a base58 encoded string.
+func ThresholdAddressFromBase58EncodedString(base58String string) (address *ThresholdAddress, err error) {
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = xerrors.Errorf("error while decoding base58 encoded ThresholdAddress (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if address, _, err = ThresholdAddressFromBytes(bytes); err != nil {
+		err = xerrors.Errorf("failed to parse ThresholdAddress from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// ThresholdAddressFromMarshalUtil parses a ThresholdAddress from the given MarshalUtil.
+func ThresholdAddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address *ThresholdAddress, err error) {
+	addressType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("error parsing AddressType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if AddressType(addressType) != ThresholdAddressType {
+		err = xerrors.Errorf("invalid AddressType (%X): %w", addressType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	address = &ThresholdAddress{}
+	if address.digest, err = marshalUtil.ReadBytes(32); err != nil {
+		err = xerrors.Errorf("error parsing digest (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Type returns the AddressType of the Address.
+func (t *ThresholdAddress) Type() AddressType {
+	return ThresholdAddressType
+}
+
+// Digest returns the hashed version of the threshold and the public keys.
+func (t *ThresholdAddress) Digest() []byte {
+	return t.digest
+}
+
+// Clone creates a copy of the Address.
+func (t *ThresholdAddress) Clone() Address {
+	clonedDigest := make([]byte, len(t.digest))
+	copy(clonedDigest, t.digest)
+
+	return &ThresholdAddress{
+		digest: clonedDigest,
+	}
+}
+
+// Bytes returns a marshaled version of the Address.
+func (t *ThresholdAddress) Bytes() []byte {
+	return byteutils.ConcatBytes([]byte{byte(ThresholdAddressType)}, t.digest)
+}
+
+// Array returns an array of bytes that contains the marshaled version of the Address.
+func (t *ThresholdAddress) Array() (array [AddressLength]byte) {
+	copy(array[:], t.Bytes())
+
+	return
+}
+
+// Base58 returns a base58 encoded version of the Address.
+func (t *ThresholdAddress) Base58() string {
+	return base58.Encode(t.Bytes())
+}
+
+// String returns a human readable version of the addresses for debug purposes.
+func (t *ThresholdAddress) String() string {
+	return stringify.Struct("ThresholdAddress",
+		stringify.StructField("Digest", t.Digest()),
+		stringify.StructField("Base58", t.Base58()),
+	)
+}
+
+// sortThresholdPublicKeys is an internal utility function that validates the parameters of a ThresholdAddress and
+// returns a sorted copy of the public keys.
+func sortThresholdPublicKeys(threshold uint8, publicKeys []ed25519.PublicKey) (sortedPublicKeys []ed25519.PublicKey, err error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MaxThresholdAddressKeys {
+		err = xerrors.Errorf("amount of public keys (%d) needs to be between 1 and %d: %w", len(publicKeys), MaxThresholdAddressKeys, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if threshold == 0 || int(threshold) > len(publicKeys) {
+		err = xerrors.Errorf("threshold (%d) needs to be between 1 and the amount of public keys (%d): %w", threshold, len(publicKeys), cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	sortedPublicKeys = make([]ed25519.PublicKey, len(publicKeys))
+	copy(sortedPublicKeys, publicKeys)
+	sort.Slice(sortedPublicKeys, func(i, j int) bool {
+		return bytes.Compare(sortedPublicKeys[i][:], sortedPublicKeys[j][:]) < 0
+	})
+	for i := 1; i < len(sortedPublicKeys); i++ {
+		if sortedPublicKeys[i] == sortedPublicKeys[i-1] {
+			err = xerrors.Errorf("duplicate public key %s: %w", sortedPublicKeys[i], cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+
+	return
+}
+
+// thresholdAddressDigest is an internal utility function that hashes the threshold and the sorted public keys.
+func thresholdAddressDigest(threshold uint8, sortedPublicKeys []ed25519.PublicKey) []byte {
+	marshalUtil := marshalutil.New(1 + len(sortedPublicKeys)*ed25519.PublicKeySize).WriteUint8(threshold)
+	for _, publicKey := range sortedPublicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+	digest := blake2b.Sum256(marshalUtil.Bytes())
+
+	return digest[:]
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ Address = &ThresholdAddress{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
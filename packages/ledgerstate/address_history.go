@@ -0,0 +1,363 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+)
+
+// region AddressTransactionMapping ////////////////////////////////////////////////////////////////////////////////////
+
+// AddressTransactionMapping represents an entry in the transaction history of an Address. It records that a
+// Transaction credited the Address (by creating an Output that is owned by it) and/or debited the Address (by consuming
+// an Output that is owned by it).
+type AddressTransactionMapping struct {
+	address       Address
+	transactionID TransactionID
+	timestamp     time.Time
+	credited      bool
+	debited       bool
+}
+
+// NewAddressTransactionMapping returns a new AddressTransactionMapping.
+func NewAddressTransactionMapping(address Address, transactionID TransactionID, timestamp time.Time, credited bool, debited bool) *AddressTransactionMapping {
+	return &AddressTransactionMapping{
+		address:       address,
+		transactionID: transactionID,
+		timestamp:     timestamp,
+		credited:      credited,
+		debited:       debited,
+	}
+}
+
+// AddressTransactionMappingFromBytes unmarshals an AddressTransactionMapping from a sequence of bytes.
+func AddressTransactionMappingFromBytes(bytes []byte) (addressTransactionMapping *AddressTransactionMapping, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if addressTransactionMapping, err = AddressTransactionMappingFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AddressTransactionMapping from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressTransactionMappingFromMarshalUtil unmarshals an AddressTransactionMapping using a MarshalUtil (for easier
+// unmarshaling).
+func AddressTransactionMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (addressTransactionMapping *AddressTransactionMapping, err error) {
+	addressTransactionMapping = &AddressTransactionMapping{}
+	if addressTransactionMapping.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	cursor, err := addressHistoryCursorFromKeyMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryCursor from MarshalUtil: %w", err)
+		return
+	}
+	addressTransactionMapping.timestamp = cursor.timestamp
+	addressTransactionMapping.transactionID = cursor.transactionID
+	if addressTransactionMapping.credited, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse credited flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if addressTransactionMapping.debited, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse debited flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Address returns the Address of the AddressTransactionMapping.
+func (a *AddressTransactionMapping) Address() Address {
+	return a.address
+}
+
+// TransactionID returns the TransactionID of the AddressTransactionMapping.
+func (a *AddressTransactionMapping) TransactionID() TransactionID {
+	return a.transactionID
+}
+
+// Timestamp returns the timestamp of the Transaction.
+func (a *AddressTransactionMapping) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// Credited returns true if the Transaction created at least one Output that is owned by the Address.
+func (a *AddressTransactionMapping) Credited() bool {
+	return a.credited
+}
+
+// Debited returns true if the Transaction consumed at least one Output that is owned by the Address.
+func (a *AddressTransactionMapping) Debited() bool {
+	return a.debited
+}
+
+// Cursor returns the AddressHistoryCursor that points to the position of the AddressTransactionMapping in the history.
+func (a *AddressTransactionMapping) Cursor() AddressHistoryCursor {
+	return NewAddressHistoryCursor(a.timestamp, a.transactionID)
+}
+
+// Bytes marshals the AddressTransactionMapping into a sequence of bytes.
+func (a *AddressTransactionMapping) Bytes() []byte {
+	return byteutils.ConcatBytes(a.key(), a.value())
+}
+
+// String returns a human readable version of the AddressTransactionMapping.
+func (a *AddressTransactionMapping) String() string {
+	return stringify.Struct("AddressTransactionMapping",
+		stringify.StructField("address", a.address),
+		stringify.StructField("transactionID", a.transactionID),
+		stringify.StructField("timestamp", a.timestamp),
+		stringify.StructField("credited", a.credited),
+		stringify.StructField("debited", a.debited),
+	)
+}
+
+// key returns the key that is used to store the AddressTransactionMapping in the addressHistory. The keys of an Address
+// are ordered like its history.
+func (a *AddressTransactionMapping) key() []byte {
+	return byteutils.ConcatBytes(a.address.Bytes(), a.Cursor().key())
+}
+
+// value returns the value that is used to store the AddressTransactionMapping in the addressHistory.
+func (a *AddressTransactionMapping) value() []byte {
+	return marshalutil.New(2 * marshalutil.BoolSize).
+		WriteBool(a.credited).
+		WriteBool(a.debited).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region addressHistory ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// addressHistory is the persistent index of the AddressTransactionMappings. The entries are stored under the Address
+// followed by the key of their AddressHistoryCursor, so a page of the history is found by comparing the keys with the
+// key of the cursor - without unmarshaling and sorting all entries of the Address.
+type addressHistory struct {
+	store kvstore.KVStore
+}
+
+// newAddressHistory creates a new addressHistory that is persisted in the given KVStore.
+func newAddressHistory(store kvstore.KVStore) *addressHistory {
+	return &addressHistory{
+		store: store,
+	}
+}
+
+// add adds the given AddressTransactionMapping to the history of its Address (if it does not exist, yet).
+func (a *addressHistory) add(addressTransactionMapping *AddressTransactionMapping) (err error) {
+	key := addressTransactionMapping.key()
+	exists, err := a.store.Has(key)
+	if err != nil {
+		return xerrors.Errorf("failed to check existence of %s: %w", addressTransactionMapping, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err = a.store.Set(key, addressTransactionMapping.value()); err != nil {
+		return xerrors.Errorf("failed to store %s: %w", addressTransactionMapping, err)
+	}
+
+	return nil
+}
+
+// delete removes the entry that the given AddressHistoryCursor points to from the history of the given Address.
+func (a *addressHistory) delete(address Address, cursor AddressHistoryCursor) (err error) {
+	if err = a.store.Delete(byteutils.ConcatBytes(address.Bytes(), cursor.key())); err != nil {
+		return xerrors.Errorf("failed to delete %s from the history of %s: %w", cursor, address, err)
+	}
+
+	return nil
+}
+
+// page returns at most limit AddressTransactionMappings of the given Address that come after the given cursor (a limit
+// of 0 returns all of them) together with a flag that indicates if there are further entries. The backends of the node
+// iterate in key order, so the iteration stops as soon as the entry after the page was found.
+func (a *addressHistory) page(address Address, cursor *AddressHistoryCursor, limit int) (addressTransactionMappings []*AddressTransactionMapping, hasMore bool, err error) {
+	var cursorKey []byte
+	if cursor != nil {
+		cursorKey = byteutils.ConcatBytes(address.Bytes(), cursor.key())
+	}
+
+	addressTransactionMappings = make([]*AddressTransactionMapping, 0)
+	if iterateErr := a.store.Iterate(address.Bytes(), func(key kvstore.Key, value kvstore.Value) bool {
+		if cursorKey != nil && bytes.Compare(key, cursorKey) <= 0 {
+			return true
+		}
+		if limit > 0 && len(addressTransactionMappings) == limit {
+			hasMore = true
+			return false
+		}
+
+		addressTransactionMapping, _, parseErr := AddressTransactionMappingFromBytes(byteutils.ConcatBytes(key, value))
+		if parseErr != nil {
+			err = xerrors.Errorf("failed to parse entry of the history of %s: %w", address, parseErr)
+			return false
+		}
+		addressTransactionMappings = append(addressTransactionMappings, addressTransactionMapping)
+
+		return true
+	}); iterateErr != nil {
+		return nil, false, xerrors.Errorf("failed to iterate the history of %s: %w", address, iterateErr)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return addressTransactionMappings, hasMore, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressHistoryCursor /////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryCursorLength contains the amount of bytes that a marshaled version of the AddressHistoryCursor
+// contains.
+const AddressHistoryCursorLength = marshalutil.TimeSize + TransactionIDLength
+
+// AddressHistoryCursor marks a position in the transaction history of an Address. The history is ordered from the
+// newest to the oldest Transaction (ties are broken by the TransactionID) so a cursor stays valid while new
+// Transactions are added to the history.
+type AddressHistoryCursor struct {
+	timestamp     time.Time
+	transactionID TransactionID
+}
+
+// NewAddressHistoryCursor returns a new AddressHistoryCursor that points to the given Transaction.
+func NewAddressHistoryCursor(timestamp time.Time, transactionID TransactionID) AddressHistoryCursor {
+	return AddressHistoryCursor{
+		timestamp:     timestamp,
+		transactionID: transactionID,
+	}
+}
+
+// AddressHistoryCursorFromBytes unmarshals an AddressHistoryCursor from a sequence of bytes.
+func AddressHistoryCursorFromBytes(bytes []byte) (cursor AddressHistoryCursor, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if cursor, err = AddressHistoryCursorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryCursor from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressHistoryCursorFromBase58 creates an AddressHistoryCursor from a base58 encoded string.
+func AddressHistoryCursorFromBase58(base58String string) (cursor AddressHistoryCursor, err error) {
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = xerrors.Errorf("error while decoding base58 encoded AddressHistoryCursor (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if cursor, _, err = AddressHistoryCursorFromBytes(bytes); err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryCursor from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AddressHistoryCursorFromMarshalUtil unmarshals an AddressHistoryCursor using a MarshalUtil (for easier unmarshaling).
+func AddressHistoryCursorFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (cursor AddressHistoryCursor, err error) {
+	if cursor.timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if cursor.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// addressHistoryCursorFromKeyMarshalUtil unmarshals an AddressHistoryCursor from its storage key using a MarshalUtil.
+func addressHistoryCursorFromKeyMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (cursor AddressHistoryCursor, err error) {
+	timestampBytes, err := marshalUtil.ReadBytes(marshalutil.Uint64Size)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	cursor.timestamp = time.Unix(0, int64(math.MaxUint64-binary.BigEndian.Uint64(timestampBytes)))
+	if cursor.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	for i := range cursor.transactionID {
+		cursor.transactionID[i] = ^cursor.transactionID[i]
+	}
+
+	return
+}
+
+// Timestamp returns the timestamp of the Transaction that the AddressHistoryCursor points to.
+func (a AddressHistoryCursor) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// TransactionID returns the TransactionID of the Transaction that the AddressHistoryCursor points to.
+func (a AddressHistoryCursor) TransactionID() TransactionID {
+	return a.transactionID
+}
+
+// Before returns true if the AddressHistoryCursor comes before the other AddressHistoryCursor in the history.
+func (a AddressHistoryCursor) Before(other AddressHistoryCursor) bool {
+	if !a.timestamp.Equal(other.timestamp) {
+		return a.timestamp.After(other.timestamp)
+	}
+
+	return bytes.Compare(a.transactionID.Bytes(), other.transactionID.Bytes()) > 0
+}
+
+// Bytes returns a marshaled version of the AddressHistoryCursor.
+func (a AddressHistoryCursor) Bytes() []byte {
+	return marshalutil.New(AddressHistoryCursorLength).
+		WriteTime(a.timestamp).
+		Write(a.transactionID).
+		Bytes()
+}
+
+// Base58 returns a base58 encoded version of the AddressHistoryCursor.
+func (a AddressHistoryCursor) Base58() string {
+	return base58.Encode(a.Bytes())
+}
+
+// String returns a human readable version of the AddressHistoryCursor.
+func (a AddressHistoryCursor) String() string {
+	return stringify.Struct("AddressHistoryCursor",
+		stringify.StructField("timestamp", a.timestamp),
+		stringify.StructField("transactionID", a.transactionID),
+	)
+}
+
+// key returns the storage key of the AddressHistoryCursor. The timestamp and the TransactionID are inverted, so that
+// the keys are ordered like the history (from the newest to the oldest Transaction).
+func (a AddressHistoryCursor) key() []byte {
+	transactionID := a.transactionID
+	for i := range transactionID {
+		transactionID[i] = ^transactionID[i]
+	}
+
+	// the timestamp is written in big endian, so that the byte order of the keys matches the order of the timestamps
+	key := make([]byte, marshalutil.Uint64Size, marshalutil.Uint64Size+TransactionIDLength)
+	binary.BigEndian.PutUint64(key, math.MaxUint64-uint64(a.timestamp.UnixNano()))
+
+	return append(key, transactionID.Bytes()...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
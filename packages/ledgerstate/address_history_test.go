@@ -0,0 +1,142 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+func TestAddressTransactionMapping_Bytes(t *testing.T) {
+	wallets := createWallets(1)
+	addressTransactionMapping := NewAddressTransactionMapping(wallets[0].address, GenesisTransactionID, time.Unix(1337, 0), true, false)
+
+	restoredMapping, consumedBytes, err := AddressTransactionMappingFromBytes(addressTransactionMapping.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(addressTransactionMapping.Bytes()), consumedBytes)
+	assert.Equal(t, addressTransactionMapping.Bytes(), restoredMapping.Bytes())
+	assert.True(t, restoredMapping.Credited())
+	assert.False(t, restoredMapping.Debited())
+
+	restoredCursor, err := AddressHistoryCursorFromBase58(addressTransactionMapping.Cursor().Base58())
+	require.NoError(t, err)
+	assert.Equal(t, addressTransactionMapping.Cursor().Bytes(), restoredCursor.Bytes())
+}
+
+func TestAddressHistoryCursor_key(t *testing.T) {
+	cursors := []AddressHistoryCursor{
+		NewAddressHistoryCursor(time.Unix(1338, 0), TransactionID{1}),
+		NewAddressHistoryCursor(time.Unix(1337, 0), TransactionID{2}),
+		NewAddressHistoryCursor(time.Unix(1337, 0), TransactionID{1}),
+		NewAddressHistoryCursor(time.Unix(1336, 0), TransactionID{3}),
+	}
+
+	// the keys are ordered like the history
+	for i := 1; i < len(cursors); i++ {
+		assert.True(t, cursors[i-1].Before(cursors[i]))
+		assert.Equal(t, -1, bytes.Compare(cursors[i-1].key(), cursors[i].key()))
+	}
+
+	restoredCursor, err := addressHistoryCursorFromKeyMarshalUtil(marshalutil.New(cursors[1].key()))
+	require.NoError(t, err)
+	assert.Equal(t, cursors[1].Bytes(), restoredCursor.Bytes())
+}
+
+func TestAddressHistory_page(t *testing.T) {
+	addressHistory := newAddressHistory(newOrderedStore(t))
+	address := createWallets(1)[0].address
+
+	// the entries are added in random order and paged from the newest to the oldest Transaction
+	expectedCursors := make([]AddressHistoryCursor, 0)
+	for _, i := range rand.Perm(20) {
+		addressTransactionMapping := NewAddressTransactionMapping(address, TransactionID{byte(i)}, time.Unix(int64(i/2), 0), true, false)
+		require.NoError(t, addressHistory.add(addressTransactionMapping))
+		expectedCursors = append(expectedCursors, addressTransactionMapping.Cursor())
+	}
+	sort.Slice(expectedCursors, func(i, j int) bool { return expectedCursors[i].Before(expectedCursors[j]) })
+
+	var cursor *AddressHistoryCursor
+	for i := 0; i < len(expectedCursors); i += 3 {
+		page, hasMore, err := addressHistory.page(address, cursor, 3)
+		require.NoError(t, err)
+		assert.Equal(t, i+3 < len(expectedCursors), hasMore)
+		for j, addressTransactionMapping := range page {
+			assert.Equal(t, expectedCursors[i+j].Bytes(), addressTransactionMapping.Cursor().Bytes())
+		}
+
+		lastCursor := page[len(page)-1].Cursor()
+		cursor = &lastCursor
+	}
+
+	require.NoError(t, addressHistory.delete(address, expectedCursors[0]))
+	page, hasMore, err := addressHistory.page(address, nil, 0)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	assert.Len(t, page, len(expectedCursors)-1)
+}
+
+func TestUTXODAG_AddressTransactionHistory(t *testing.T) {
+	store := newOrderedStore(t)
+	branchDAG := NewBranchDAG(store)
+	defer branchDAG.Shutdown()
+	require.NoError(t, branchDAG.Prune())
+	utxoDAG := NewUTXODAG(store, branchDAG)
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+
+	tx1 := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	_, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG.StoreAddressTransactionMappings(tx1))
+
+	time.Sleep(time.Millisecond)
+
+	tx2 := buildTransaction(utxoDAG, wallets[1], wallets[1], []*SigLockedSingleOutput{tx1.Essence().Outputs()[0].(*SigLockedSingleOutput)})
+	_, err = utxoDAG.BookTransaction(tx2)
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG.StoreAddressTransactionMappings(tx2))
+
+	history, nextCursor, err := utxoDAG.AddressTransactionHistory(wallets[0].address, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Nil(t, nextCursor)
+	assert.Equal(t, tx1.ID(), history[0].TransactionID())
+	assert.False(t, history[0].Credited())
+	assert.True(t, history[0].Debited())
+
+	// the history of the second wallet is returned from the newest to the oldest Transaction
+	history, nextCursor, err = utxoDAG.AddressTransactionHistory(wallets[1].address, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.NotNil(t, nextCursor)
+	assert.Equal(t, tx2.ID(), history[0].TransactionID())
+	assert.True(t, history[0].Credited())
+	assert.True(t, history[0].Debited())
+
+	history, nextCursor, err = utxoDAG.AddressTransactionHistory(wallets[1].address, nextCursor, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Nil(t, nextCursor)
+	assert.Equal(t, tx1.ID(), history[0].TransactionID())
+	assert.True(t, history[0].Credited())
+	assert.False(t, history[0].Debited())
+}
+
+// newOrderedStore returns an in-memory KVStore that iterates in key order like the backends of the node.
+func newOrderedStore(t *testing.T) kvstore.KVStore {
+	db, err := database.NewMemDB()
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+
+	return db.NewStore()
+}
@@ -64,3 +64,63 @@ func TestBLSAddress(t *testing.T) {
 	assert.Equal(t, address.Type(), addressFromBase58.Type())
 	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
 }
+
+func TestAliasAddress(t *testing.T) {
+	address := NewAliasAddress(NewOutputID(GenesisTransactionID, 1).Bytes())
+
+	// alias address from bytes
+	address1, _, err := AliasAddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), address1.Type())
+	assert.Equal(t, address.Digest(), address1.Digest())
+
+	// alias address from bytes using AddressFromBytes
+	address2, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), address2.Type())
+	assert.Equal(t, address.Digest(), address2.Digest())
+
+	// alias address from base58 string
+	addressFromBase58, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromBase58.Type())
+	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
+
+	// alias addresses are derived deterministically from the origin
+	assert.Equal(t, address.Digest(), NewAliasAddress(NewOutputID(GenesisTransactionID, 1).Bytes()).Digest())
+	assert.NotEqual(t, address.Digest(), NewAliasAddress(NewOutputID(GenesisTransactionID, 2).Bytes()).Digest())
+}
+
+func TestThresholdAddress(t *testing.T) {
+	publicKeys := []ed25519.PublicKey{ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey}
+	address, err := NewThresholdAddress(2, publicKeys)
+	require.NoError(t, err)
+
+	// threshold address from bytes using AddressFromBytes
+	address1, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), address1.Type())
+	assert.Equal(t, address.Digest(), address1.Digest())
+
+	// threshold address from base58 string
+	addressFromBase58, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromBase58.Type())
+	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
+
+	// the order of the public keys does not matter but the threshold does
+	reorderedAddress, err := NewThresholdAddress(2, []ed25519.PublicKey{publicKeys[2], publicKeys[0], publicKeys[1]})
+	require.NoError(t, err)
+	assert.Equal(t, address.Digest(), reorderedAddress.Digest())
+	otherThresholdAddress, err := NewThresholdAddress(3, publicKeys)
+	require.NoError(t, err)
+	assert.NotEqual(t, address.Digest(), otherThresholdAddress.Digest())
+
+	// invalid parameters
+	_, err = NewThresholdAddress(0, publicKeys)
+	assert.Error(t, err)
+	_, err = NewThresholdAddress(4, publicKeys)
+	assert.Error(t, err)
+	_, err = NewThresholdAddress(1, []ed25519.PublicKey{publicKeys[0], publicKeys[0]})
+	assert.Error(t, err)
+}
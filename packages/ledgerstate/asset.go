@@ -0,0 +1,686 @@
+package ledgerstate
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// region AssetMetadata ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// MaxAssetNameLength defines the maximum length of the name of an asset (in bytes).
+	MaxAssetNameLength = 64
+
+	// MaxAssetSymbolLength defines the maximum length of the symbol of an asset (in bytes).
+	MaxAssetSymbolLength = 16
+)
+
+// AssetMetadataType represents the payload Type of the AssetMetadata.
+var AssetMetadataType payload.Type
+
+// init defers the initialization of the AssetMetadataType to not have an initialization loop.
+func init() {
+	AssetMetadataType = payload.NewType(1338, "AssetMetadataType", func(data []byte) (payload.Payload, error) {
+		return AssetMetadataFromMarshalUtil(marshalutil.New(data))
+	})
+}
+
+// AssetMetadata is a payload that can be attached to the TransactionEssence of a minting Transaction to describe the
+// newly created colored tokens. The node indexes it for every Color that is minted by the Transaction.
+type AssetMetadata struct {
+	name      string
+	symbol    string
+	precision uint8
+	supply    uint64
+}
+
+// NewAssetMetadata creates a new AssetMetadata from the given details. Names and symbols that exceed the maximum length
+// are truncated when the AssetMetadata is marshaled.
+func NewAssetMetadata(name string, symbol string, precision uint8, supply uint64) *AssetMetadata {
+	return &AssetMetadata{
+		name:      name,
+		symbol:    symbol,
+		precision: precision,
+		supply:    supply,
+	}
+}
+
+// AssetMetadataFromBytes unmarshals an AssetMetadata from a sequence of bytes.
+func AssetMetadataFromBytes(bytes []byte) (assetMetadata *AssetMetadata, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if assetMetadata, err = AssetMetadataFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AssetMetadata from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AssetMetadataFromMarshalUtil unmarshals an AssetMetadata using a MarshalUtil (for easier unmarshaling).
+func AssetMetadataFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (assetMetadata *AssetMetadata, err error) {
+	readStartOffset := marshalUtil.ReadOffset()
+
+	payloadSize, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse payload size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	payloadType, err := payload.TypeFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse payload Type from MarshalUtil: %w", err)
+		return
+	}
+	if payloadType != AssetMetadataType {
+		err = xerrors.Errorf("payload type '%s' does not match expected '%s': %w", payloadType, AssetMetadataType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	assetMetadata = &AssetMetadata{}
+	if assetMetadata.name, err = readAssetMetadataString(marshalUtil, MaxAssetNameLength); err != nil {
+		err = xerrors.Errorf("failed to parse name: %w", err)
+		return
+	}
+	if assetMetadata.symbol, err = readAssetMetadataString(marshalUtil, MaxAssetSymbolLength); err != nil {
+		err = xerrors.Errorf("failed to parse symbol: %w", err)
+		return
+	}
+	if assetMetadata.precision, err = marshalUtil.ReadUint8(); err != nil {
+		err = xerrors.Errorf("failed to parse precision (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if assetMetadata.supply, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if parsedBytes := marshalUtil.ReadOffset() - readStartOffset; parsedBytes != int(payloadSize)+marshalutil.Uint32Size {
+		err = xerrors.Errorf("parsed bytes (%d) did not match expected size (%d): %w", parsedBytes, payloadSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// readAssetMetadataString is an internal utility function that reads a length prefixed string of at most maxLength
+// bytes.
+func readAssetMetadataString(marshalUtil *marshalutil.MarshalUtil, maxLength int) (result string, err error) {
+	length, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse length (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if int(length) > maxLength {
+		err = xerrors.Errorf("length (%d) exceeds maximum of %d bytes: %w", length, maxLength, cerrors.ErrParseBytesFailed)
+		return
+	}
+	bytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		err = xerrors.Errorf("failed to parse bytes (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return string(bytes), nil
+}
+
+// Name returns the name of the asset.
+func (a *AssetMetadata) Name() string {
+	return a.name
+}
+
+// Symbol returns the currency symbol of the asset.
+func (a *AssetMetadata) Symbol() string {
+	return a.symbol
+}
+
+// Precision returns the amount of decimal places that are used when displaying the asset.
+func (a *AssetMetadata) Precision() uint8 {
+	return a.precision
+}
+
+// Supply returns the declared supply of the asset.
+func (a *AssetMetadata) Supply() uint64 {
+	return a.supply
+}
+
+// Type returns the Type of the Payload.
+func (a *AssetMetadata) Type() payload.Type {
+	return AssetMetadataType
+}
+
+// Bytes returns a marshaled version of the AssetMetadata.
+func (a *AssetMetadata) Bytes() []byte {
+	name := a.name
+	if len(name) > MaxAssetNameLength {
+		name = name[:MaxAssetNameLength]
+	}
+	symbol := a.symbol
+	if len(symbol) > MaxAssetSymbolLength {
+		symbol = symbol[:MaxAssetSymbolLength]
+	}
+
+	payloadBytes := marshalutil.New().
+		Write(AssetMetadataType).
+		WriteUint16(uint16(len(name))).
+		WriteBytes([]byte(name)).
+		WriteUint16(uint16(len(symbol))).
+		WriteBytes([]byte(symbol)).
+		WriteUint8(a.precision).
+		WriteUint64(a.supply).
+		Bytes()
+
+	return marshalutil.New(marshalutil.Uint32Size + len(payloadBytes)).
+		WriteUint32(uint32(len(payloadBytes))).
+		WriteBytes(payloadBytes).
+		Bytes()
+}
+
+// String returns a human readable version of the AssetMetadata.
+func (a *AssetMetadata) String() string {
+	return stringify.Struct("AssetMetadata",
+		stringify.StructField("name", a.name),
+		stringify.StructField("symbol", a.symbol),
+		stringify.StructField("precision", a.precision),
+		stringify.StructField("supply", a.supply),
+	)
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ payload.Payload = &AssetMetadata{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorMetadata ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorMetadata represents the AssetMetadata that was attached to the Transaction that minted a Color.
+type ColorMetadata struct {
+	color         Color
+	transactionID TransactionID
+	assetMetadata *AssetMetadata
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorMetadata returns a new ColorMetadata.
+func NewColorMetadata(color Color, transactionID TransactionID, assetMetadata *AssetMetadata) *ColorMetadata {
+	return &ColorMetadata{
+		color:         color,
+		transactionID: transactionID,
+		assetMetadata: assetMetadata,
+	}
+}
+
+// ColorMetadataFromBytes unmarshals a ColorMetadata from a sequence of bytes.
+func ColorMetadataFromBytes(bytes []byte) (colorMetadata *ColorMetadata, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorMetadata, err = ColorMetadataFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColorMetadata from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorMetadataFromMarshalUtil unmarshals a ColorMetadata using a MarshalUtil (for easier unmarshaling).
+func ColorMetadataFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorMetadata *ColorMetadata, err error) {
+	colorMetadata = &ColorMetadata{}
+	if colorMetadata.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorMetadata.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if colorMetadata.assetMetadata, err = AssetMetadataFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AssetMetadata from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// ColorMetadataFromObjectStorage is a factory method that creates a new ColorMetadata instance from a storage key of
+// the object storage. It is used by the object storage, to create new instances of this entity.
+func ColorMetadataFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = ColorMetadataFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse ColorMetadata from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color that is described by the ColorMetadata.
+func (c *ColorMetadata) Color() Color {
+	return c.color
+}
+
+// TransactionID returns the identifier of the Transaction that minted the Color.
+func (c *ColorMetadata) TransactionID() TransactionID {
+	return c.transactionID
+}
+
+// AssetMetadata returns the AssetMetadata that was attached to the minting Transaction.
+func (c *ColorMetadata) AssetMetadata() *AssetMetadata {
+	return c.assetMetadata
+}
+
+// Bytes marshals the ColorMetadata into a sequence of bytes.
+func (c *ColorMetadata) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorMetadata.
+func (c *ColorMetadata) String() string {
+	return stringify.Struct("ColorMetadata",
+		stringify.StructField("color", c.color),
+		stringify.StructField("transactionID", c.transactionID),
+		stringify.StructField("assetMetadata", c.assetMetadata),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorMetadata) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorMetadata) ObjectStorageKey() []byte {
+	return c.color.Bytes()
+}
+
+// ObjectStorageValue marshals the ColorMetadata into a sequence of bytes that are used as the value part in the object
+// storage.
+func (c *ColorMetadata) ObjectStorageValue() []byte {
+	return byteutils.ConcatBytes(c.transactionID.Bytes(), c.assetMetadata.Bytes())
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &ColorMetadata{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorMetadata //////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorMetadata is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedColorMetadata struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedColorMetadata) Retain() *CachedColorMetadata {
+	return &CachedColorMetadata{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorMetadata) Unwrap() *ColorMetadata {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorMetadata)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedColorMetadata) Consume(consumer func(colorMetadata *ColorMetadata), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorMetadata))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedColorMetadata.
+func (c *CachedColorMetadata) String() string {
+	return stringify.Struct("CachedColorMetadata",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorSupplyChange ////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupplyChange records how a single Transaction changed the supply of a Color. Tokens are minted by creating
+// Outputs with ColorMint and they are burned by consuming colored tokens without creating them again (which uncolors
+// them back to IOTA).
+type ColorSupplyChange struct {
+	color         Color
+	transactionID TransactionID
+	minted        uint64
+	burned        uint64
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorSupplyChange returns a new ColorSupplyChange.
+func NewColorSupplyChange(color Color, transactionID TransactionID, minted uint64, burned uint64) *ColorSupplyChange {
+	return &ColorSupplyChange{
+		color:         color,
+		transactionID: transactionID,
+		minted:        minted,
+		burned:        burned,
+	}
+}
+
+// ColorSupplyChangeFromBytes unmarshals a ColorSupplyChange from a sequence of bytes.
+func ColorSupplyChangeFromBytes(bytes []byte) (colorSupplyChange *ColorSupplyChange, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorSupplyChange, err = ColorSupplyChangeFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColorSupplyChange from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorSupplyChangeFromMarshalUtil unmarshals a ColorSupplyChange using a MarshalUtil (for easier unmarshaling).
+func ColorSupplyChangeFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorSupplyChange *ColorSupplyChange, err error) {
+	colorSupplyChange = &ColorSupplyChange{}
+	if colorSupplyChange.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupplyChange.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupplyChange.minted, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse minted balance (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupplyChange.burned, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse burned balance (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorSupplyChangeFromObjectStorage is a factory method that creates a new ColorSupplyChange instance from a storage
+// key of the object storage. It is used by the object storage, to create new instances of this entity.
+func ColorSupplyChangeFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = ColorSupplyChangeFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse ColorSupplyChange from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color whose supply was changed.
+func (c *ColorSupplyChange) Color() Color {
+	return c.color
+}
+
+// TransactionID returns the identifier of the Transaction that changed the supply.
+func (c *ColorSupplyChange) TransactionID() TransactionID {
+	return c.transactionID
+}
+
+// Minted returns the amount of tokens of the Color that were created by the Transaction.
+func (c *ColorSupplyChange) Minted() uint64 {
+	return c.minted
+}
+
+// Burned returns the amount of tokens of the Color that were destroyed by the Transaction.
+func (c *ColorSupplyChange) Burned() uint64 {
+	return c.burned
+}
+
+// Bytes marshals the ColorSupplyChange into a sequence of bytes.
+func (c *ColorSupplyChange) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorSupplyChange.
+func (c *ColorSupplyChange) String() string {
+	return stringify.Struct("ColorSupplyChange",
+		stringify.StructField("color", c.color),
+		stringify.StructField("transactionID", c.transactionID),
+		stringify.StructField("minted", c.minted),
+		stringify.StructField("burned", c.burned),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorSupplyChange) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorSupplyChange) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(c.color.Bytes(), c.transactionID.Bytes())
+}
+
+// ObjectStorageValue marshals the ColorSupplyChange into a sequence of bytes that are used as the value part in the
+// object storage.
+func (c *ColorSupplyChange) ObjectStorageValue() []byte {
+	return marshalutil.New(2 * marshalutil.Uint64Size).
+		WriteUint64(c.minted).
+		WriteUint64(c.burned).
+		Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &ColorSupplyChange{}
+
+// colorSupplyChanges is an internal utility function that determines the amount of tokens that are minted and burned
+// by a Transaction with the given consumed Outputs (the balances of the Transaction need to be valid).
+func colorSupplyChanges(transaction *Transaction, consumedOutputs Outputs) (minted map[Color]uint64, burned map[Color]uint64) {
+	minted = make(map[Color]uint64)
+	burned = make(map[Color]uint64)
+	for _, consumedOutput := range consumedOutputs {
+		consumedOutput.Balances().ForEach(func(color Color, balance uint64) bool {
+			if color != ColorIOTA {
+				burned[color] += balance
+			}
+
+			return true
+		})
+	}
+
+	for _, output := range transaction.Essence().Outputs() {
+		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			switch color {
+			case ColorIOTA:
+			case ColorMint:
+				minted[blake2b.Sum256(output.ID().Bytes())] += balance
+			default:
+				burned[color] -= balance
+			}
+
+			return true
+		})
+	}
+
+	for color, balance := range burned {
+		if balance == 0 {
+			delete(burned, color)
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupply contains the supply of a Color that results from all confirmed Transactions that minted or burned tokens
+// of the Color.
+type ColorSupply struct {
+	// Color contains the Color that the supply refers to.
+	Color Color
+
+	// TotalSupply contains the amount of tokens that were minted.
+	TotalSupply uint64
+
+	// BurnedSupply contains the amount of tokens that were burned.
+	BurnedSupply uint64
+
+	// CirculatingSupply contains the amount of tokens that were minted and not burned.
+	CirculatingSupply uint64
+}
+
+// colorSupplyFromBytes unmarshals the persisted running total of a ColorSupply from a sequence of bytes.
+func colorSupplyFromBytes(color Color, bytes []byte) (colorSupply *ColorSupply, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	colorSupply = &ColorSupply{Color: color}
+	if colorSupply.TotalSupply, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse total supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if colorSupply.BurnedSupply, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse burned supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if colorSupply.BurnedSupply < colorSupply.TotalSupply {
+		colorSupply.CirculatingSupply = colorSupply.TotalSupply - colorSupply.BurnedSupply
+	}
+
+	return colorSupply, nil
+}
+
+// bytes marshals the running total of the ColorSupply into a sequence of bytes (the CirculatingSupply is derived).
+func (c *ColorSupply) bytes() []byte {
+	return marshalutil.New(2 * marshalutil.Uint64Size).
+		WriteUint64(c.TotalSupply).
+		WriteUint64(c.BurnedSupply).
+		Bytes()
+}
+
+// String returns a human readable version of the ColorSupply.
+func (c *ColorSupply) String() string {
+	return stringify.Struct("ColorSupply",
+		stringify.StructField("color", c.Color),
+		stringify.StructField("totalSupply", c.TotalSupply),
+		stringify.StructField("burnedSupply", c.BurnedSupply),
+		stringify.StructField("circulatingSupply", c.CirculatingSupply),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region colorSupplies ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// colorSupplyPrefix defines the storage prefix for the running totals of the ColorSupplies.
+	colorSupplyPrefix byte = iota
+
+	// colorSupplyTransactionPrefix defines the storage prefix for the confirmed Transactions that were applied to the
+	// colorSupplies.
+	colorSupplyTransactionPrefix
+)
+
+// colorSupplies is the persistent index of the ColorSupplies. It keeps a running total of the tokens that were minted
+// and burned by the confirmed Transactions, so the supply of a Color can be retrieved without loading all of its
+// ColorSupplyChanges (and without counting conflicting Transactions that are still pending).
+type colorSupplies struct {
+	store kvstore.KVStore
+	mutex sync.RWMutex
+}
+
+// newColorSupplies creates a new colorSupplies index that is persisted in the given KVStore.
+func newColorSupplies(store kvstore.KVStore) *colorSupplies {
+	return &colorSupplies{
+		store: store,
+	}
+}
+
+// supply returns the ColorSupply of the given Color.
+func (c *colorSupplies) supply(color Color) (colorSupply *ColorSupply, err error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.load(color)
+}
+
+// applyTransaction adds the minted and burned tokens of the given confirmed Transaction to the running totals of their
+// Colors. Transactions that were applied before are ignored.
+func (c *colorSupplies) applyTransaction(transaction *Transaction, consumedOutputs Outputs) (err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	transactionKey := byteutils.ConcatBytes([]byte{colorSupplyTransactionPrefix}, transaction.ID().Bytes())
+	if applied, err := c.store.Has(transactionKey); err != nil || applied {
+		return err
+	}
+
+	minted, burned := colorSupplyChanges(transaction, consumedOutputs)
+	supplies := make(map[Color]*ColorSupply)
+	for color, balance := range minted {
+		if supplies[color], err = c.load(color); err != nil {
+			return err
+		}
+		supplies[color].TotalSupply += balance
+	}
+	for color, balance := range burned {
+		if _, exists := supplies[color]; !exists {
+			if supplies[color], err = c.load(color); err != nil {
+				return err
+			}
+		}
+		supplies[color].BurnedSupply += balance
+	}
+
+	batch := c.store.Batched()
+	defer func() {
+		if err != nil {
+			batch.Cancel()
+		}
+	}()
+
+	for color, colorSupply := range supplies {
+		if err = batch.Set(byteutils.ConcatBytes([]byte{colorSupplyPrefix}, color.Bytes()), colorSupply.bytes()); err != nil {
+			return xerrors.Errorf("failed to store supply of %s: %w", color, err)
+		}
+	}
+	if err = batch.Set(transactionKey, []byte{}); err != nil {
+		return xerrors.Errorf("failed to mark Transaction as applied to the color supplies: %w", err)
+	}
+
+	if err = batch.Commit(); err != nil {
+		return xerrors.Errorf("failed to commit update of the color supplies: %w", err)
+	}
+
+	return nil
+}
+
+// load is an internal utility function that loads the ColorSupply of the given Color from the store.
+func (c *colorSupplies) load(color Color) (colorSupply *ColorSupply, err error) {
+	colorSupplyBytes, err := c.store.Get(byteutils.ConcatBytes([]byte{colorSupplyPrefix}, color.Bytes()))
+	if err != nil {
+		if xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return &ColorSupply{Color: color}, nil
+		}
+		return nil, xerrors.Errorf("failed to load supply of %s: %w", color, err)
+	}
+
+	if colorSupply, err = colorSupplyFromBytes(color, colorSupplyBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse supply of %s: %w", color, err)
+	}
+
+	return colorSupply, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
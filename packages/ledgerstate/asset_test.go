@@ -0,0 +1,108 @@
+package ledgerstate
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestAssetMetadata_Bytes(t *testing.T) {
+	assetMetadata := NewAssetMetadata("Test Token", "TST", 2, 1000)
+
+	restoredPayload, _, err := payload.FromBytes(assetMetadata.Bytes())
+	require.NoError(t, err)
+	restoredAssetMetadata, isAssetMetadata := restoredPayload.(*AssetMetadata)
+	require.True(t, isAssetMetadata)
+	assert.Equal(t, assetMetadata, restoredAssetMetadata)
+
+	truncatedAssetMetadata, _, err := AssetMetadataFromBytes(NewAssetMetadata(strings.Repeat("a", MaxAssetNameLength+1), "TST", 0, 1).Bytes())
+	require.NoError(t, err)
+	assert.Len(t, truncatedAssetMetadata.Name(), MaxAssetNameLength)
+}
+
+func TestUTXODAG_ColorSupply(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+
+	// mint 60 tokens and attach the metadata of the asset
+	mintEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		NewInputs(NewUTXOInput(generateOutput(utxoDAG, wallets[0].address, 1).ID())),
+		NewOutputs(NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
+			ColorIOTA: 40,
+			ColorMint: 60,
+		}), wallets[0].address)),
+	)
+	mintEssence.SetPayload(NewAssetMetadata("Test Token", "TST", 2, 60))
+	mintTransaction := NewTransaction(mintEssence, wallets[0].unlockBlocks(mintEssence))
+
+	targetBranch, err := utxoDAG.BookTransaction(mintTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, MasterBranchID, targetBranch)
+
+	mintedOutputID := mintTransaction.Essence().Outputs()[0].ID()
+	mintedColor := Color(blake2b.Sum256(mintedOutputID.Bytes()))
+
+	// only the changes of confirmed Transactions are counted
+	colorSupply, err := utxoDAG.ColorSupply(mintedColor)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), colorSupply.TotalSupply)
+
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(mintTransaction.ID()))
+	colorSupply, err = utxoDAG.ColorSupply(mintedColor)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(60), colorSupply.TotalSupply)
+	assert.Equal(t, uint64(0), colorSupply.BurnedSupply)
+	assert.Equal(t, uint64(60), colorSupply.CirculatingSupply)
+
+	assert.True(t, utxoDAG.ColorMetadata(mintedColor).Consume(func(colorMetadata *ColorMetadata) {
+		assert.Equal(t, mintTransaction.ID(), colorMetadata.TransactionID())
+		assert.Equal(t, "Test Token", colorMetadata.AssetMetadata().Name())
+		assert.Equal(t, uint64(60), colorMetadata.AssetMetadata().Supply())
+	}))
+
+	// burn 40 of the minted tokens by uncoloring them
+	var mintedOutput Output
+	require.True(t, utxoDAG.Output(mintedOutputID).Consume(func(output Output) {
+		mintedOutput = output
+	}))
+	burnTransaction := buildDustTransaction(wallets[0], mintedOutput, NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
+		ColorIOTA:   80,
+		mintedColor: 20,
+	}), wallets[1].address))
+
+	targetBranch, err = utxoDAG.BookTransaction(burnTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, MasterBranchID, targetBranch)
+
+	// a pending double spend that burns all of the tokens is not counted
+	doubleSpendTransaction := buildDustTransaction(wallets[0], mintedOutput, NewSigLockedSingleOutput(100, wallets[1].address))
+	_, err = utxoDAG.BookTransaction(doubleSpendTransaction)
+	require.NoError(t, err)
+
+	// confirmed Transactions are only counted once
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(burnTransaction.ID()))
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(burnTransaction.ID()))
+	colorSupply, err = utxoDAG.ColorSupply(mintedColor)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(60), colorSupply.TotalSupply)
+	assert.Equal(t, uint64(40), colorSupply.BurnedSupply)
+	assert.Equal(t, uint64(20), colorSupply.CirculatingSupply)
+
+	colorSupplyChanges := utxoDAG.ColorSupplyChanges(mintedColor)
+	assert.Len(t, colorSupplyChanges, 3)
+
+	// Colors that were never minted have no supply
+	colorSupply, err = utxoDAG.ColorSupply(Color{1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), colorSupply.TotalSupply)
+	assert.False(t, utxoDAG.ColorMetadata(Color{1}).Consume(func(*ColorMetadata) {}))
+}
@@ -30,6 +30,7 @@ type BranchDAG struct {
 	childBranchStorage    *objectstorage.ObjectStorage
 	conflictStorage       *objectstorage.ObjectStorage
 	conflictMemberStorage *objectstorage.ObjectStorage
+	branchTombstones      *tombstones
 	shutdownOnce          sync.Once
 }
 
@@ -42,6 +43,7 @@ func NewBranchDAG(store kvstore.KVStore) (newBranchDAG *BranchDAG) {
 		childBranchStorage:    osFactory.New(PrefixChildBranchStorage, ChildBranchFromObjectStorage, childBranchStorageOptions...),
 		conflictStorage:       osFactory.New(PrefixConflictStorage, ConflictFromObjectStorage, conflictStorageOptions...),
 		conflictMemberStorage: osFactory.New(PrefixConflictMemberStorage, ConflictMemberFromObjectStorage, conflictMemberStorageOptions...),
+		branchTombstones:      newTombstones(store.WithRealm([]byte{database.PrefixLedgerState, PrefixBranchTombstoneStorage})),
 	}
 	newBranchDAG.init()
 
@@ -254,6 +256,67 @@ func (b *BranchDAG) MergeToMaster(branchID BranchID) (movedBranches map[BranchID
 	return
 }
 
+// PruneRejectedBranch removes a Rejected Branch and its future cone (which is Rejected as well) from the BranchDAG and
+// leaves a tombstone for every removed Branch. It returns the BranchIDs of the pruned Branches.
+func (b *BranchDAG) PruneRejectedBranch(branchID BranchID) (prunedBranchIDs BranchIDs, err error) {
+	prunedBranchIDs = NewBranchIDs()
+
+	switch branchID {
+	case MasterBranchID, InvalidBranchID, LazyBookedConflictsBranchID:
+		err = xerrors.Errorf("tried to prune reserved Branch with %s: %w", branchID, cerrors.ErrFatal)
+		return
+	}
+
+	if b.BranchPruned(branchID) {
+		return
+	}
+
+	// abort if the Branch is not Rejected
+	if !b.Branch(branchID).Consume(func(branch Branch) {
+		if branch.InclusionState() != Rejected {
+			err = xerrors.Errorf("tried to prune non-rejected Branch with %s: %w", branchID, cerrors.ErrFatal)
+		}
+	}) {
+		err = xerrors.Errorf("failed to load Branch with %s: %w", branchID, cerrors.ErrFatal)
+	}
+	if err != nil {
+		return
+	}
+
+	// collect the future cone of the Branch
+	branchStack := list.New()
+	branchStack.PushBack(branchID)
+	for branchStack.Len() >= 1 {
+		currentStackElement := branchStack.Front()
+		branchStack.Remove(currentStackElement)
+
+		currentBranchID := currentStackElement.Value.(BranchID)
+		if _, seen := prunedBranchIDs[currentBranchID]; seen {
+			continue
+		}
+		prunedBranchIDs.Add(currentBranchID)
+
+		b.ChildBranches(currentBranchID).Consume(func(childBranch *ChildBranch) {
+			branchStack.PushBack(childBranch.ChildBranchID())
+		})
+	}
+
+	// remove the Branches and their references
+	for prunedBranchID := range prunedBranchIDs {
+		if err = b.pruneBranch(prunedBranchID); err != nil {
+			err = xerrors.Errorf("failed to prune Branch with %s: %w", prunedBranchID, err)
+			return
+		}
+	}
+
+	return
+}
+
+// BranchPruned returns true if the Branch with the given BranchID was removed by PruneRejectedBranch.
+func (b *BranchDAG) BranchPruned(branchID BranchID) (pruned bool) {
+	return b.branchTombstones.contains(branchID.Bytes())
+}
+
 // Branch retrieves the Branch with the given BranchID from the object storage.
 func (b *BranchDAG) Branch(branchID BranchID) (cachedBranch *CachedBranch) {
 	return &CachedBranch{CachedObject: b.branchStorage.Load(branchID.Bytes())}
@@ -300,13 +363,18 @@ func (b *BranchDAG) ConflictMembers(conflictID ConflictID) (cachedConflictMember
 }
 
 // BranchIDsContainRejectedBranch is an utility function that checks if the given BranchIDs contain a Rejected
-// Branch. It returns the BranchID of the first Rejected Branch that it finds.
+// Branch. It returns the BranchID of the first Rejected Branch that it finds. Pruned Branches are considered to be
+// Rejected.
 func (b *BranchDAG) BranchIDsContainRejectedBranch(branchIDs BranchIDs) (rejected bool, rejectedBranchID BranchID) {
 	for rejectedBranchID = range branchIDs {
 		if !b.Branch(rejectedBranchID).Consume(func(branch Branch) {
 			rejected = branch.InclusionState() == Rejected
 		}) {
-			panic(fmt.Sprintf("failed to load Branch with %s", rejectedBranchID))
+			if !b.BranchPruned(rejectedBranchID) {
+				panic(fmt.Sprintf("failed to load Branch with %s", rejectedBranchID))
+			}
+
+			rejected = true
 		}
 
 		if rejected {
@@ -333,6 +401,11 @@ func (b *BranchDAG) Prune() (err error) {
 		}
 	}
 
+	if err = b.branchTombstones.clear(); err != nil {
+		err = xerrors.Errorf("failed to clear the Branch tombstones (%v): %w", err, cerrors.ErrFatal)
+		return
+	}
+
 	b.init()
 
 	return
@@ -624,6 +697,38 @@ func (b *BranchDAG) aggregateNormalizedBranches(normalizedBranchIDs BranchIDs) (
 	return
 }
 
+// pruneBranch is an internal utility function that removes the given Branch together with its ConflictMembers and
+// ChildBranch references from the object storage and stores a tombstone for it.
+func (b *BranchDAG) pruneBranch(branchID BranchID) (err error) {
+	cachedBranch := b.Branch(branchID)
+	defer cachedBranch.Release()
+
+	branch := cachedBranch.Unwrap()
+	if branch == nil {
+		return xerrors.Errorf("failed to load Branch with %s: %w", branchID, cerrors.ErrFatal)
+	}
+
+	if conflictBranch, isConflictBranch := branch.(*ConflictBranch); isConflictBranch {
+		for conflictID := range conflictBranch.Conflicts() {
+			b.unregisterConflictMember(conflictID, branchID)
+		}
+	}
+
+	for parentBranchID := range branch.Parents() {
+		b.childBranchStorage.Delete(NewChildBranch(parentBranchID, branchID, branch.Type()).ObjectStorageKey())
+	}
+	b.ChildBranches(branchID).Consume(func(childBranch *ChildBranch) {
+		childBranch.Delete()
+	})
+
+	if err = b.branchTombstones.add(branchID.Bytes()); err != nil {
+		return xerrors.Errorf("failed to store tombstone of Branch with %s: %w", branchID, err)
+	}
+	branch.Delete()
+
+	return
+}
+
 // registerConflictMember is an internal utility function that removes the ConflictMember references of a Branch
 // belonging to a given Conflict. It automatically creates the Conflict if it doesn't exist, yet.
 func (b *BranchDAG) unregisterConflictMember(conflictID ConflictID, branchID BranchID) {
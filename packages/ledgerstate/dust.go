@@ -0,0 +1,286 @@
+package ledgerstate
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// region DustParams ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DustParams contains the parameters of the dust protection rules that prevent the ledger state from being bloated with
+// a large amount of Outputs that hold only a very small balance.
+type DustParams struct {
+	// MinDeposit defines the minimum balance that every Output needs to hold (0 disables the check).
+	MinDeposit uint64
+
+	// DustThreshold defines the balance below which an Output is considered to be dust (0 disables the limit of dust
+	// Outputs per Address). Transactions need to create the dust allowance for the dust Outputs of an Address in the
+	// same Transaction, which limits the dust per Transaction deterministically but not the dust that an Address
+	// accumulates over several Transactions. The latter depends on the confirmed ledger state of the node, so it is
+	// only enforced as a local filter for the Transactions that are issued by the node.
+	DustThreshold uint64
+
+	// DustAllowanceDivisor defines how much balance in non-dust Outputs an Address needs to hold to be allowed to own
+	// a single dust Output.
+	DustAllowanceDivisor uint64
+
+	// MaxDustOutputsPerAddress defines the maximum amount of dust Outputs that an Address can own irrespective of its
+	// dust allowance (0 means no upper bound).
+	MaxDustOutputsPerAddress uint64
+}
+
+// isDust returns true if the given Output is considered to be dust.
+func (d DustParams) isDust(output Output) bool {
+	return d.DustThreshold != 0 && outputDeposit(output) < d.DustThreshold
+}
+
+// allowedDustOutputs returns the amount of dust Outputs that an Address holding the given dust allowance can own.
+func (d DustParams) allowedDustOutputs(dustAllowance uint64) (allowedDustOutputs uint64) {
+	if d.DustAllowanceDivisor != 0 {
+		allowedDustOutputs = dustAllowance / d.DustAllowanceDivisor
+	}
+	if d.MaxDustOutputsPerAddress != 0 && allowedDustOutputs > d.MaxDustOutputsPerAddress {
+		allowedDustOutputs = d.MaxDustOutputsPerAddress
+	}
+
+	return
+}
+
+// outputDeposit returns the sum of all balances of the given Output.
+func outputDeposit(output Output) (deposit uint64) {
+	output.Balances().ForEach(func(color Color, balance uint64) bool {
+		deposit += balance
+
+		return true
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region dustBalance //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dustBalance keeps track of the amount of dust Outputs and the dust allowance of a single Address.
+type dustBalance struct {
+	dustOutputs   uint64
+	dustAllowance uint64
+}
+
+// add adds the given Output to the dustBalance.
+func (d *dustBalance) add(output Output, params DustParams) {
+	if params.isDust(output) {
+		d.dustOutputs++
+		return
+	}
+
+	d.dustAllowance += outputDeposit(output)
+}
+
+// dustBalanceFromBytes unmarshals a dustBalance from a sequence of bytes.
+func dustBalanceFromBytes(bytes []byte) (balance *dustBalance, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	balance = &dustBalance{}
+	if balance.dustOutputs, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse dust Output count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if balance.dustAllowance, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse dust allowance (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	return balance, nil
+}
+
+// remove removes the given Output from the dustBalance.
+func (d *dustBalance) remove(output Output, params DustParams) {
+	if params.isDust(output) {
+		if d.dustOutputs > 0 {
+			d.dustOutputs--
+		}
+		return
+	}
+
+	if deposit := outputDeposit(output); deposit < d.dustAllowance {
+		d.dustAllowance -= deposit
+		return
+	}
+	d.dustAllowance = 0
+}
+
+// valid returns true if the Address does not own more dust Outputs than its dust allowance permits.
+func (d *dustBalance) valid(params DustParams) bool {
+	return d.dustOutputs <= params.allowedDustOutputs(d.dustAllowance)
+}
+
+// Bytes returns a marshaled version of the dustBalance.
+func (d *dustBalance) Bytes() []byte {
+	return marshalutil.New(2 * marshalutil.Uint64Size).
+		WriteUint64(d.dustOutputs).
+		WriteUint64(d.dustAllowance).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region dustBalances /////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// dustBalancePrefix defines the storage prefix for the dustBalances of the Addresses.
+	dustBalancePrefix byte = iota
+
+	// dustOutputPrefix defines the storage prefix for the confirmed unspent Outputs that are part of the dustBalances.
+	dustOutputPrefix
+
+	// dustTransactionPrefix defines the storage prefix for the confirmed Transactions that were applied to the
+	// dustBalances.
+	dustTransactionPrefix
+)
+
+// dustBalances is the persistent index of the dustBalances of the Addresses. It only contains the confirmed unspent
+// Outputs, so that the dust allowance of an Address can be checked without iterating all of its Outputs.
+type dustBalances struct {
+	store kvstore.KVStore
+	mutex sync.RWMutex
+}
+
+// newDustBalances creates a new dustBalances index that is persisted in the given KVStore.
+func newDustBalances(store kvstore.KVStore) *dustBalances {
+	return &dustBalances{
+		store: store,
+	}
+}
+
+// balance returns the dustBalance of the confirmed unspent Outputs of the given Address.
+func (d *dustBalances) balance(address Address) (balance *dustBalance, err error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.load(address)
+}
+
+// contains returns true if the Output with the given OutputID is part of the dustBalances.
+func (d *dustBalances) contains(outputID OutputID) (contains bool, err error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.store.Has(byteutils.ConcatBytes([]byte{dustOutputPrefix}, outputID.Bytes()))
+}
+
+// addOutputs adds the given Outputs of a snapshot to the dustBalances.
+func (d *dustBalances) addOutputs(params DustParams, outputs ...Output) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.update(params, nil, outputs, nil)
+}
+
+// applyTransaction removes the consumed Outputs of the given confirmed Transaction from the dustBalances and adds the
+// created ones. Transactions that were applied before are ignored.
+func (d *dustBalances) applyTransaction(params DustParams, transaction *Transaction, consumedOutputs Outputs, createdOutputs Outputs) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	transactionKey := byteutils.ConcatBytes([]byte{dustTransactionPrefix}, transaction.ID().Bytes())
+	if applied, err := d.store.Has(transactionKey); err != nil || applied {
+		return err
+	}
+
+	return d.update(params, consumedOutputs, createdOutputs, transactionKey)
+}
+
+// update is an internal utility function that removes and adds the given Outputs and stores the modified dustBalances
+// (and the optional key of the applied Transaction) in a single batch.
+func (d *dustBalances) update(params DustParams, removedOutputs Outputs, addedOutputs Outputs, transactionKey []byte) (err error) {
+	balances := make(map[string]*dustBalance)
+	addresses := make(map[string]Address)
+	balance := func(address Address) (balance *dustBalance, err error) {
+		if balance, exists := balances[address.Base58()]; exists {
+			return balance, nil
+		}
+		if balance, err = d.load(address); err != nil {
+			return nil, err
+		}
+		balances[address.Base58()] = balance
+		addresses[address.Base58()] = address
+
+		return balance, nil
+	}
+
+	batch := d.store.Batched()
+	defer func() {
+		if err != nil {
+			batch.Cancel()
+		}
+	}()
+
+	for _, output := range removedOutputs {
+		outputKey := byteutils.ConcatBytes([]byte{dustOutputPrefix}, output.ID().Bytes())
+		contained, err := d.store.Has(outputKey)
+		if err != nil {
+			return xerrors.Errorf("failed to check if Output with %s is part of the dust balances: %w", output.ID(), err)
+		}
+		if !contained {
+			continue
+		}
+
+		addressBalance, err := balance(output.Address())
+		if err != nil {
+			return err
+		}
+		addressBalance.remove(output, params)
+		if err = batch.Delete(outputKey); err != nil {
+			return xerrors.Errorf("failed to remove Output with %s from the dust balances: %w", output.ID(), err)
+		}
+	}
+	for _, output := range addedOutputs {
+		addressBalance, err := balance(output.Address())
+		if err != nil {
+			return err
+		}
+		addressBalance.add(output, params)
+		if err = batch.Set(byteutils.ConcatBytes([]byte{dustOutputPrefix}, output.ID().Bytes()), []byte{}); err != nil {
+			return xerrors.Errorf("failed to add Output with %s to the dust balances: %w", output.ID(), err)
+		}
+	}
+
+	for addressBase58, addressBalance := range balances {
+		if err = batch.Set(byteutils.ConcatBytes([]byte{dustBalancePrefix}, addresses[addressBase58].Bytes()), addressBalance.Bytes()); err != nil {
+			return xerrors.Errorf("failed to store dust balance of %s: %w", addresses[addressBase58], err)
+		}
+	}
+	if transactionKey != nil {
+		if err = batch.Set(transactionKey, []byte{}); err != nil {
+			return xerrors.Errorf("failed to mark Transaction as applied to the dust balances: %w", err)
+		}
+	}
+
+	if err = batch.Commit(); err != nil {
+		return xerrors.Errorf("failed to commit update of the dust balances: %w", err)
+	}
+
+	return nil
+}
+
+// load is an internal utility function that loads the dustBalance of the given Address from the store.
+func (d *dustBalances) load(address Address) (balance *dustBalance, err error) {
+	balanceBytes, err := d.store.Get(byteutils.ConcatBytes([]byte{dustBalancePrefix}, address.Bytes()))
+	if err != nil {
+		if xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return &dustBalance{}, nil
+		}
+		return nil, xerrors.Errorf("failed to load dust balance of %s: %w", address, err)
+	}
+
+	if balance, err = dustBalanceFromBytes(balanceBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse dust balance of %s: %w", address, err)
+	}
+
+	return balance, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
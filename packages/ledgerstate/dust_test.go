@@ -0,0 +1,93 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestUTXODAG_DustProtection_MinDeposit(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t, DustProtection(DustParams{MinDeposit: 50}))
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+	tx := buildDustTransaction(wallets[0], input, NewSigLockedSingleOutput(60, wallets[1].address), NewSigLockedSingleOutput(40, wallets[1].address))
+
+	assert.True(t, xerrors.Is(utxoDAG.CheckDustProtection(tx), ErrTransactionInvalid))
+
+	targetBranch, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+	assert.Equal(t, InvalidBranchID, targetBranch)
+	assert.True(t, utxoDAG.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+		assert.Equal(t, InvalidReasonDustProtection, transactionMetadata.InvalidReason())
+
+		restoredTransactionMetadata, _, err := TransactionMetadataFromBytes(transactionMetadata.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, InvalidReasonDustProtection, restoredTransactionMetadata.InvalidReason())
+	}))
+}
+
+func TestUTXODAG_DustProtection_DustAllowance(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t, DustProtection(DustParams{
+		DustThreshold:        10,
+		DustAllowanceDivisor: 20,
+	}))
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(4)
+
+	// the dust Output is covered by the allowance that is created on the same Address
+	tx1 := buildDustTransaction(wallets[0], generateOutput(utxoDAG, wallets[0].address, 1), NewSigLockedSingleOutput(95, wallets[1].address), NewSigLockedSingleOutput(5, wallets[1].address))
+	require.NoError(t, utxoDAG.CheckDustProtection(tx1))
+	targetBranch, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+	assert.Equal(t, MasterBranchID, targetBranch)
+
+	// the balances only contain the confirmed Outputs
+	balance, err := utxoDAG.dustBalances.balance(wallets[1].address)
+	require.NoError(t, err)
+	assert.Equal(t, &dustBalance{}, balance)
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx1.ID()))
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx1.ID()))
+	balance, err = utxoDAG.dustBalances.balance(wallets[1].address)
+	require.NoError(t, err)
+	assert.Equal(t, &dustBalance{dustOutputs: 1, dustAllowance: 95}, balance)
+
+	// Transactions that create dust without creating the allowance on the same Address are invalid
+	tx2 := buildDustTransaction(wallets[0], generateOutput(utxoDAG, wallets[0].address, 2), NewSigLockedSingleOutput(5, wallets[2].address), NewSigLockedSingleOutput(95, wallets[0].address))
+	assert.True(t, xerrors.Is(utxoDAG.CheckDustProtection(tx2), ErrTransactionInvalid))
+	targetBranch, err = utxoDAG.BookTransaction(tx2)
+	require.NoError(t, err)
+	assert.Equal(t, InvalidBranchID, targetBranch)
+
+	// the allowance can not be spent while the Address still owns dust (the confirmed allowance of an Address is only
+	// checked locally and does not mark the Transaction as invalid)
+	var allowanceOutput Output
+	for _, output := range tx1.Essence().Outputs() {
+		if outputDeposit(output) == 95 {
+			allowanceOutput = output
+		}
+	}
+	tx3 := buildDustTransaction(wallets[1], allowanceOutput, NewSigLockedSingleOutput(95, wallets[3].address))
+	assert.Error(t, utxoDAG.CheckDustProtection(tx3))
+
+	// confirming the spend removes the allowance from the balance of the Address
+	targetBranch, err = utxoDAG.BookTransaction(tx3)
+	require.NoError(t, err)
+	assert.Equal(t, MasterBranchID, targetBranch)
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx3.ID()))
+	balance, err = utxoDAG.dustBalances.balance(wallets[1].address)
+	require.NoError(t, err)
+	assert.Equal(t, &dustBalance{dustOutputs: 1}, balance)
+}
+
+func buildDustTransaction(spender wallet, input Output, outputs ...Output) *Transaction {
+	txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(outputs...))
+
+	return NewTransaction(txEssence, spender.unlockBlocks(txEssence))
+}
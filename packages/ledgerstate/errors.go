@@ -13,4 +13,17 @@ var (
 
 	// ErrInvalidStateTransition is returned if there is an invalid state transition in the ledger state.
 	ErrInvalidStateTransition = errors.New("invalid state transition")
+
+	// ErrUnsupportedSnapshotVersion is returned if a snapshot is read whose format version is not known.
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+	// ErrSnapshotLedgerRootMismatch is returned if the Outputs of a snapshot do not match the ledger root of its header.
+	ErrSnapshotLedgerRootMismatch = errors.New("snapshot ledger root mismatch")
+
+	// ErrSnapshotManaPledgesMismatch is returned if the consensus mana that the ManaPledges of a snapshot pledge to a
+	// node does not match the balance of the Outputs that are pledged to it.
+	ErrSnapshotManaPledgesMismatch = errors.New("snapshot mana pledges are not backed by its outputs")
+
+	// ErrSnapshotWriterClosed is returned if Outputs are written to a SnapshotWriter that was already closed.
+	ErrSnapshotWriterClosed = errors.New("snapshot writer closed")
 )
@@ -0,0 +1,80 @@
+package ledgerstate
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// NoInvalidReason represents the InvalidReason of Transactions that have not been booked into the InvalidBranch.
+	NoInvalidReason InvalidReason = iota
+
+	// InvalidReasonInputsInvalid represents the InvalidReason of Transactions that consume Outputs of the InvalidBranch.
+	InvalidReasonInputsInvalid
+
+	// InvalidReasonPastConeInvalid represents the InvalidReason of Transactions that consume Outputs that are in each
+	// others past cone.
+	InvalidReasonPastConeInvalid
+
+	// InvalidReasonBranchesConflicting represents the InvalidReason of Transactions that consume Outputs of conflicting
+	// Branches.
+	InvalidReasonBranchesConflicting
+
+	// InvalidReasonDustProtection represents the InvalidReason of Transactions that violate the dust protection rules.
+	InvalidReasonDustProtection
+)
+
+// InvalidReason represents a type that encodes why a Transaction was booked into the InvalidBranch.
+type InvalidReason uint8
+
+// InvalidReasonFromBytes unmarshals an InvalidReason from a sequence of bytes.
+func InvalidReasonFromBytes(invalidReasonBytes []byte) (invalidReason InvalidReason, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(invalidReasonBytes)
+	if invalidReason, err = InvalidReasonFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse InvalidReason from MarshalUtil: %w", err)
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// InvalidReasonFromMarshalUtil unmarshals an InvalidReason using a MarshalUtil (for easier unmarshaling).
+func InvalidReasonFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (invalidReason InvalidReason, err error) {
+	invalidReasonUint8, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse InvalidReason (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if invalidReason = InvalidReason(invalidReasonUint8); invalidReason > InvalidReasonDustProtection {
+		err = xerrors.Errorf("unsupported InvalidReason (%X): %w", invalidReasonUint8, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the InvalidReason.
+func (i InvalidReason) Bytes() []byte {
+	return []byte{byte(i)}
+}
+
+// String returns a human readable version of the InvalidReason.
+func (i InvalidReason) String() string {
+	invalidReasonNames := [...]string{
+		"NoInvalidReason",
+		"InvalidReasonInputsInvalid",
+		"InvalidReasonPastConeInvalid",
+		"InvalidReasonBranchesConflicting",
+		"InvalidReasonDustProtection",
+	}
+
+	if int(i) >= len(invalidReasonNames) {
+		return fmt.Sprintf("InvalidReason(%X)", byte(i))
+	}
+
+	return invalidReasonNames[i]
+}
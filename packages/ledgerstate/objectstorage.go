@@ -36,6 +36,33 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixStateCommitmentStorage defines the storage prefix for the nodes and EpochCommitments of the StateCommitment.
+	PrefixStateCommitmentStorage
+
+	// PrefixAddressTransactionMappingStorage defines the storage prefix for the transaction history of the Addresses.
+	PrefixAddressTransactionMappingStorage
+
+	// PrefixColorMetadataStorage defines the storage prefix for the ColorMetadata object storage.
+	PrefixColorMetadataStorage
+
+	// PrefixColorSupplyChangeStorage defines the storage prefix for the ColorSupplyChange object storage.
+	PrefixColorSupplyChangeStorage
+
+	// PrefixBranchTombstoneStorage defines the storage prefix for the tombstones of pruned Branches.
+	PrefixBranchTombstoneStorage
+
+	// PrefixTransactionTombstoneStorage defines the storage prefix for the tombstones of pruned Transactions.
+	PrefixTransactionTombstoneStorage
+
+	// PrefixDustBalanceStorage defines the storage prefix for the dust balances of the confirmed unspent Outputs.
+	PrefixDustBalanceStorage
+
+	// PrefixSnapshotOutputPledgeStorage defines the storage prefix for the OutputPledges of the Outputs of a snapshot.
+	PrefixSnapshotOutputPledgeStorage
+
+	// PrefixColorSupplyStorage defines the storage prefix for the running totals of the ColorSupplies.
+	PrefixColorSupplyStorage
 )
 
 // branchStorageOptions contains a list of default settings for the Branch object storage.
@@ -101,3 +128,16 @@ var addressOutputMappingStorageOptions = []objectstorage.Option{
 	objectstorage.PartitionKey(AddressLength, OutputIDLength),
 	objectstorage.LeakDetectionEnabled(false),
 }
+
+// colorMetadataStorageOptions contains a list of default settings for the ColorMetadata object storage.
+var colorMetadataStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.LeakDetectionEnabled(false),
+}
+
+// colorSupplyChangeStorageOptions contains a list of default settings for the ColorSupplyChange object storage.
+var colorSupplyChangeStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.PartitionKey(ColorLength, TransactionIDLength),
+	objectstorage.LeakDetectionEnabled(false),
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"sync"
@@ -35,6 +36,9 @@ const (
 
 	// MaxOutputBalance defines the maximum balance on an Output (the supply).
 	MaxOutputBalance = 2779530283277761
+
+	// MaxAliasStateDataSize defines the maximum size of the state data that can be stored in an AliasOutput.
+	MaxAliasStateDataSize = 1024
 )
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -51,6 +55,14 @@ const (
 
 	// SigLockedColoredOutputType represents an Output that holds colored coins that gets unlocked by a signature.
 	SigLockedColoredOutputType
+
+	// AliasOutputType represents an Output that forms a chain of states with a stable AliasAddress and that gets
+	// unlocked by its state or governance controller.
+	AliasOutputType
+
+	// ExtendedLockedOutputType represents an Output that holds colored coins that can only be unlocked after a timelock
+	// and that can be reclaimed by a fallback address after a deadline.
+	ExtendedLockedOutputType
 )
 
 // String returns a human readable representation of the OutputType.
@@ -58,6 +70,8 @@ func (o OutputType) String() string {
 	return [...]string{
 		"SigLockedSingleOutputType",
 		"SigLockedColoredOutputType",
+		"AliasOutputType",
+		"ExtendedLockedOutputType",
 	}[o]
 }
 
@@ -187,8 +201,8 @@ type Output interface {
 	Address() Address
 
 	// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the
-	// Output.
-	UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (bool, error)
+	// Output (the consumed Outputs of the Transaction are required to resolve AliasUnlockBlocks).
+	UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (bool, error)
 
 	// Input returns an Input that references the Output.
 	Input() Input
@@ -241,6 +255,16 @@ func OutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output Output,
 			err = xerrors.Errorf("failed to parse SigLockedColoredOutput: %w", err)
 			return
 		}
+	case AliasOutputType:
+		if output, err = AliasOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AliasOutput: %w", err)
+			return
+		}
+	case ExtendedLockedOutputType:
+		if output, err = ExtendedLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse ExtendedLockedOutput: %w", err)
+			return
+		}
 	default:
 		err = xerrors.Errorf("unsupported OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
 		return
@@ -601,16 +625,8 @@ func (s *SigLockedSingleOutput) Balances() *ColoredBalances {
 }
 
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
-func (s *SigLockedSingleOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (unlockValid bool, err error) {
-	signatureUnlockBlock, correctType := unlockBlock.(*SignatureUnlockBlock)
-	if !correctType {
-		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
-		return
-	}
-
-	unlockValid = signatureUnlockBlock.AddressSignatureValid(s.address, tx.Essence().Bytes())
-
-	return
+func (s *SigLockedSingleOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	return addressUnlockValid(s.address, tx, unlockBlock, inputs)
 }
 
 // Address returns the Address that the Output is associated to.
@@ -772,16 +788,8 @@ func (s *SigLockedColoredOutput) Balances() *ColoredBalances {
 }
 
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
-func (s *SigLockedColoredOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (unlockValid bool, err error) {
-	signatureUnlockBlock, correctType := unlockBlock.(*SignatureUnlockBlock)
-	if !correctType {
-		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
-		return
-	}
-
-	unlockValid = signatureUnlockBlock.AddressSignatureValid(s.address, tx.Essence().Bytes())
-
-	return
+func (s *SigLockedColoredOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	return addressUnlockValid(s.address, tx, unlockBlock, inputs)
 }
 
 // Address returns the Address that the Output is associated to.
@@ -867,6 +875,689 @@ var _ Output = &SigLockedColoredOutput{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region AliasOutput //////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// aliasOutputFlagOrigin marks an AliasOutput that creates a new chain (its AliasAddress is derived from its OutputID).
+	aliasOutputFlagOrigin byte = 1 << iota
+
+	// aliasOutputFlagGovernanceUpdate marks an AliasOutput that was created by the governance controller of the chain.
+	aliasOutputFlagGovernanceUpdate
+)
+
+// AliasOutput is an Output that forms a chain of states with a stable identity. The AliasAddress of the chain is derived
+// from the OutputID of the origin AliasOutput and every consecutive AliasOutput of the chain carries it forward.
+//
+// The state controller can advance the state (increasing the state index by one and updating the state data and the
+// balances) while the governance controller can change the controllers or destroy the chain. Outputs that are owned by
+// the AliasAddress can be unlocked by unlocking the current AliasOutput of the chain in the same Transaction.
+type AliasOutput struct {
+	id                 OutputID
+	idMutex            sync.RWMutex
+	balances           *ColoredBalances
+	aliasAddress       *AliasAddress
+	stateAddress       Address
+	governingAddress   Address
+	stateIndex         uint32
+	stateData          []byte
+	isGovernanceUpdate bool
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAliasOutputMint is the constructor for the origin AliasOutput of a new chain.
+func NewAliasOutputMint(balances *ColoredBalances, stateAddress Address, governingAddress Address, stateData []byte) (output *AliasOutput, err error) {
+	if stateAddress == nil || governingAddress == nil {
+		err = xerrors.Errorf("state and governance controller of an AliasOutput need to be set: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+	if len(stateData) > MaxAliasStateDataSize {
+		err = xerrors.Errorf("state data of AliasOutput (%d bytes) exceeds MaxAliasStateDataSize (%d): %w", len(stateData), MaxAliasStateDataSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &AliasOutput{
+		balances:         balances,
+		stateAddress:     stateAddress,
+		governingAddress: governingAddress,
+		stateData:        stateData,
+	}
+
+	return
+}
+
+// AliasOutputFromBytes unmarshals an AliasOutput from a sequence of bytes.
+func AliasOutputFromBytes(bytes []byte) (output *AliasOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if output, err = AliasOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasOutputFromMarshalUtil unmarshals an AliasOutput using a MarshalUtil (for easier unmarshaling).
+func AliasOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *AliasOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != AliasOutputType {
+		err = xerrors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &AliasOutput{}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	flags, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse flags (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	output.isGovernanceUpdate = flags&aliasOutputFlagGovernanceUpdate != 0
+	if flags&aliasOutputFlagOrigin == 0 {
+		if output.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AliasAddress: %w", err)
+			return
+		}
+	}
+	if output.stateAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse state controller Address: %w", err)
+		return
+	}
+	if output.governingAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse governance controller Address: %w", err)
+		return
+	}
+	if output.stateIndex, err = marshalUtil.ReadUint32(); err != nil {
+		err = xerrors.Errorf("failed to parse state index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	stateDataSize, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse state data size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if stateDataSize > MaxAliasStateDataSize {
+		err = xerrors.Errorf("state data of AliasOutput (%d bytes) exceeds MaxAliasStateDataSize (%d): %w", stateDataSize, MaxAliasStateDataSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.stateData, err = marshalUtil.ReadBytes(int(stateDataSize)); err != nil {
+		err = xerrors.Errorf("failed to parse state data (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.IsOrigin() && (output.stateIndex != 0 || output.isGovernanceUpdate) {
+		err = xerrors.Errorf("origin AliasOutput needs to be a state update with a state index of 0: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (a *AliasOutput) ID() OutputID {
+	a.idMutex.RLock()
+	defer a.idMutex.RUnlock()
+
+	return a.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (a *AliasOutput) SetID(outputID OutputID) Output {
+	a.idMutex.Lock()
+	defer a.idMutex.Unlock()
+
+	a.id = outputID
+
+	return a
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (a *AliasOutput) Type() OutputType {
+	return AliasOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (a *AliasOutput) Balances() *ColoredBalances {
+	return a.balances
+}
+
+// Address returns the AliasAddress of the chain that the Output belongs to.
+func (a *AliasOutput) Address() Address {
+	return a.AliasAddress()
+}
+
+// AliasAddress returns the stable identity of the chain. The origin AliasOutput derives it from its own OutputID while
+// all consecutive AliasOutputs carry it explicitly.
+func (a *AliasOutput) AliasAddress() *AliasAddress {
+	if a.IsOrigin() {
+		return NewAliasAddress(a.ID().Bytes())
+	}
+
+	return a.aliasAddress
+}
+
+// IsOrigin returns true if the AliasOutput creates a new chain.
+func (a *AliasOutput) IsOrigin() bool {
+	return a.aliasAddress == nil
+}
+
+// IsGovernanceUpdate returns true if the AliasOutput was created by the governance controller of the chain.
+func (a *AliasOutput) IsGovernanceUpdate() bool {
+	return a.isGovernanceUpdate
+}
+
+// StateAddress returns the Address of the state controller.
+func (a *AliasOutput) StateAddress() Address {
+	return a.stateAddress
+}
+
+// GoverningAddress returns the Address of the governance controller.
+func (a *AliasOutput) GoverningAddress() Address {
+	return a.governingAddress
+}
+
+// StateIndex returns the number of state transitions since the origin of the chain.
+func (a *AliasOutput) StateIndex() uint32 {
+	return a.stateIndex
+}
+
+// StateData returns the arbitrary state data that is anchored in the Output.
+func (a *AliasOutput) StateData() []byte {
+	return a.stateData
+}
+
+// NewAliasOutputNext creates the successor of the AliasOutput in its chain. A state update increases the state index
+// while a governance update keeps the state untouched. The returned AliasOutput can be modified with the setters before
+// it is added to a Transaction.
+func (a *AliasOutput) NewAliasOutputNext(governanceUpdate bool) (next *AliasOutput) {
+	next = a.Clone().(*AliasOutput)
+	next.id = EmptyOutputID
+	next.aliasAddress = a.AliasAddress().Clone().(*AliasAddress)
+	next.isGovernanceUpdate = governanceUpdate
+	if !governanceUpdate {
+		next.stateIndex++
+	}
+
+	return
+}
+
+// SetBalances updates the funds that are associated with the Output.
+func (a *AliasOutput) SetBalances(balances *ColoredBalances) {
+	a.balances = balances
+}
+
+// SetStateAddress updates the Address of the state controller (only allowed in governance updates).
+func (a *AliasOutput) SetStateAddress(address Address) {
+	a.stateAddress = address
+}
+
+// SetGoverningAddress updates the Address of the governance controller (only allowed in governance updates).
+func (a *AliasOutput) SetGoverningAddress(address Address) {
+	a.governingAddress = address
+}
+
+// SetStateData updates the state data of the Output (only allowed in state updates).
+func (a *AliasOutput) SetStateData(stateData []byte) (err error) {
+	if len(stateData) > MaxAliasStateDataSize {
+		err = xerrors.Errorf("state data of AliasOutput (%d bytes) exceeds MaxAliasStateDataSize (%d): %w", len(stateData), MaxAliasStateDataSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+	a.stateData = stateData
+
+	return
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output. A
+// state update needs to be unlocked by the state controller while governance updates and the destruction of the chain
+// need to be unlocked by the governance controller.
+func (a *AliasOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	next, err := a.successor(tx.Essence().Outputs())
+	if err != nil {
+		err = xerrors.Errorf("failed to determine successor of AliasOutput: %w", err)
+		return
+	}
+
+	if next != nil && !next.isGovernanceUpdate {
+		return addressUnlockValid(a.stateAddress, tx, unlockBlock, inputs)
+	}
+
+	return addressUnlockValid(a.governingAddress, tx, unlockBlock, inputs)
+}
+
+// Input returns an Input that references the Output.
+func (a *AliasOutput) Input() Input {
+	if a.ID() == EmptyOutputID {
+		panic("Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(a.ID())
+}
+
+// Clone creates a copy of the Output.
+func (a *AliasOutput) Clone() Output {
+	clonedOutput := &AliasOutput{
+		id:                 a.ID(),
+		balances:           a.balances.Clone(),
+		stateAddress:       a.stateAddress.Clone(),
+		governingAddress:   a.governingAddress.Clone(),
+		stateIndex:         a.stateIndex,
+		stateData:          make([]byte, len(a.stateData)),
+		isGovernanceUpdate: a.isGovernanceUpdate,
+	}
+	copy(clonedOutput.stateData, a.stateData)
+	if a.aliasAddress != nil {
+		clonedOutput.aliasAddress = a.aliasAddress.Clone().(*AliasAddress)
+	}
+
+	return clonedOutput
+}
+
+// Bytes returns a marshaled version of the Output.
+func (a *AliasOutput) Bytes() []byte {
+	return a.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AliasOutput) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AliasOutput) ObjectStorageKey() []byte {
+	return a.ID().Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (a *AliasOutput) ObjectStorageValue() []byte {
+	var flags byte
+	if a.IsOrigin() {
+		flags |= aliasOutputFlagOrigin
+	}
+	if a.isGovernanceUpdate {
+		flags |= aliasOutputFlagGovernanceUpdate
+	}
+
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(AliasOutputType)).
+		WriteBytes(a.balances.Bytes()).
+		WriteByte(flags)
+	if !a.IsOrigin() {
+		marshalUtil.WriteBytes(a.aliasAddress.Bytes())
+	}
+
+	return marshalUtil.
+		WriteBytes(a.stateAddress.Bytes()).
+		WriteBytes(a.governingAddress.Bytes()).
+		WriteUint32(a.stateIndex).
+		WriteUint16(uint16(len(a.stateData))).
+		WriteBytes(a.stateData).
+		Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (a *AliasOutput) Compare(other Output) int {
+	return bytes.Compare(a.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (a *AliasOutput) String() string {
+	return stringify.Struct("AliasOutput",
+		stringify.StructField("id", a.ID()),
+		stringify.StructField("aliasAddress", a.AliasAddress()),
+		stringify.StructField("balances", a.balances),
+		stringify.StructField("stateAddress", a.stateAddress),
+		stringify.StructField("governingAddress", a.governingAddress),
+		stringify.StructField("stateIndex", a.stateIndex),
+		stringify.StructField("stateData", a.stateData),
+		stringify.StructField("isGovernanceUpdate", a.isGovernanceUpdate),
+	)
+}
+
+// successor returns the AliasOutput that continues the chain in the given Outputs (or nil if the chain is destroyed).
+func (a *AliasOutput) successor(outputs Outputs) (next *AliasOutput, err error) {
+	aliasAddress := a.AliasAddress().Array()
+	for _, output := range outputs {
+		aliasOutput, isAliasOutput := output.(*AliasOutput)
+		if !isAliasOutput || aliasOutput.IsOrigin() || aliasOutput.aliasAddress.Array() != aliasAddress {
+			continue
+		}
+
+		if next != nil {
+			err = xerrors.Errorf("chain of %s is continued by more than one AliasOutput: %w", a.AliasAddress().Base58(), ErrTransactionInvalid)
+			return
+		}
+		next = aliasOutput
+	}
+
+	return
+}
+
+// transitionValid checks if the given AliasOutput is a valid successor of the AliasOutput in its chain.
+func (a *AliasOutput) transitionValid(next *AliasOutput) bool {
+	if next.IsOrigin() || next.aliasAddress.Array() != a.AliasAddress().Array() {
+		return false
+	}
+
+	if next.isGovernanceUpdate {
+		return next.stateIndex == a.stateIndex &&
+			bytes.Equal(next.stateData, a.stateData) &&
+			bytes.Equal(next.balances.Bytes(), a.balances.Bytes())
+	}
+
+	return a.stateIndex < math.MaxUint32 && next.stateIndex == a.stateIndex+1 &&
+		next.stateAddress.Array() == a.stateAddress.Array() &&
+		next.governingAddress.Array() == a.governingAddress.Array()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &AliasOutput{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ExtendedLockedOutput /////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// extendedLockedOutputFlagFallback marks an ExtendedLockedOutput that returns to a fallback address after a deadline.
+	extendedLockedOutputFlagFallback byte = 1 << iota
+
+	// extendedLockedOutputFlagTimeLock marks an ExtendedLockedOutput that can not be unlocked before a timelock.
+	extendedLockedOutputFlagTimeLock
+)
+
+// ExtendedLockedOutput is an Output that holds colored balances and that adds time based conditions to the signature
+// of its Address. The Address can only unlock the Output once the timelock has passed. If fallback options are set, the
+// Address can only unlock the Output before the fallback deadline and the fallback address takes over afterwards. All
+// conditions are checked against the timestamp of the spending Transaction.
+type ExtendedLockedOutput struct {
+	id               OutputID
+	idMutex          sync.RWMutex
+	balances         *ColoredBalances
+	address          Address
+	fallbackAddress  Address
+	fallbackDeadline time.Time
+	timelock         time.Time
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewExtendedLockedOutput is the constructor for an ExtendedLockedOutput without any additional conditions.
+func NewExtendedLockedOutput(balances *ColoredBalances, address Address) *ExtendedLockedOutput {
+	return &ExtendedLockedOutput{
+		balances: balances,
+		address:  address,
+	}
+}
+
+// WithFallbackOptions adds a fallback address to the Output that can unlock it after the given deadline.
+func (e *ExtendedLockedOutput) WithFallbackOptions(fallbackAddress Address, fallbackDeadline time.Time) *ExtendedLockedOutput {
+	e.fallbackAddress = fallbackAddress
+	e.fallbackDeadline = fallbackDeadline
+
+	return e
+}
+
+// WithTimeLock adds a timelock to the Output that prevents the Address from unlocking it before the given time.
+func (e *ExtendedLockedOutput) WithTimeLock(timelock time.Time) *ExtendedLockedOutput {
+	e.timelock = timelock
+
+	return e
+}
+
+// ExtendedLockedOutputFromBytes unmarshals an ExtendedLockedOutput from a sequence of bytes.
+func ExtendedLockedOutputFromBytes(bytes []byte) (output *ExtendedLockedOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if output, err = ExtendedLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ExtendedLockedOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ExtendedLockedOutputFromMarshalUtil unmarshals an ExtendedLockedOutput using a MarshalUtil (for easier unmarshaling).
+func ExtendedLockedOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *ExtendedLockedOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != ExtendedLockedOutputType {
+		err = xerrors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &ExtendedLockedOutput{}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	if output.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	flags, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse flags (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if flags&extendedLockedOutputFlagFallback != 0 {
+		if output.fallbackAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse fallback Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if output.fallbackDeadline, err = marshalUtil.ReadTime(); err != nil {
+			err = xerrors.Errorf("failed to parse fallback deadline (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if flags&extendedLockedOutputFlagTimeLock != 0 {
+		if output.timelock, err = marshalUtil.ReadTime(); err != nil {
+			err = xerrors.Errorf("failed to parse timelock (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+
+	// the Address would never be able to unlock the Output if the fallback deadline does not lie after the timelock
+	if output.fallbackAddress != nil && !output.timelock.IsZero() && !output.fallbackDeadline.After(output.timelock) {
+		err = xerrors.Errorf("fallback deadline (%v) needs to be after the timelock (%v): %w", output.fallbackDeadline, output.timelock, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (e *ExtendedLockedOutput) ID() OutputID {
+	e.idMutex.RLock()
+	defer e.idMutex.RUnlock()
+
+	return e.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (e *ExtendedLockedOutput) SetID(outputID OutputID) Output {
+	e.idMutex.Lock()
+	defer e.idMutex.Unlock()
+
+	e.id = outputID
+
+	return e
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (e *ExtendedLockedOutput) Type() OutputType {
+	return ExtendedLockedOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (e *ExtendedLockedOutput) Balances() *ColoredBalances {
+	return e.balances
+}
+
+// Address returns the Address of the recipient of the Output.
+func (e *ExtendedLockedOutput) Address() Address {
+	return e.address
+}
+
+// FallbackAddress returns the Address that can unlock the Output after the fallback deadline (nil if not set).
+func (e *ExtendedLockedOutput) FallbackAddress() Address {
+	return e.fallbackAddress
+}
+
+// FallbackDeadline returns the time after which the fallback Address takes over the Output (zero if not set).
+func (e *ExtendedLockedOutput) FallbackDeadline() time.Time {
+	return e.fallbackDeadline
+}
+
+// TimeLock returns the time before which the Address can not unlock the Output (zero if not set).
+func (e *ExtendedLockedOutput) TimeLock() time.Time {
+	return e.timelock
+}
+
+// UnlockAddressNow returns the Address that is allowed to unlock the Output at the given time (nil if the Output is
+// still timelocked).
+func (e *ExtendedLockedOutput) UnlockAddressNow(now time.Time) Address {
+	if e.fallbackAddress != nil && !now.Before(e.fallbackDeadline) {
+		return e.fallbackAddress
+	}
+	if now.Before(e.timelock) {
+		return nil
+	}
+
+	return e.address
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
+func (e *ExtendedLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	unlockAddress := e.UnlockAddressNow(tx.Essence().Timestamp())
+	if unlockAddress == nil {
+		return
+	}
+
+	return addressUnlockValid(unlockAddress, tx, unlockBlock, inputs)
+}
+
+// Input returns an Input that references the Output.
+func (e *ExtendedLockedOutput) Input() Input {
+	if e.ID() == EmptyOutputID {
+		panic("Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(e.ID())
+}
+
+// Clone creates a copy of the Output.
+func (e *ExtendedLockedOutput) Clone() Output {
+	clonedOutput := &ExtendedLockedOutput{
+		id:               e.ID(),
+		balances:         e.balances.Clone(),
+		address:          e.address.Clone(),
+		fallbackDeadline: e.fallbackDeadline,
+		timelock:         e.timelock,
+	}
+	if e.fallbackAddress != nil {
+		clonedOutput.fallbackAddress = e.fallbackAddress.Clone()
+	}
+
+	return clonedOutput
+}
+
+// UpdateMintingColor replaces the ColorMint in the balances of the Output with the hash of the OutputID. It returns a
+// copy of the original Output with the modified balances.
+func (e *ExtendedLockedOutput) UpdateMintingColor() (updatedOutput *ExtendedLockedOutput) {
+	coloredBalances := e.Balances().Map()
+	if mintedCoins, mintedCoinsExist := coloredBalances[ColorMint]; mintedCoinsExist {
+		delete(coloredBalances, ColorMint)
+		coloredBalances[Color(blake2b.Sum256(e.ID().Bytes()))] = mintedCoins
+	}
+	updatedOutput = e.Clone().(*ExtendedLockedOutput)
+	updatedOutput.balances = NewColoredBalances(coloredBalances)
+
+	return
+}
+
+// Bytes returns a marshaled version of the Output.
+func (e *ExtendedLockedOutput) Bytes() []byte {
+	return e.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (e *ExtendedLockedOutput) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (e *ExtendedLockedOutput) ObjectStorageKey() []byte {
+	return e.ID().Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (e *ExtendedLockedOutput) ObjectStorageValue() []byte {
+	var flags byte
+	if e.fallbackAddress != nil {
+		flags |= extendedLockedOutputFlagFallback
+	}
+	if !e.timelock.IsZero() {
+		flags |= extendedLockedOutputFlagTimeLock
+	}
+
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(ExtendedLockedOutputType)).
+		WriteBytes(e.balances.Bytes()).
+		WriteBytes(e.address.Bytes()).
+		WriteByte(flags)
+	if e.fallbackAddress != nil {
+		marshalUtil.WriteBytes(e.fallbackAddress.Bytes()).WriteTime(e.fallbackDeadline)
+	}
+	if !e.timelock.IsZero() {
+		marshalUtil.WriteTime(e.timelock)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (e *ExtendedLockedOutput) Compare(other Output) int {
+	return bytes.Compare(e.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (e *ExtendedLockedOutput) String() string {
+	return stringify.Struct("ExtendedLockedOutput",
+		stringify.StructField("id", e.ID()),
+		stringify.StructField("address", e.address),
+		stringify.StructField("balances", e.balances),
+		stringify.StructField("fallbackAddress", e.fallbackAddress),
+		stringify.StructField("fallbackDeadline", e.fallbackDeadline),
+		stringify.StructField("timelock", e.timelock),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &ExtendedLockedOutput{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CachedOutput /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CachedOutput is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
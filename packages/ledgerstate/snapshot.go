@@ -2,28 +2,56 @@ package ledgerstate
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
 )
 
-// Snapshot defines a snapshot of the ledger state.
-type Snapshot map[TransactionID]map[Address]*ColoredBalances
+// region Snapshot /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Snapshot defines a snapshot of the ledger state. It consists of the genesis Transactions, whose outputs are created
+// from the given balances, and of a list of unspent Outputs that keep their original OutputIDs (i.e. the ledger state
+// of a local snapshot).
+type Snapshot struct {
+	Transactions map[TransactionID]map[Address]*ColoredBalances
+	Outputs      Outputs
+}
 
-// WriteTo writes the snapshot data to the given writer in the following format:
+// NewSnapshot returns a new empty Snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		Transactions: make(map[TransactionID]map[Address]*ColoredBalances),
+		Outputs:      make(Outputs, 0),
+	}
+}
+
+// WriteTo writes the snapshot data to the given writer in the legacy (version 1) format:
 // 	transaction_count(int64)
 //	-> transaction_count * transaction_id(32byte)
 //		->address_count(int64)
 //			->address_count * address(33byte)
 //				->balance_count(int64)
 //					->balance_count * value(int64)+color(32byte)
-func (s Snapshot) WriteTo(writer io.Writer) (int64, error) {
+//	output_count(int64)
+//	-> output_count * output_id(34byte)+output_length(int64)+output(output_length byte)
+func (s *Snapshot) WriteTo(writer io.Writer) (int64, error) {
 	var bytesWritten int64
-	transactionCount := len(s)
+	transactionCount := len(s.Transactions)
 	if err := binary.Write(writer, binary.LittleEndian, int64(transactionCount)); err != nil {
 		return 0, fmt.Errorf("unable to write transactions count: %w", err)
 	}
 	bytesWritten += 8
-	for txID, addresses := range s {
+	for txID, addresses := range s.Transactions {
 		if err := binary.Write(writer, binary.LittleEndian, txID); err != nil {
 			return bytesWritten, fmt.Errorf("unable to write transaction ID: %w", err)
 		}
@@ -61,73 +89,850 @@ func (s Snapshot) WriteTo(writer io.Writer) (int64, error) {
 		}
 	}
 
+	if err := binary.Write(writer, binary.LittleEndian, int64(len(s.Outputs))); err != nil {
+		return bytesWritten, fmt.Errorf("unable to write output count: %w", err)
+	}
+	bytesWritten += 8
+	for _, output := range s.Outputs {
+		if err := binary.Write(writer, binary.LittleEndian, output.ID().Bytes()); err != nil {
+			return bytesWritten, fmt.Errorf("unable to write output ID: %w", err)
+		}
+		bytesWritten += OutputIDLength
+		outputBytes := output.Bytes()
+		if err := binary.Write(writer, binary.LittleEndian, int64(len(outputBytes))); err != nil {
+			return bytesWritten, fmt.Errorf("unable to write output length: %w", err)
+		}
+		bytesWritten += 8
+		if err := binary.Write(writer, binary.LittleEndian, outputBytes); err != nil {
+			return bytesWritten, fmt.Errorf("unable to write output: %w", err)
+		}
+		bytesWritten += int64(len(outputBytes))
+	}
+
 	return bytesWritten, nil
 }
 
-// ReadFrom reads the snapshot bytes from the given reader.
-// This function overrides existing content of the snapshot.
-func (s Snapshot) ReadFrom(reader io.Reader) (int64, error) {
-	var bytesRead int64
-	var transactionCount int64
-	if err := binary.Read(reader, binary.LittleEndian, &transactionCount); err != nil {
-		return 0, fmt.Errorf("unable to read transaction count: %w", err)
+// ReadFrom reads the snapshot bytes from the given reader. Both the legacy format written by WriteTo and the versioned
+// format written by the SnapshotWriter are supported (the OutputMetadata of the latter is not retained).
+// This function overrides existing content of the snapshot. Snapshots that were written before the outputs section was
+// introduced are still supported and result in an empty list of Outputs.
+func (s *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
+	s.Transactions = make(map[TransactionID]map[Address]*ColoredBalances)
+	s.Outputs = make(Outputs, 0)
+
+	countingReader := &countingReader{reader: reader}
+	var magic uint64
+	if err := binary.Read(countingReader, binary.LittleEndian, &magic); err != nil {
+		return countingReader.count, fmt.Errorf("unable to read transaction count: %w", err)
 	}
-	bytesRead += 8
 
+	if magic != snapshotMagic {
+		err := s.readLegacyFrom(countingReader, int64(magic))
+
+		return countingReader.count, err
+	}
+
+	snapshotReader, err := newSnapshotReader(countingReader)
+	if err != nil {
+		return countingReader.count, err
+	}
+	for {
+		output, _, _, err := snapshotReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return countingReader.count, nil
+			}
+			return countingReader.count, err
+		}
+		s.Outputs = append(s.Outputs, output)
+	}
+}
+
+// readLegacyFrom is an internal utility function that reads the remaining content of a legacy snapshot whose
+// transaction count was already consumed from the reader.
+func (s *Snapshot) readLegacyFrom(reader io.Reader, transactionCount int64) error {
 	var i int64
 	for ; i < transactionCount; i++ {
 		txIDBytes := make([]byte, TransactionIDLength)
 		if err := binary.Read(reader, binary.LittleEndian, txIDBytes); err != nil {
-			return bytesRead, fmt.Errorf("unable to read transaction ID: %w", err)
+			return fmt.Errorf("unable to read transaction ID: %w", err)
 		}
-		bytesRead += TransactionIDLength
 		var addrCount int64
 		if err := binary.Read(reader, binary.LittleEndian, &addrCount); err != nil {
-			return bytesRead, fmt.Errorf("unable to read address count: %w", err)
+			return fmt.Errorf("unable to read address count: %w", err)
 		}
-		bytesRead += 8
-		txAddrMap := make(map[Address]*ColoredBalances, addrCount)
+		txAddrMap := make(map[Address]*ColoredBalances)
 		var j int64
 		for ; j < addrCount; j++ {
 			addrBytes := make([]byte, AddressLength)
 			if err := binary.Read(reader, binary.LittleEndian, addrBytes); err != nil {
-				return bytesRead, fmt.Errorf("unable to read address: %w", err)
+				return fmt.Errorf("unable to read address: %w", err)
 			}
-			bytesRead += AddressLength
 			var balanceCount int64
 			if err := binary.Read(reader, binary.LittleEndian, &balanceCount); err != nil {
-				return bytesRead, fmt.Errorf("unable to read balance count: %w", err)
+				return fmt.Errorf("unable to read balance count: %w", err)
 			}
-			bytesRead += 8
 
-			balances := make(map[Color]uint64, balanceCount)
+			balances := make(map[Color]uint64)
 			var k int64
 			for ; k < balanceCount; k++ {
 				var value uint64
 				if err := binary.Read(reader, binary.LittleEndian, &value); err != nil {
-					return bytesRead, fmt.Errorf("unable to read balance value: %w", err)
+					return fmt.Errorf("unable to read balance value: %w", err)
 				}
-				bytesRead += 8
 				color := Color{}
 				if err := binary.Read(reader, binary.LittleEndian, &color); err != nil {
-					return bytesRead, fmt.Errorf("unable to read balance color: %w", err)
+					return fmt.Errorf("unable to read balance color: %w", err)
 				}
-				bytesRead += ColorLength
 				balances[color] = value
 			}
 			coloredBalances := NewColoredBalances(balances)
 			addr, _, err := AddressFromBytes(addrBytes)
 			if err != nil {
-				return bytesRead, fmt.Errorf("unable to unmarshal address: %w", err)
+				return fmt.Errorf("unable to unmarshal address: %w", err)
 			}
 			txAddrMap[addr] = coloredBalances
 		}
 		txID, _, err := TransactionIDFromBytes(txIDBytes)
 		if err != nil {
-			return bytesRead, fmt.Errorf("unable to unmarshal txIDbytes: %w", err)
+			return fmt.Errorf("unable to unmarshal txIDbytes: %w", err)
+		}
+		s.Transactions[txID] = txAddrMap
+	}
+
+	var outputCount int64
+	if err := binary.Read(reader, binary.LittleEndian, &outputCount); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return fmt.Errorf("unable to read output count: %w", err)
+	}
+
+	for i = 0; i < outputCount; i++ {
+		outputIDBytes := make([]byte, OutputIDLength)
+		if err := binary.Read(reader, binary.LittleEndian, outputIDBytes); err != nil {
+			return fmt.Errorf("unable to read output ID: %w", err)
+		}
+		var outputLength int64
+		if err := binary.Read(reader, binary.LittleEndian, &outputLength); err != nil {
+			return fmt.Errorf("unable to read output length: %w", err)
+		}
+		if outputLength < 0 || outputLength > maxSnapshotElementSize {
+			return fmt.Errorf("output length %d exceeds the maximum of %d bytes: %w", outputLength, maxSnapshotElementSize, cerrors.ErrParseBytesFailed)
+		}
+		outputBytes := make([]byte, outputLength)
+		if err := binary.Read(reader, binary.LittleEndian, outputBytes); err != nil {
+			return fmt.Errorf("unable to read output: %w", err)
+		}
+
+		outputID, _, err := OutputIDFromBytes(outputIDBytes)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal output ID: %w", err)
 		}
-		s[txID] = txAddrMap
+		output, _, err := OutputFromBytes(outputBytes)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal output: %w", err)
+		}
+		s.Outputs = append(s.Outputs, output.SetID(outputID))
+	}
+
+	return nil
+}
+
+// outputs returns all Outputs that are contained in the Snapshot. The Outputs of the genesis Transactions are assigned
+// consecutive indexes in the same way as they are by UTXODAG.LoadSnapshot.
+func (s *Snapshot) outputs() (outputs Outputs) {
+	outputs = make(Outputs, 0, len(s.Outputs))
+
+	index := uint16(0)
+	for transactionID, addressBalance := range s.Transactions {
+		for address, balance := range addressBalance {
+			output := NewSigLockedColoredOutput(balance, address)
+			output.SetID(NewOutputID(transactionID, index))
+			outputs = append(outputs, output)
+
+			index++
+		}
+	}
+
+	return append(outputs, s.Outputs...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotHeader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// LegacySnapshotVersion is the version that is reported for snapshots that were written by Snapshot.WriteTo.
+	LegacySnapshotVersion uint16 = 1
+
+	// SnapshotVersionWithoutManaPledges is the version of snapshots whose header does not contain any ManaPledges.
+	SnapshotVersionWithoutManaPledges uint16 = 2
+
+	// SnapshotVersionWithoutOutputPledges is the version of snapshots whose Outputs are not accompanied by the
+	// OutputPledges of the Transactions that created them and whose LedgerRoot does not commit to the ManaPledges.
+	SnapshotVersionWithoutOutputPledges uint16 = 3
+
+	// SnapshotVersion is the version of the snapshot format that is written by the SnapshotWriter.
+	SnapshotVersion uint16 = 4
+
+	// snapshotMagic marks the beginning of a versioned snapshot. Interpreted as the transaction count of a legacy
+	// snapshot it is negative, which allows to tell both formats apart by their first 8 bytes.
+	snapshotMagic uint64 = 0xfffffff0534e4150
+
+	// snapshotOutputCountOffset is the position of the output count relative to the start of the snapshot.
+	snapshotOutputCountOffset = 8 + 2 + 4 + 8
+
+	// maxSnapshotElementSize is the maximum size of a serialized Output or OutputMetadata in a snapshot. Outputs are
+	// created by Transactions, which have to fit into a single Message of at most 64 KiB.
+	maxSnapshotElementSize = 64 * 1024
+)
+
+// SnapshotHeader contains the information that precedes the Outputs of a versioned snapshot.
+type SnapshotHeader struct {
+	// Version is the version of the snapshot format.
+	Version uint16
+
+	// NetworkID is the identifier of the network that the snapshot was created for.
+	NetworkID uint32
+
+	// Time is the time at which the snapshot was created.
+	Time time.Time
+
+	// OutputCount is the number of Outputs that are contained in the snapshot.
+	OutputCount uint64
+
+	// LedgerRoot commits to the ManaPledges, Outputs and OutputPledges of the snapshot (see LedgerRoot).
+	LedgerRoot LedgerRoot
+
+	// ManaPledges contains the initial mana of the nodes in the network that the snapshot was created for.
+	ManaPledges []*ManaPledge
+}
+
+// String returns a human readable version of the SnapshotHeader.
+func (s *SnapshotHeader) String() string {
+	return fmt.Sprintf("SnapshotHeader{Version: %d, NetworkID: %d, Time: %s, OutputCount: %d, LedgerRoot: %s, ManaPledges: %d}",
+		s.Version, s.NetworkID, s.Time, s.OutputCount, s.LedgerRoot, len(s.ManaPledges))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ManaPledge ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaPledgeLength contains the amount of bytes that a marshaled version of the ManaPledge contains.
+const ManaPledgeLength = identity.IDLength + 2*marshalutil.Uint64Size + marshalutil.TimeSize
+
+// ManaPledge is the initial access and consensus mana that a genesis snapshot pledges to a node. The pledged consensus
+// mana has to be backed by the balances of the Outputs whose OutputPledge names the node as the consensus pledge ID, so
+// that spending these Outputs revokes it again.
+type ManaPledge struct {
+	// NodeID is the identifier of the node that the mana is pledged to.
+	NodeID identity.ID
+
+	// AccessMana is the amount of access mana that is pledged to the node.
+	AccessMana uint64
+
+	// ConsensusMana is the amount of consensus mana that is pledged to the node.
+	ConsensusMana uint64
+
+	// Timestamp is the time at which the mana is pledged.
+	Timestamp time.Time
+}
+
+// ManaPledgeFromBytes unmarshals a ManaPledge from a sequence of bytes.
+func ManaPledgeFromBytes(bytes []byte) (manaPledge *ManaPledge, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if manaPledge, err = ManaPledgeFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ManaPledge from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ManaPledgeFromMarshalUtil unmarshals a ManaPledge using a MarshalUtil (for easier unmarshaling).
+func ManaPledgeFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (manaPledge *ManaPledge, err error) {
+	manaPledge = &ManaPledge{}
+	if manaPledge.NodeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse node ID: %w", err)
+	}
+	if manaPledge.AccessMana, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse access mana (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if manaPledge.ConsensusMana, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse consensus mana (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if manaPledge.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		return nil, xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	return manaPledge, nil
+}
+
+// Bytes returns a marshaled version of the ManaPledge.
+func (m *ManaPledge) Bytes() []byte {
+	return marshalutil.New(ManaPledgeLength).
+		Write(m.NodeID).
+		WriteUint64(m.AccessMana).
+		WriteUint64(m.ConsensusMana).
+		WriteTime(m.Timestamp).
+		Bytes()
+}
+
+// String returns a human readable version of the ManaPledge.
+func (m *ManaPledge) String() string {
+	return stringify.Struct("ManaPledge",
+		stringify.StructField("nodeID", m.NodeID),
+		stringify.StructField("accessMana", m.AccessMana),
+		stringify.StructField("consensusMana", m.ConsensusMana),
+		stringify.StructField("timestamp", m.Timestamp),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OutputPledge /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OutputPledgeLength contains the amount of bytes that a marshaled version of the OutputPledge contains.
+const OutputPledgeLength = 2*identity.IDLength + marshalutil.TimeSize
+
+// OutputPledge contains the mana pledge IDs and the timestamp of the Transaction that created an Output. The
+// Transactions are not part of a snapshot, so the OutputPledges are stored next to the Outputs to allow the mana of the
+// Outputs to be revoked from the right nodes once they are spent.
+type OutputPledge struct {
+	// AccessPledgeID is the identifier of the node that the access mana of the Output was pledged to.
+	AccessPledgeID identity.ID
+
+	// ConsensusPledgeID is the identifier of the node that the consensus mana of the Output was pledged to.
+	ConsensusPledgeID identity.ID
+
+	// Timestamp is the timestamp of the Transaction that created the Output.
+	Timestamp time.Time
+}
+
+// NewOutputPledge returns the OutputPledge of the Outputs that are created by the given Transaction.
+func NewOutputPledge(transaction *Transaction) *OutputPledge {
+	return &OutputPledge{
+		AccessPledgeID:    transaction.Essence().AccessPledgeID(),
+		ConsensusPledgeID: transaction.Essence().ConsensusPledgeID(),
+		Timestamp:         transaction.Essence().Timestamp(),
+	}
+}
+
+// OutputPledgeFromBytes unmarshals an OutputPledge from a sequence of bytes.
+func OutputPledgeFromBytes(bytes []byte) (outputPledge *OutputPledge, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if outputPledge, err = OutputPledgeFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse OutputPledge from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// OutputPledgeFromMarshalUtil unmarshals an OutputPledge using a MarshalUtil (for easier unmarshaling).
+func OutputPledgeFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (outputPledge *OutputPledge, err error) {
+	outputPledge = &OutputPledge{}
+	if outputPledge.AccessPledgeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse access pledge ID: %w", err)
+	}
+	if outputPledge.ConsensusPledgeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse consensus pledge ID: %w", err)
+	}
+	if outputPledge.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		return nil, xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
 	}
 
-	return bytesRead, nil
+	return outputPledge, nil
 }
+
+// Bytes returns a marshaled version of the OutputPledge.
+func (o *OutputPledge) Bytes() []byte {
+	return marshalutil.New(OutputPledgeLength).
+		Write(o.AccessPledgeID).
+		Write(o.ConsensusPledgeID).
+		WriteTime(o.Timestamp).
+		Bytes()
+}
+
+// String returns a human readable version of the OutputPledge.
+func (o *OutputPledge) String() string {
+	return stringify.Struct("OutputPledge",
+		stringify.StructField("accessPledgeID", o.AccessPledgeID),
+		stringify.StructField("consensusPledgeID", o.ConsensusPledgeID),
+		stringify.StructField("timestamp", o.Timestamp),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LedgerRoot ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LedgerRootLength contains the amount of bytes that a marshaled version of the LedgerRoot contains.
+const LedgerRootLength = blake2b.Size256
+
+// LedgerRoot is the blake2b-256 hash over the ManaPledges of the header followed by the OutputIDs, the serialized
+// Outputs and their OutputPledges of a snapshot in the order in which they appear in the snapshot.
+type LedgerRoot [LedgerRootLength]byte
+
+// Bytes returns a marshaled version of the LedgerRoot.
+func (l LedgerRoot) Bytes() []byte {
+	return l[:]
+}
+
+// Base58 returns a base58 encoded version of the LedgerRoot.
+func (l LedgerRoot) Base58() string {
+	return base58.Encode(l.Bytes())
+}
+
+// String returns a human readable version of the LedgerRoot.
+func (l LedgerRoot) String() string {
+	return "LedgerRoot(" + l.Base58() + ")"
+}
+
+// ledgerRootHasher is an internal utility that calculates the LedgerRoot of a stream of Outputs.
+type ledgerRootHasher struct {
+	hash.Hash
+}
+
+// newLedgerRootHasher returns a new ledgerRootHasher.
+func newLedgerRootHasher() *ledgerRootHasher {
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ledgerRootHasher{Hash: hasher}
+}
+
+// addManaPledge adds the given ManaPledge of the header to the LedgerRoot.
+func (l *ledgerRootHasher) addManaPledge(manaPledge *ManaPledge) {
+	_, _ = l.Write(manaPledge.Bytes())
+}
+
+// add adds the given Output and its OutputPledge (nil for snapshot versions without OutputPledges) to the LedgerRoot.
+func (l *ledgerRootHasher) add(output Output, outputPledge *OutputPledge) {
+	_, _ = l.Write(output.ID().Bytes())
+	_, _ = l.Write(output.Bytes())
+	if outputPledge != nil {
+		_, _ = l.Write(outputPledge.Bytes())
+	}
+}
+
+// root returns the LedgerRoot of the Outputs that were added so far.
+func (l *ledgerRootHasher) root() (ledgerRoot LedgerRoot) {
+	copy(ledgerRoot[:], l.Sum(nil))
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region manaPledgeBalances ///////////////////////////////////////////////////////////////////////////////////////////
+
+// manaPledgeBalances is an internal utility that keeps track of the consensus mana that the ManaPledges of a snapshot
+// pledge to each node and that is not (yet) backed by the balances of the Outputs that are pledged to the same node.
+type manaPledgeBalances map[identity.ID]int64
+
+// newManaPledgeBalances returns the manaPledgeBalances of the given ManaPledges. It returns nil if there are no
+// ManaPledges, as the Outputs of such snapshots do not need to back any mana.
+func newManaPledgeBalances(manaPledges []*ManaPledge) (balances manaPledgeBalances) {
+	if len(manaPledges) == 0 {
+		return nil
+	}
+
+	balances = make(manaPledgeBalances)
+	for _, manaPledge := range manaPledges {
+		balances[manaPledge.NodeID] += int64(manaPledge.ConsensusMana)
+	}
+
+	return balances
+}
+
+// addOutput subtracts the balance of the given Output from the consensus mana of the node that it is pledged to.
+func (m manaPledgeBalances) addOutput(output Output, outputPledge *OutputPledge) {
+	if m == nil || outputPledge == nil || outputPledge.ConsensusPledgeID == (identity.ID{}) {
+		return
+	}
+
+	output.Balances().ForEach(func(color Color, balance uint64) bool {
+		m[outputPledge.ConsensusPledgeID] -= int64(balance)
+		return true
+	})
+}
+
+// backed returns true if the pledged consensus mana of every node equals the balance of the Outputs pledged to it.
+func (m manaPledgeBalances) backed() bool {
+	for _, balance := range m {
+		if balance != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotWriter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotWriter streams Outputs together with their OutputMetadata into a versioned snapshot, so that the ledger state
+// does not need to be held in memory. The output count and the LedgerRoot of the header are only known once all
+// Outputs were written, which is why they are filled in when the SnapshotWriter is closed. The snapshot has the
+// following format:
+//	magic(uint64)
+//	version(uint16)
+//	network_id(uint32)
+//	snapshot_time(int64 unix nanoseconds)
+//	output_count(uint64)
+//	ledger_root(32byte)
+//	mana_pledge_count(uint32)
+//	-> mana_pledge_count * mana_pledge(80byte)
+//	-> output_count * metadata_length(uint32)+metadata+output_length(uint32)+output+output_pledge(72byte)
+type SnapshotWriter struct {
+	writer      io.WriteSeeker
+	startOffset int64
+	header      SnapshotHeader
+	hasher      *ledgerRootHasher
+	balances    manaPledgeBalances
+	closed      bool
+}
+
+// NewSnapshotWriter writes the header of a new snapshot to the given writer and returns a SnapshotWriter that can be
+// used to add the Outputs. The optional ManaPledges define the initial mana of the nodes in the network, and their
+// consensus mana has to be backed by the Outputs that are pledged to the same nodes.
+func NewSnapshotWriter(writer io.WriteSeeker, networkID uint32, snapshotTime time.Time, manaPledges ...*ManaPledge) (snapshotWriter *SnapshotWriter, err error) {
+	snapshotWriter = &SnapshotWriter{
+		writer: writer,
+		header: SnapshotHeader{
+			Version:     SnapshotVersion,
+			NetworkID:   networkID,
+			Time:        snapshotTime,
+			ManaPledges: manaPledges,
+		},
+		hasher:   newLedgerRootHasher(),
+		balances: newManaPledgeBalances(manaPledges),
+	}
+
+	if snapshotWriter.startOffset, err = writer.Seek(0, io.SeekCurrent); err != nil {
+		return nil, fmt.Errorf("unable to determine start of snapshot: %w", err)
+	}
+	for _, field := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"magic", snapshotMagic},
+		{"version", snapshotWriter.header.Version},
+		{"network ID", snapshotWriter.header.NetworkID},
+		{"snapshot time", snapshotTime.UnixNano()},
+		{"output count", snapshotWriter.header.OutputCount},
+		{"ledger root", snapshotWriter.header.LedgerRoot},
+		{"mana pledge count", uint32(len(manaPledges))},
+	} {
+		if err = binary.Write(writer, binary.LittleEndian, field.value); err != nil {
+			return nil, fmt.Errorf("unable to write %s: %w", field.name, err)
+		}
+	}
+	for _, manaPledge := range manaPledges {
+		if err = binary.Write(writer, binary.LittleEndian, manaPledge.Bytes()); err != nil {
+			return nil, fmt.Errorf("unable to write mana pledge: %w", err)
+		}
+		snapshotWriter.hasher.addManaPledge(manaPledge)
+	}
+
+	return snapshotWriter, nil
+}
+
+// WriteOutput adds the given Output together with its OutputMetadata and the OutputPledge of the Transaction that
+// created it to the snapshot. Outputs without a known OutputPledge (e.g. the genesis) are written with an empty one.
+func (s *SnapshotWriter) WriteOutput(output Output, outputMetadata *OutputMetadata, outputPledge *OutputPledge) error {
+	if s.closed {
+		return ErrSnapshotWriterClosed
+	}
+	if output.ID() != outputMetadata.ID() {
+		return fmt.Errorf("OutputMetadata of %s does not belong to %s", outputMetadata.ID(), output.ID())
+	}
+	if outputPledge == nil {
+		outputPledge = &OutputPledge{}
+	}
+
+	for _, element := range []struct {
+		name  string
+		bytes []byte
+	}{
+		{"output metadata", outputMetadata.Bytes()},
+		{"output", output.Bytes()},
+	} {
+		if err := binary.Write(s.writer, binary.LittleEndian, uint32(len(element.bytes))); err != nil {
+			return fmt.Errorf("unable to write %s length: %w", element.name, err)
+		}
+		if err := binary.Write(s.writer, binary.LittleEndian, element.bytes); err != nil {
+			return fmt.Errorf("unable to write %s: %w", element.name, err)
+		}
+	}
+	if err := binary.Write(s.writer, binary.LittleEndian, outputPledge.Bytes()); err != nil {
+		return fmt.Errorf("unable to write output pledge: %w", err)
+	}
+
+	s.hasher.add(output, outputPledge)
+	s.balances.addOutput(output, outputPledge)
+	s.header.OutputCount++
+
+	return nil
+}
+
+// Close fills in the output count and the LedgerRoot of the header and returns the final SnapshotHeader. The
+// underlying writer is positioned at the end of the snapshot afterwards and is not closed. It returns
+// ErrSnapshotManaPledgesMismatch if the ManaPledges are not backed by the Outputs.
+func (s *SnapshotWriter) Close() (header SnapshotHeader, err error) {
+	if s.closed {
+		return header, ErrSnapshotWriterClosed
+	}
+	s.closed = true
+	if !s.balances.backed() {
+		return header, ErrSnapshotManaPledgesMismatch
+	}
+	s.header.LedgerRoot = s.hasher.root()
+
+	endOffset, err := s.writer.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return header, fmt.Errorf("unable to determine end of snapshot: %w", err)
+	}
+	if _, err = s.writer.Seek(s.startOffset+snapshotOutputCountOffset, io.SeekStart); err != nil {
+		return header, fmt.Errorf("unable to seek to output count: %w", err)
+	}
+	if err = binary.Write(s.writer, binary.LittleEndian, s.header.OutputCount); err != nil {
+		return header, fmt.Errorf("unable to write output count: %w", err)
+	}
+	if err = binary.Write(s.writer, binary.LittleEndian, s.header.LedgerRoot); err != nil {
+		return header, fmt.Errorf("unable to write ledger root: %w", err)
+	}
+	if _, err = s.writer.Seek(endOffset, io.SeekStart); err != nil {
+		return header, fmt.Errorf("unable to seek to end of snapshot: %w", err)
+	}
+
+	return s.header, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotReader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotReader reads the Outputs of a snapshot one by one. Versioned snapshots are streamed from the underlying reader
+// and their LedgerRoot is verified after the last Output was read. Legacy snapshots are read completely and their
+// Outputs are returned with the OutputMetadata that UTXODAG.LoadSnapshot would assign to them.
+type SnapshotReader struct {
+	reader        io.Reader
+	header        SnapshotHeader
+	hasher        *ledgerRootHasher
+	balances      manaPledgeBalances
+	outputsRead   uint64
+	legacyOutputs Outputs
+}
+
+// NewSnapshotReader reads the header of the snapshot from the given reader and returns a SnapshotReader for its
+// Outputs.
+func NewSnapshotReader(reader io.Reader) (*SnapshotReader, error) {
+	var magic uint64
+	if err := binary.Read(reader, binary.LittleEndian, &magic); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot magic: %w", err)
+	}
+
+	if magic != snapshotMagic {
+		legacySnapshot := NewSnapshot()
+		if err := legacySnapshot.readLegacyFrom(reader, int64(magic)); err != nil {
+			return nil, err
+		}
+		legacyOutputs := legacySnapshot.outputs()
+
+		return &SnapshotReader{
+			header: SnapshotHeader{
+				Version:     LegacySnapshotVersion,
+				OutputCount: uint64(len(legacyOutputs)),
+			},
+			legacyOutputs: legacyOutputs,
+		}, nil
+	}
+
+	return newSnapshotReader(reader)
+}
+
+// newSnapshotReader is an internal utility function that creates a SnapshotReader for a versioned snapshot whose magic
+// was already consumed from the reader.
+func newSnapshotReader(reader io.Reader) (snapshotReader *SnapshotReader, err error) {
+	snapshotReader = &SnapshotReader{
+		reader: reader,
+		hasher: newLedgerRootHasher(),
+	}
+
+	if err = binary.Read(reader, binary.LittleEndian, &snapshotReader.header.Version); err != nil {
+		return nil, fmt.Errorf("unable to read version: %w", err)
+	}
+	if snapshotReader.header.Version < SnapshotVersionWithoutManaPledges || snapshotReader.header.Version > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version %d: %w", snapshotReader.header.Version, ErrUnsupportedSnapshotVersion)
+	}
+	if err = binary.Read(reader, binary.LittleEndian, &snapshotReader.header.NetworkID); err != nil {
+		return nil, fmt.Errorf("unable to read network ID: %w", err)
+	}
+	var snapshotTime int64
+	if err = binary.Read(reader, binary.LittleEndian, &snapshotTime); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot time: %w", err)
+	}
+	snapshotReader.header.Time = time.Unix(0, snapshotTime)
+	if err = binary.Read(reader, binary.LittleEndian, &snapshotReader.header.OutputCount); err != nil {
+		return nil, fmt.Errorf("unable to read output count: %w", err)
+	}
+	if err = binary.Read(reader, binary.LittleEndian, &snapshotReader.header.LedgerRoot); err != nil {
+		return nil, fmt.Errorf("unable to read ledger root: %w", err)
+	}
+	if snapshotReader.header.Version == SnapshotVersionWithoutManaPledges {
+		return snapshotReader, nil
+	}
+
+	var manaPledgeCount uint32
+	if err = binary.Read(reader, binary.LittleEndian, &manaPledgeCount); err != nil {
+		return nil, fmt.Errorf("unable to read mana pledge count: %w", err)
+	}
+	for i := uint32(0); i < manaPledgeCount; i++ {
+		manaPledgeBytes := make([]byte, ManaPledgeLength)
+		if err = binary.Read(reader, binary.LittleEndian, manaPledgeBytes); err != nil {
+			return nil, fmt.Errorf("unable to read mana pledge: %w", err)
+		}
+		manaPledge, _, parseErr := ManaPledgeFromBytes(manaPledgeBytes)
+		if parseErr != nil {
+			return nil, fmt.Errorf("unable to unmarshal mana pledge: %w", parseErr)
+		}
+		snapshotReader.header.ManaPledges = append(snapshotReader.header.ManaPledges, manaPledge)
+	}
+	if snapshotReader.header.Version == SnapshotVersionWithoutOutputPledges {
+		return snapshotReader, nil
+	}
+
+	for _, manaPledge := range snapshotReader.header.ManaPledges {
+		snapshotReader.hasher.addManaPledge(manaPledge)
+	}
+	snapshotReader.balances = newManaPledgeBalances(snapshotReader.header.ManaPledges)
+
+	return snapshotReader, nil
+}
+
+// Header returns the SnapshotHeader of the snapshot.
+func (s *SnapshotReader) Header() SnapshotHeader {
+	return s.header
+}
+
+// Next returns the next Output of the snapshot together with its OutputMetadata and the OutputPledge of the Transaction
+// that created it (nil for snapshot versions without OutputPledges). It returns io.EOF after the last Output was read
+// (or ErrSnapshotLedgerRootMismatch if the Outputs do not match the LedgerRoot of the header and
+// ErrSnapshotManaPledgesMismatch if they do not back the ManaPledges).
+func (s *SnapshotReader) Next() (output Output, outputMetadata *OutputMetadata, outputPledge *OutputPledge, err error) {
+	if s.outputsRead == s.header.OutputCount {
+		if s.hasher != nil && s.hasher.root() != s.header.LedgerRoot {
+			return nil, nil, nil, ErrSnapshotLedgerRootMismatch
+		}
+		if !s.balances.backed() {
+			return nil, nil, nil, ErrSnapshotManaPledgesMismatch
+		}
+
+		return nil, nil, nil, io.EOF
+	}
+	s.outputsRead++
+
+	if s.hasher == nil {
+		output = s.legacyOutputs[0]
+		s.legacyOutputs = s.legacyOutputs[1:]
+
+		return output, newSnapshotOutputMetadata(output.ID()), nil, nil
+	}
+
+	var outputMetadataBytes, outputBytes []byte
+	for _, element := range []struct {
+		name  string
+		bytes *[]byte
+	}{
+		{"output metadata", &outputMetadataBytes},
+		{"output", &outputBytes},
+	} {
+		var length uint32
+		if err = binary.Read(s.reader, binary.LittleEndian, &length); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to read %s length: %w", element.name, err)
+		}
+		if length > maxSnapshotElementSize {
+			return nil, nil, nil, fmt.Errorf("%s length %d exceeds the maximum of %d bytes: %w", element.name, length, maxSnapshotElementSize, cerrors.ErrParseBytesFailed)
+		}
+		*element.bytes = make([]byte, length)
+		if err = binary.Read(s.reader, binary.LittleEndian, *element.bytes); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to read %s: %w", element.name, err)
+		}
+	}
+
+	if outputMetadata, _, err = OutputMetadataFromBytes(outputMetadataBytes); err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to unmarshal output metadata: %w", err)
+	}
+	if output, _, err = OutputFromBytes(outputBytes); err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to unmarshal output: %w", err)
+	}
+	output = output.SetID(outputMetadata.ID())
+
+	if s.header.Version > SnapshotVersionWithoutOutputPledges {
+		outputPledgeBytes := make([]byte, OutputPledgeLength)
+		if err = binary.Read(s.reader, binary.LittleEndian, outputPledgeBytes); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to read output pledge: %w", err)
+		}
+		if outputPledge, _, err = OutputPledgeFromBytes(outputPledgeBytes); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to unmarshal output pledge: %w", err)
+		}
+	}
+	s.hasher.add(output, outputPledge)
+	s.balances.addOutput(output, outputPledge)
+
+	return output, outputMetadata, outputPledge, nil
+}
+
+// VerifySnapshot reads all Outputs of the snapshot of the given reader without storing them and checks that they match
+// the LedgerRoot of its header and back its ManaPledges. Legacy snapshots do not have a LedgerRoot, so only their format is checked.
+func VerifySnapshot(reader io.Reader) (header SnapshotHeader, err error) {
+	snapshotReader, err := NewSnapshotReader(reader)
+	if err != nil {
+		return header, err
+	}
+	header = snapshotReader.Header()
+
+	for {
+		if _, _, _, err = snapshotReader.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return header, nil
+			}
+			return header, err
+		}
+	}
+}
+
+// newSnapshotOutputMetadata is an internal utility function that creates the OutputMetadata of an Output that is
+// loaded from a snapshot without OutputMetadata.
+func newSnapshotOutputMetadata(outputID OutputID) (outputMetadata *OutputMetadata) {
+	outputMetadata = NewOutputMetadata(outputID)
+	outputMetadata.SetBranchID(MasterBranchID)
+	outputMetadata.SetSolid(true)
+	outputMetadata.SetFinalized(true)
+
+	return outputMetadata
+}
+
+// countingReader is an internal utility that counts the bytes that are read from the wrapped reader.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+// Read reads from the wrapped reader and counts the bytes that were read.
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.reader.Read(p)
+	c.count += int64(n)
+
+	return n, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,291 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestSnapshot_WriteToReadFrom(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	output := NewSigLockedSingleOutput(100, address)
+	output.SetID(NewOutputID(TransactionID{1}, 2))
+
+	snapshot := &Snapshot{
+		Transactions: map[TransactionID]map[Address]*ColoredBalances{
+			GenesisTransactionID: {
+				address: NewColoredBalances(map[Color]uint64{ColorIOTA: 1000}),
+			},
+		},
+		Outputs: Outputs{output},
+	}
+
+	var buffer bytes.Buffer
+	bytesWritten, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buffer.Len()), bytesWritten)
+
+	loadedSnapshot := NewSnapshot()
+	bytesRead, err := loadedSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, bytesWritten, bytesRead)
+
+	require.Len(t, loadedSnapshot.Transactions[GenesisTransactionID], 1)
+	require.Len(t, loadedSnapshot.Outputs, 1)
+	assert.Equal(t, output.ID(), loadedSnapshot.Outputs[0].ID())
+	assert.Equal(t, output.Bytes(), loadedSnapshot.Outputs[0].Bytes())
+}
+
+func TestSnapshot_ReadFromWithoutOutputs(t *testing.T) {
+	// snapshots that were created before the outputs section was added only contain the transaction count
+	var buffer bytes.Buffer
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, int64(0)))
+
+	snapshot := NewSnapshot()
+	_, err := snapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Transactions)
+	assert.Empty(t, snapshot.Outputs)
+}
+
+func TestSnapshotWriterReader(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "snapshot")
+	require.NoError(t, err)
+	defer file.Close()
+
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make(Outputs, 0)
+	for i := uint16(0); i < 3; i++ {
+		output := NewSigLockedSingleOutput(uint64(100+i), address)
+		output.SetID(NewOutputID(TransactionID{1}, i))
+		outputs = append(outputs, output)
+	}
+
+	outputPledge := &OutputPledge{
+		AccessPledgeID:    identity.GenerateIdentity().ID(),
+		ConsensusPledgeID: identity.GenerateIdentity().ID(),
+		Timestamp:         time.Unix(time.Now().Unix(), 0),
+	}
+
+	snapshotTime := time.Unix(0, time.Now().UnixNano())
+	writer, err := NewSnapshotWriter(file, 22, snapshotTime)
+	require.NoError(t, err)
+	for _, output := range outputs {
+		require.NoError(t, writer.WriteOutput(output, newSnapshotOutputMetadata(output.ID()), outputPledge))
+	}
+	assert.Error(t, writer.WriteOutput(outputs[0], NewOutputMetadata(outputs[1].ID()), nil))
+	header, err := writer.Close()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), header.OutputCount)
+	assert.Equal(t, ErrSnapshotWriterClosed, writer.WriteOutput(outputs[0], newSnapshotOutputMetadata(outputs[0].ID()), nil))
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	reader, err := NewSnapshotReader(file)
+	require.NoError(t, err)
+	assert.Equal(t, header, reader.Header())
+	assert.Equal(t, SnapshotVersion, reader.Header().Version)
+	assert.Equal(t, uint32(22), reader.Header().NetworkID)
+	assert.True(t, snapshotTime.Equal(reader.Header().Time))
+
+	for _, output := range outputs {
+		readOutput, readOutputMetadata, readOutputPledge, err := reader.Next()
+		require.NoError(t, err)
+		assert.Equal(t, output.ID(), readOutput.ID())
+		assert.Equal(t, output.Bytes(), readOutput.Bytes())
+		assert.Equal(t, output.ID(), readOutputMetadata.ID())
+		assert.True(t, readOutputMetadata.Finalized())
+		assert.Equal(t, outputPledge.Bytes(), readOutputPledge.Bytes())
+	}
+	_, _, _, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+
+	// the Snapshot can read the versioned format as well
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	snapshot := NewSnapshot()
+	bytesRead, err := snapshot.ReadFrom(file)
+	require.NoError(t, err)
+	fileInfo, err := file.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, fileInfo.Size(), bytesRead)
+	assert.Len(t, snapshot.Outputs, 3)
+}
+
+func TestSnapshotReader_LedgerRootMismatch(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "snapshot")
+	require.NoError(t, err)
+	defer file.Close()
+
+	output := NewSigLockedSingleOutput(100, NewED25519Address(ed25519.GenerateKeyPair().PublicKey))
+	output.SetID(NewOutputID(TransactionID{1}, 0))
+
+	writer, err := NewSnapshotWriter(file, 22, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteOutput(output, newSnapshotOutputMetadata(output.ID()), nil))
+	_, err = writer.Close()
+	require.NoError(t, err)
+
+	// tamper with the output pledge and with the balance of the output, which precedes it at the end of the snapshot
+	fileInfo, err := file.Stat()
+	require.NoError(t, err)
+	for _, offset := range []int64{fileInfo.Size() - 1, fileInfo.Size() - OutputPledgeLength - AddressLength - 8} {
+		_, err = file.WriteAt([]byte{1}, offset)
+		require.NoError(t, err)
+
+		_, err = file.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		reader, err := NewSnapshotReader(file)
+		require.NoError(t, err)
+		_, _, _, err = reader.Next()
+		require.NoError(t, err)
+		_, _, _, err = reader.Next()
+		assert.Equal(t, ErrSnapshotLedgerRootMismatch, err)
+
+		_, err = file.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		_, err = VerifySnapshot(file)
+		assert.Equal(t, ErrSnapshotLedgerRootMismatch, err)
+	}
+}
+
+func TestSnapshotReader_ElementTooLarge(t *testing.T) {
+	var buffer bytes.Buffer
+	for _, element := range []interface{}{snapshotMagic, SnapshotVersion, uint32(22), time.Now().UnixNano(), uint64(1), LedgerRoot{}, uint32(0), uint32(maxSnapshotElementSize + 1)} {
+		require.NoError(t, binary.Write(&buffer, binary.LittleEndian, element))
+	}
+
+	reader, err := NewSnapshotReader(&buffer)
+	require.NoError(t, err)
+	_, _, _, err = reader.Next()
+	assert.Error(t, err)
+}
+
+func TestSnapshotReader_Legacy(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	output := NewSigLockedSingleOutput(100, address)
+	output.SetID(NewOutputID(TransactionID{1}, 2))
+
+	snapshot := &Snapshot{
+		Transactions: map[TransactionID]map[Address]*ColoredBalances{
+			GenesisTransactionID: {
+				address: NewColoredBalances(map[Color]uint64{ColorIOTA: 1000}),
+			},
+		},
+		Outputs: Outputs{output},
+	}
+
+	var buffer bytes.Buffer
+	_, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+
+	reader, err := NewSnapshotReader(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, LegacySnapshotVersion, reader.Header().Version)
+	assert.Equal(t, uint64(2), reader.Header().OutputCount)
+
+	genesisOutput, genesisOutputMetadata, genesisOutputPledge, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, NewOutputID(GenesisTransactionID, 0), genesisOutput.ID())
+	assert.Equal(t, MasterBranchID, genesisOutputMetadata.BranchID())
+	assert.Nil(t, genesisOutputPledge)
+
+	readOutput, _, _, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, output.Bytes(), readOutput.Bytes())
+
+	_, _, _, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSnapshotReader_LegacyOutputLength(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, int64(0)))
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, int64(1)))
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, NewOutputID(TransactionID{1}, 0).Bytes()))
+	require.NoError(t, binary.Write(&buffer, binary.LittleEndian, int64(1<<40)))
+
+	// a corrupted length prefix does not allocate the announced amount of memory
+	_, err := NewSnapshotReader(bytes.NewReader(buffer.Bytes()))
+	assert.True(t, xerrors.Is(err, cerrors.ErrParseBytesFailed))
+	_, err = (&Snapshot{}).ReadFrom(bytes.NewReader(buffer.Bytes()))
+	assert.True(t, xerrors.Is(err, cerrors.ErrParseBytesFailed))
+}
+
+func TestSnapshotWriter_ManaPledges(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "snapshot")
+	require.NoError(t, err)
+	defer file.Close()
+
+	pledgeTime := time.Unix(time.Now().Unix(), 0)
+	manaPledges := []*ManaPledge{
+		{NodeID: identity.GenerateIdentity().ID(), AccessMana: 100, ConsensusMana: 50, Timestamp: pledgeTime},
+		{NodeID: identity.GenerateIdentity().ID(), AccessMana: 0, ConsensusMana: 50, Timestamp: pledgeTime},
+	}
+
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make(Outputs, 0)
+	outputPledges := make([]*OutputPledge, 0)
+	for i, manaPledge := range manaPledges {
+		output := NewSigLockedSingleOutput(manaPledge.ConsensusMana, address)
+		output.SetID(NewOutputID(GenesisTransactionID, uint16(i)))
+		outputs = append(outputs, output)
+		outputPledges = append(outputPledges, &OutputPledge{
+			AccessPledgeID:    manaPledge.NodeID,
+			ConsensusPledgeID: manaPledge.NodeID,
+			Timestamp:         pledgeTime,
+		})
+	}
+
+	// the consensus mana of the second pledge is not backed by any Output
+	writer, err := NewSnapshotWriter(file, 22, time.Now(), manaPledges...)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteOutput(outputs[0], newSnapshotOutputMetadata(outputs[0].ID()), outputPledges[0]))
+	_, err = writer.Close()
+	assert.Equal(t, ErrSnapshotManaPledgesMismatch, err)
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	writer, err = NewSnapshotWriter(file, 22, time.Now(), manaPledges...)
+	require.NoError(t, err)
+	for i, output := range outputs {
+		require.NoError(t, writer.WriteOutput(output, newSnapshotOutputMetadata(output.ID()), outputPledges[i]))
+	}
+	_, err = writer.Close()
+	require.NoError(t, err)
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	reader, err := NewSnapshotReader(file)
+	require.NoError(t, err)
+	require.Len(t, reader.Header().ManaPledges, 2)
+	for i, manaPledge := range reader.Header().ManaPledges {
+		assert.Equal(t, manaPledges[i].Bytes(), manaPledge.Bytes())
+	}
+	for i, output := range outputs {
+		readOutput, _, readOutputPledge, err := reader.Next()
+		require.NoError(t, err)
+		assert.Equal(t, output.Bytes(), readOutput.Bytes())
+		assert.Equal(t, outputPledges[i].Bytes(), readOutputPledge.Bytes())
+	}
+	_, _, _, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+
+	// the ManaPledges are committed to by the LedgerRoot, so tampering with the access mana of the first one is detected
+	_, err = file.WriteAt([]byte{1}, snapshotOutputCountOffset+8+LedgerRootLength+4+identity.IDLength)
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	_, err = VerifySnapshot(file)
+	assert.Equal(t, ErrSnapshotLedgerRootMismatch, err)
+}
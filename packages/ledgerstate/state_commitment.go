@@ -0,0 +1,1131 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// region StateRoot ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// StateRootLength contains the amount of bytes that a marshaled version of the StateRoot contains.
+const StateRootLength = blake2b.Size256
+
+// StateRoot is the root of the sparse Merkle tree that commits to the confirmed unspent Outputs of the ledger state.
+type StateRoot [StateRootLength]byte
+
+// StateRootFromBytes unmarshals a StateRoot from a sequence of bytes.
+func StateRootFromBytes(bytes []byte) (stateRoot StateRoot, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if stateRoot, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse StateRoot from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// StateRootFromMarshalUtil unmarshals a StateRoot using a MarshalUtil (for easier unmarshaling).
+func StateRootFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (stateRoot StateRoot, err error) {
+	stateRootBytes, err := marshalUtil.ReadBytes(StateRootLength)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse StateRoot (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(stateRoot[:], stateRootBytes)
+
+	return
+}
+
+// Bytes returns a marshaled version of the StateRoot.
+func (s StateRoot) Bytes() []byte {
+	return s[:]
+}
+
+// Base58 returns a base58 encoded version of the StateRoot.
+func (s StateRoot) Base58() string {
+	return base58.Encode(s.Bytes())
+}
+
+// String returns a human readable version of the StateRoot.
+func (s StateRoot) String() string {
+	return "StateRoot(" + s.Base58() + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region StateCommitment //////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// stateTreeDepth contains the maximum depth of the sparse Merkle tree (one level per bit of the hashed OutputID).
+	stateTreeDepth = 8 * StateRootLength
+
+	// stateTreeLeafPrefix is prepended to the data of the leaves before they are hashed.
+	stateTreeLeafPrefix byte = 0
+
+	// stateTreeNodePrefix is prepended to the children of the inner nodes before they are hashed.
+	stateTreeNodePrefix byte = 1
+)
+
+const (
+	// stateCommitmentNodePrefix defines the storage prefix for the non-empty nodes of the sparse Merkle tree.
+	stateCommitmentNodePrefix byte = iota
+
+	// stateCommitmentTransactionPrefix defines the storage prefix for the Transactions that were committed.
+	stateCommitmentTransactionPrefix
+
+	// stateCommitmentEpochPrefix defines the storage prefix for the EpochCommitments.
+	stateCommitmentEpochPrefix
+
+	// stateCommitmentOutputCountKey defines the storage key of the number of committed Outputs.
+	stateCommitmentOutputCountKey
+
+	// stateCommitmentLatestEpochKey defines the storage key of the index of the latest EpochCommitment.
+	stateCommitmentLatestEpochKey
+
+	// stateCommitmentPendingTransactionPrefix defines the storage prefix for the confirmed Transactions that are applied
+	// when the epoch that contains their timestamp is committed.
+	stateCommitmentPendingTransactionPrefix
+
+	// stateCommitmentEpochDiffPrefix defines the storage prefix for the changes of the leaves that the Transactions of an
+	// epoch applied to the sparse Merkle tree.
+	stateCommitmentEpochDiffPrefix
+)
+
+// StateCommitment maintains an order independent commitment to the confirmed unspent Outputs of the ledger state. It is
+// implemented as a compacted sparse Merkle tree that maps the hash of the OutputID to the hash of the Output, so that
+// two nodes can compare their ledger state by comparing a single StateRoot and so that light clients can verify the
+// existence of an Output with a StateInclusionProof. The leaves are stored at the shortest prefix of their path that is
+// unique in the tree, so an update only touches about log2(n) nodes.
+//
+// Confirmed Transactions are staged and applied when the epoch that contains their timestamp is committed. A
+// Transaction that is confirmed after its epoch was committed is still assigned to the epoch of its timestamp: the
+// changes of every epoch are recorded, so that the EpochCommitments of the affected epochs can be revised by reverting
+// the changes of the later epochs. The StateRoot of an epoch therefore only depends on the confirmed Transactions whose
+// timestamps lie before its end and not on the time at which a node confirmed them.
+type StateCommitment struct {
+	store kvstore.KVStore
+	mutex sync.RWMutex
+}
+
+// newStateCommitment returns a new StateCommitment that persists its nodes in the given store.
+func newStateCommitment(store kvstore.KVStore) *StateCommitment {
+	return &StateCommitment{
+		store: store,
+	}
+}
+
+// Root returns the current StateRoot of the confirmed unspent Outputs.
+func (s *StateCommitment) Root() (stateRoot StateRoot, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	root, err := s.node(0, StateRoot{})
+	if err != nil {
+		return StateRoot{}, err
+	}
+
+	return root.subtreeHash(), nil
+}
+
+// OutputCount returns the number of Outputs that are contained in the StateCommitment.
+func (s *StateCommitment) OutputCount() (outputCount uint64, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.outputCount()
+}
+
+// Contains returns true if the Output with the given OutputID is contained in the StateCommitment.
+func (s *StateCommitment) Contains(outputID OutputID) (contains bool, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	batch, err := s.newStateTreeBatch()
+	if err != nil {
+		return false, err
+	}
+	path := stateTreePath(outputID)
+	_, leaf, err := batch.leaf(path)
+	if err != nil {
+		return false, err
+	}
+
+	return leaf != nil && leaf.path == path, nil
+}
+
+// InclusionProof returns a StateInclusionProof for the Output with the given OutputID together with the StateRoot that
+// it can be verified against. It returns an error wrapping kvstore.ErrKeyNotFound if the Output is not contained.
+func (s *StateCommitment) InclusionProof(outputID OutputID) (proof *StateInclusionProof, stateRoot StateRoot, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	batch, err := s.newStateTreeBatch()
+	if err != nil {
+		return nil, StateRoot{}, err
+	}
+	path := stateTreePath(outputID)
+	depth, leaf, err := batch.leaf(path)
+	if err != nil {
+		return nil, StateRoot{}, err
+	}
+	if leaf == nil || leaf.path != path {
+		return nil, StateRoot{}, xerrors.Errorf("Output with %s is not contained in the StateCommitment: %w", outputID, kvstore.ErrKeyNotFound)
+	}
+
+	proof = &StateInclusionProof{siblings: make([]StateRoot, depth)}
+	for ; depth > 0; depth-- {
+		sibling, siblingErr := batch.node(depth, stateTreeSibling(path, depth))
+		if siblingErr != nil {
+			return nil, StateRoot{}, siblingErr
+		}
+		proof.siblings[depth-1] = sibling.subtreeHash()
+	}
+
+	root, err := batch.node(0, path)
+	if err != nil {
+		return nil, StateRoot{}, err
+	}
+
+	return proof, root.subtreeHash(), nil
+}
+
+// CommitEpoch persists the current StateRoot as the EpochCommitment of the given EpochIndex. Epochs can only be
+// committed once - committing an epoch again returns the existing EpochCommitment - and they have to be committed in
+// order, as the tree only reflects the state at the end of the latest epoch.
+func (s *StateCommitment) CommitEpoch(epochIndex EpochIndex) (epochCommitment *EpochCommitment, err error) {
+	return s.commitEpoch(epochIndex, epochIndex)
+}
+
+// EpochCommitment returns the EpochCommitment of the given EpochIndex (it returns an error wrapping
+// kvstore.ErrKeyNotFound if the epoch was not committed).
+func (s *StateCommitment) EpochCommitment(epochIndex EpochIndex) (epochCommitment *EpochCommitment, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.epochCommitment(epochIndex)
+}
+
+// LatestEpochCommitment returns the EpochCommitment with the highest EpochIndex (it returns an error wrapping
+// kvstore.ErrKeyNotFound if no epoch was committed, yet).
+func (s *StateCommitment) LatestEpochCommitment() (epochCommitment *EpochCommitment, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	latestEpochIndex, err := s.latestEpochIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.epochCommitment(latestEpochIndex)
+}
+
+// commitEpoch persists the current StateRoot as the EpochCommitment of the given EpochIndex and revises the already
+// committed EpochCommitments starting at the given revisedEpochIndex, as Transactions of these epochs were applied late.
+func (s *StateCommitment) commitEpoch(epochIndex EpochIndex, revisedEpochIndex EpochIndex) (epochCommitment *EpochCommitment, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if epochCommitment, err = s.epochCommitment(epochIndex); err == nil || !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return
+	}
+	if latestEpochIndex, latestErr := s.latestEpochIndex(); latestErr == nil && epochIndex < latestEpochIndex {
+		return nil, xerrors.Errorf("epoch %d is older than the latest committed epoch %d: %w", epochIndex, latestEpochIndex, cerrors.ErrFatal)
+	}
+
+	root, err := s.node(0, StateRoot{})
+	if err != nil {
+		return nil, err
+	}
+	outputCount, err := s.outputCount()
+	if err != nil {
+		return nil, err
+	}
+	epochCommitment = NewEpochCommitment(epochIndex, root.subtreeHash(), outputCount, time.Now())
+
+	batch := s.store.Batched()
+	if err = batch.Set(append([]byte{stateCommitmentEpochPrefix}, epochIndex.Bytes()...), epochCommitment.Bytes()); err != nil {
+		batch.Cancel()
+		return nil, xerrors.Errorf("failed to store EpochCommitment: %w", err)
+	}
+	if err = batch.Set([]byte{stateCommitmentLatestEpochKey}, epochIndex.Bytes()); err != nil {
+		batch.Cancel()
+		return nil, xerrors.Errorf("failed to store latest EpochIndex: %w", err)
+	}
+	if err = s.reviseEpochCommitments(batch, revisedEpochIndex, epochIndex); err != nil {
+		batch.Cancel()
+		return nil, err
+	}
+	if err = batch.Commit(); err != nil {
+		return nil, xerrors.Errorf("failed to commit EpochCommitment: %w", err)
+	}
+
+	return epochCommitment, nil
+}
+
+// reviseEpochCommitments recomputes the EpochCommitments of the epochs from the given revisedEpochIndex up to (but
+// excluding) the given epochIndex by reverting the recorded changes of the later epochs in memory, so that they also
+// contain the Transactions that were applied after the epochs had been committed.
+func (s *StateCommitment) reviseEpochCommitments(mutations kvstore.BatchedMutations, revisedEpochIndex EpochIndex, epochIndex EpochIndex) (err error) {
+	if revisedEpochIndex >= epochIndex {
+		return nil
+	}
+
+	batch, err := s.newStateTreeBatch()
+	if err != nil {
+		return err
+	}
+	for currentEpochIndex := epochIndex; currentEpochIndex > revisedEpochIndex; currentEpochIndex-- {
+		diffs, diffsErr := s.epochDiffs(currentEpochIndex)
+		if diffsErr != nil {
+			return diffsErr
+		}
+		for i := len(diffs) - 1; i >= 0; i-- {
+			if _, err = batch.setLeaf(diffs[i].path, diffs[i].previousLeaf); err != nil {
+				return err
+			}
+		}
+
+		previousEpochCommitment, epochCommitmentErr := s.epochCommitment(currentEpochIndex - 1)
+		if epochCommitmentErr != nil {
+			if errors.Is(epochCommitmentErr, kvstore.ErrKeyNotFound) {
+				// the epochs before the first committed epoch do not have an EpochCommitment that could be revised
+				return nil
+			}
+			return epochCommitmentErr
+		}
+
+		root, rootErr := batch.node(0, StateRoot{})
+		if rootErr != nil {
+			return rootErr
+		}
+		if root.subtreeHash() == previousEpochCommitment.StateRoot() {
+			continue
+		}
+
+		revisedEpochCommitment := NewEpochCommitment(currentEpochIndex-1, root.subtreeHash(), batch.outputCount, time.Now())
+		if err = mutations.Set(append([]byte{stateCommitmentEpochPrefix}, revisedEpochCommitment.EpochIndex().Bytes()...), revisedEpochCommitment.Bytes()); err != nil {
+			return xerrors.Errorf("failed to store revised EpochCommitment of epoch %d: %w", revisedEpochCommitment.EpochIndex(), err)
+		}
+	}
+
+	return nil
+}
+
+// addOutputs adds the given Outputs of a snapshot to the StateCommitment.
+func (s *StateCommitment) addOutputs(outputs ...Output) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch, err := s.newStateTreeBatch()
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		if err = batch.update(output.ID(), hashStateTreeLeaf(output)); err != nil {
+			return err
+		}
+	}
+
+	return batch.commit(nil)
+}
+
+// stageTransaction marks the given confirmed Transaction to be applied when the epoch that contains the given timestamp
+// is committed. Transactions that were committed before are ignored.
+func (s *StateCommitment) stageTransaction(transactionID TransactionID, timestamp time.Time) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if committed, err := s.store.Has(append([]byte{stateCommitmentTransactionPrefix}, transactionID.Bytes()...)); err != nil || committed {
+		return err
+	}
+
+	if err = s.store.Set(pendingTransactionKey(transactionID, timestamp), []byte{}); err != nil {
+		return xerrors.Errorf("failed to stage %s: %w", transactionID, err)
+	}
+
+	return nil
+}
+
+// pendingTransactions returns the staged Transactions whose timestamp lies before the given time in the order of their
+// timestamps.
+func (s *StateCommitment) pendingTransactions(before time.Time) (stagedTransactions []*stagedTransaction, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err = s.store.Iterate([]byte{stateCommitmentPendingTransactionPrefix}, func(key kvstore.Key, _ kvstore.Value) bool {
+		marshalUtil := marshalutil.New(key[1:])
+		timestamp, timestampErr := marshalUtil.ReadUint64()
+		if timestampErr != nil {
+			err = xerrors.Errorf("failed to parse timestamp of pending Transaction: %w", timestampErr)
+			return false
+		}
+		if int64(timestamp) >= before.UnixNano() {
+			return true
+		}
+
+		transactionID, transactionIDErr := TransactionIDFromMarshalUtil(marshalUtil)
+		if transactionIDErr != nil {
+			err = xerrors.Errorf("failed to parse pending Transaction: %w", transactionIDErr)
+			return false
+		}
+		stagedTransactions = append(stagedTransactions, &stagedTransaction{
+			transactionID: transactionID,
+			timestamp:     time.Unix(0, int64(timestamp)),
+		})
+
+		return true
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate pending Transactions: %w", err)
+	}
+
+	// the timestamps are not marshaled in big endian, so the keys are not iterated in order
+	sort.Slice(stagedTransactions, func(i, j int) bool {
+		if !stagedTransactions[i].timestamp.Equal(stagedTransactions[j].timestamp) {
+			return stagedTransactions[i].timestamp.Before(stagedTransactions[j].timestamp)
+		}
+		return bytes.Compare(stagedTransactions[i].transactionID.Bytes(), stagedTransactions[j].transactionID.Bytes()) < 0
+	})
+
+	return stagedTransactions, err
+}
+
+// commitTransaction removes the Outputs that are consumed by the given staged Transaction from the StateCommitment and
+// adds the created Outputs that are not spent by an already committed Transaction, yet (the consumers are retrieved
+// with the given function). The changes are recorded as part of the given epoch. Transactions that were committed before
+// are ignored.
+func (s *StateCommitment) commitTransaction(transaction *Transaction, createdOutputs Outputs, consumers func(outputID OutputID) []TransactionID, epochIndex EpochIndex) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	transactionKey := append([]byte{stateCommitmentTransactionPrefix}, transaction.ID().Bytes()...)
+	pendingKey := pendingTransactionKey(transaction.ID(), transaction.Essence().Timestamp())
+	if committed, err := s.store.Has(transactionKey); err != nil || committed {
+		if err == nil {
+			err = s.store.Delete(pendingKey)
+		}
+		return err
+	}
+
+	batch, err := s.newStateTreeBatch()
+	if err != nil {
+		return err
+	}
+	for _, input := range transaction.Essence().Inputs() {
+		if err = batch.update(input.(*UTXOInput).ReferencedOutputID(), StateRoot{}); err != nil {
+			return err
+		}
+	}
+
+OutputLoop:
+	for _, output := range createdOutputs {
+		for _, consumer := range consumers(output.ID()) {
+			spent, err := s.store.Has(append([]byte{stateCommitmentTransactionPrefix}, consumer.Bytes()...))
+			if err != nil {
+				return xerrors.Errorf("failed to check if %s was committed: %w", consumer, err)
+			}
+			if spent {
+				continue OutputLoop
+			}
+		}
+
+		if err = batch.update(output.ID(), hashStateTreeLeaf(output)); err != nil {
+			return err
+		}
+	}
+
+	return batch.commit(func(mutations kvstore.BatchedMutations) error {
+		if err := mutations.Set(transactionKey, []byte{}); err != nil {
+			return xerrors.Errorf("failed to mark %s as committed: %w", transaction.ID(), err)
+		}
+		if err := mutations.Delete(pendingKey); err != nil {
+			return xerrors.Errorf("failed to remove %s from the pending Transactions: %w", transaction.ID(), err)
+		}
+
+		return s.appendEpochDiffs(mutations, epochIndex, batch.diffs)
+	})
+}
+
+// newStateTreeBatch returns a stateTreeBatch that collects updates of the tree of the StateCommitment.
+func (s *StateCommitment) newStateTreeBatch() (batch *stateTreeBatch, err error) {
+	batch = &stateTreeBatch{
+		stateCommitment: s,
+		nodes:           make(map[string]*stateTreeNode),
+	}
+	if batch.outputCount, err = s.outputCount(); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// node returns the node at the given depth that lies on the given path (nil if the subtree is empty).
+func (s *StateCommitment) node(depth int, path StateRoot) (node *stateTreeNode, err error) {
+	nodeBytes, err := s.store.Get(stateTreeNodeKey(depth, path))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, xerrors.Errorf("failed to load node at depth %d: %w", depth, err)
+	}
+	if node, err = stateTreeNodeFromBytes(nodeBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse node at depth %d: %w", depth, err)
+	}
+
+	return node, nil
+}
+
+// outputCount returns the number of Outputs that are contained in the StateCommitment.
+func (s *StateCommitment) outputCount() (outputCount uint64, err error) {
+	outputCountBytes, err := s.store.Get([]byte{stateCommitmentOutputCountKey})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, xerrors.Errorf("failed to load output count: %w", err)
+	}
+
+	if outputCount, err = marshalutil.New(outputCountBytes).ReadUint64(); err != nil {
+		return 0, xerrors.Errorf("failed to parse output count: %w", err)
+	}
+
+	return outputCount, nil
+}
+
+// epochCommitment loads the EpochCommitment of the given EpochIndex from the store.
+func (s *StateCommitment) epochCommitment(epochIndex EpochIndex) (epochCommitment *EpochCommitment, err error) {
+	epochCommitmentBytes, err := s.store.Get(append([]byte{stateCommitmentEpochPrefix}, epochIndex.Bytes()...))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load EpochCommitment of epoch %d: %w", epochIndex, err)
+	}
+	if epochCommitment, _, err = EpochCommitmentFromBytes(epochCommitmentBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse EpochCommitment of epoch %d: %w", epochIndex, err)
+	}
+
+	return epochCommitment, nil
+}
+
+// latestEpochIndex returns the highest EpochIndex that was committed.
+func (s *StateCommitment) latestEpochIndex() (epochIndex EpochIndex, err error) {
+	epochIndexBytes, err := s.store.Get([]byte{stateCommitmentLatestEpochKey})
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load latest EpochIndex: %w", err)
+	}
+
+	epochIndexUint64, err := marshalutil.New(epochIndexBytes).ReadUint64()
+	if err != nil {
+		return 0, xerrors.Errorf("failed to parse latest EpochIndex: %w", err)
+	}
+
+	return EpochIndex(epochIndexUint64), nil
+}
+
+// epochDiffs returns the changes of the leaves that were recorded for the given epoch in the order of their application.
+func (s *StateCommitment) epochDiffs(epochIndex EpochIndex) (diffs []*stateTreeDiff, err error) {
+	diffsBytes, err := s.store.Get(append([]byte{stateCommitmentEpochDiffPrefix}, epochIndex.Bytes()...))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, xerrors.Errorf("failed to load changes of epoch %d: %w", epochIndex, err)
+	}
+
+	marshalUtil := marshalutil.New(diffsBytes)
+	for marshalUtil.ReadOffset() < len(diffsBytes) {
+		diff, diffErr := stateTreeDiffFromMarshalUtil(marshalUtil)
+		if diffErr != nil {
+			return nil, xerrors.Errorf("failed to parse changes of epoch %d: %w", epochIndex, diffErr)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+// appendEpochDiffs adds the given changes of the leaves to the ones that were recorded for the given epoch.
+func (s *StateCommitment) appendEpochDiffs(mutations kvstore.BatchedMutations, epochIndex EpochIndex, diffs []*stateTreeDiff) (err error) {
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	key := append([]byte{stateCommitmentEpochDiffPrefix}, epochIndex.Bytes()...)
+	diffsBytes, err := s.store.Get(key)
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return xerrors.Errorf("failed to load changes of epoch %d: %w", epochIndex, err)
+	}
+	for _, diff := range diffs {
+		diffsBytes = append(diffsBytes, diff.Bytes()...)
+	}
+	if err = mutations.Set(key, diffsBytes); err != nil {
+		return xerrors.Errorf("failed to store changes of epoch %d: %w", epochIndex, err)
+	}
+
+	return nil
+}
+
+// pendingTransactionKey returns the storage key of the staged Transaction with the given ID and timestamp.
+func pendingTransactionKey(transactionID TransactionID, timestamp time.Time) []byte {
+	return marshalutil.New(1 + marshalutil.Uint64Size + TransactionIDLength).
+		WriteByte(stateCommitmentPendingTransactionPrefix).
+		WriteUint64(uint64(timestamp.UnixNano())).
+		Write(transactionID).
+		Bytes()
+}
+
+// stateTreePath returns the path of the leaf of the given OutputID in the sparse Merkle tree.
+func stateTreePath(outputID OutputID) StateRoot {
+	return blake2b.Sum256(outputID.Bytes())
+}
+
+// stateTreeBit returns the bit of the path that decides the direction below the node at the given depth.
+func stateTreeBit(path StateRoot, depth int) byte {
+	return (path[depth/8] >> (7 - uint(depth%8))) & 1
+}
+
+// stateTreeChild returns a path that leads to the given child of the node at the given depth.
+func stateTreeChild(path StateRoot, depth int, bit byte) StateRoot {
+	path[depth/8] &^= 1 << (7 - uint(depth%8))
+	path[depth/8] |= bit << (7 - uint(depth%8))
+
+	return path
+}
+
+// stateTreeSibling returns a path that leads to the sibling of the node at the given depth.
+func stateTreeSibling(path StateRoot, depth int) StateRoot {
+	path[(depth-1)/8] ^= 1 << (7 - uint((depth-1)%8))
+
+	return path
+}
+
+// stateTreeCommonPrefixLength returns the number of leading bits that the given paths have in common.
+func stateTreeCommonPrefixLength(path1 StateRoot, path2 StateRoot) (length int) {
+	for length < stateTreeDepth && stateTreeBit(path1, length) == stateTreeBit(path2, length) {
+		length++
+	}
+
+	return length
+}
+
+// stateTreeNodeKey returns the storage key of the node at the given depth that lies on the given path.
+func stateTreeNodeKey(depth int, path StateRoot) []byte {
+	// reset the bits below the given depth, so that all paths through the node share the same key
+	for i := depth; i < stateTreeDepth; i++ {
+		path[i/8] &^= 1 << (7 - uint(i%8))
+	}
+
+	return marshalutil.New(1 + marshalutil.Uint16Size + StateRootLength).
+		WriteByte(stateCommitmentNodePrefix).
+		WriteUint16(uint16(depth)).
+		WriteBytes(path.Bytes()).
+		Bytes()
+}
+
+// hashStateTreeLeaf returns the leaf of the given Output in the sparse Merkle tree.
+func hashStateTreeLeaf(output Output) StateRoot {
+	return blake2b.Sum256(marshalutil.New().
+		WriteByte(stateTreeLeafPrefix).
+		Write(output.ID()).
+		WriteBytes(output.Bytes()).
+		Bytes())
+}
+
+// hashStateTreeNode returns the inner node of the sparse Merkle tree with the given children.
+func hashStateTreeNode(left StateRoot, right StateRoot) StateRoot {
+	return blake2b.Sum256(marshalutil.New(1 + 2*StateRootLength).
+		WriteByte(stateTreeNodePrefix).
+		WriteBytes(left.Bytes()).
+		WriteBytes(right.Bytes()).
+		Bytes())
+}
+
+// stagedTransaction is a confirmed Transaction that waits for the epoch that contains its timestamp to be committed.
+type stagedTransaction struct {
+	transactionID TransactionID
+	timestamp     time.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region stateTreeNode ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// stateTreeNode is a non-empty node of the compacted sparse Merkle tree. A subtree that contains a single leaf is
+// replaced by the leaf itself, which is why leaves store their full path, while inner nodes only store the hash of
+// their children. Empty subtrees are not stored and have a zero hash.
+type stateTreeNode struct {
+	leaf bool
+	path StateRoot
+	hash StateRoot
+}
+
+// stateTreeNodeFromBytes unmarshals a stateTreeNode from a sequence of bytes.
+func stateTreeNodeFromBytes(bytes []byte) (node *stateTreeNode, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	nodeType, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse node type (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	node = &stateTreeNode{leaf: nodeType == stateTreeLeafPrefix}
+	if node.leaf {
+		if node.path, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+			return nil, xerrors.Errorf("failed to parse path: %w", err)
+		}
+	}
+	if node.hash, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse hash: %w", err)
+	}
+
+	return node, nil
+}
+
+// subtreeHash returns the hash of the subtree that is formed by the node (a nil node is an empty subtree).
+func (s *stateTreeNode) subtreeHash() StateRoot {
+	if s == nil {
+		return StateRoot{}
+	}
+
+	return s.hash
+}
+
+// Bytes returns a marshaled version of the stateTreeNode.
+func (s *stateTreeNode) Bytes() []byte {
+	if !s.leaf {
+		return marshalutil.New(1 + StateRootLength).WriteByte(stateTreeNodePrefix).WriteBytes(s.hash.Bytes()).Bytes()
+	}
+
+	return marshalutil.New(1 + 2*StateRootLength).
+		WriteByte(stateTreeLeafPrefix).
+		WriteBytes(s.path.Bytes()).
+		WriteBytes(s.hash.Bytes()).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region stateTreeDiff ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// stateTreeDiff is the change of a leaf of the sparse Merkle tree (a zero leaf represents a missing leaf).
+type stateTreeDiff struct {
+	path         StateRoot
+	previousLeaf StateRoot
+	leaf         StateRoot
+}
+
+// stateTreeDiffFromMarshalUtil unmarshals a stateTreeDiff using a MarshalUtil (for easier unmarshaling).
+func stateTreeDiffFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (diff *stateTreeDiff, err error) {
+	diff = &stateTreeDiff{}
+	if diff.path, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse path: %w", err)
+	}
+	if diff.previousLeaf, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse previous leaf: %w", err)
+	}
+	if diff.leaf, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse leaf: %w", err)
+	}
+
+	return diff, nil
+}
+
+// Bytes returns a marshaled version of the stateTreeDiff.
+func (s *stateTreeDiff) Bytes() []byte {
+	return marshalutil.New(3 * StateRootLength).
+		WriteBytes(s.path.Bytes()).
+		WriteBytes(s.previousLeaf.Bytes()).
+		WriteBytes(s.leaf.Bytes()).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region stateTreeBatch ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// stateTreeBatch collects updates of the leaves of the sparse Merkle tree in memory and writes the modified nodes to the
+// store in a single batch. Leaves that share a part of their path only load and store the shared nodes once.
+type stateTreeBatch struct {
+	stateCommitment *StateCommitment
+	nodes           map[string]*stateTreeNode
+	diffs           []*stateTreeDiff
+	outputCount     uint64
+}
+
+// update sets the leaf of the given OutputID (a zero leaf removes it) and updates the nodes on the path to the root.
+func (s *stateTreeBatch) update(outputID OutputID, leaf StateRoot) (err error) {
+	path := stateTreePath(outputID)
+	previousLeaf, err := s.setLeaf(path, leaf)
+	if err != nil {
+		return err
+	}
+	if previousLeaf != leaf {
+		s.diffs = append(s.diffs, &stateTreeDiff{path: path, previousLeaf: previousLeaf, leaf: leaf})
+	}
+
+	return nil
+}
+
+// setLeaf sets the leaf with the given path (a zero leaf removes it) and returns the previous leaf.
+func (s *stateTreeBatch) setLeaf(path StateRoot, leaf StateRoot) (previousLeaf StateRoot, err error) {
+	depth, node, err := s.leaf(path)
+	if err != nil {
+		return StateRoot{}, err
+	}
+	if node != nil && node.path == path {
+		previousLeaf = node.hash
+	}
+
+	switch {
+	case previousLeaf == leaf:
+		return previousLeaf, nil
+	case leaf == StateRoot{}:
+		return previousLeaf, s.removeLeaf(path, depth)
+	case previousLeaf != StateRoot{}:
+		s.setNode(depth, path, &stateTreeNode{leaf: true, path: path, hash: leaf})
+		return previousLeaf, s.rehash(path, depth)
+	default:
+		return previousLeaf, s.insertLeaf(path, leaf, depth, node)
+	}
+}
+
+// leaf follows the given path from the root until it reaches a leaf or an empty subtree and returns its depth together
+// with the leaf (nil for an empty subtree). The leaf belongs to a different path if the given one is not contained.
+func (s *stateTreeBatch) leaf(path StateRoot) (depth int, leaf *stateTreeNode, err error) {
+	for depth = 0; depth <= stateTreeDepth; depth++ {
+		if leaf, err = s.node(depth, path); err != nil || leaf == nil || leaf.leaf {
+			return depth, leaf, err
+		}
+	}
+
+	return 0, nil, xerrors.Errorf("failed to find a leaf on the path %s: %w", path, cerrors.ErrFatal)
+}
+
+// insertLeaf adds a leaf with a new path to the subtree at the given depth, which is either empty or contains the given
+// existing leaf. In the latter case both leaves are moved below the first bit in which their paths differ.
+func (s *stateTreeBatch) insertLeaf(path StateRoot, leaf StateRoot, depth int, existingLeaf *stateTreeNode) (err error) {
+	newLeaf := &stateTreeNode{leaf: true, path: path, hash: leaf}
+	if existingLeaf != nil {
+		splitDepth := stateTreeCommonPrefixLength(existingLeaf.path, path)
+		for ; depth <= splitDepth; depth++ {
+			s.setNode(depth, path, &stateTreeNode{})
+		}
+		s.setNode(depth, existingLeaf.path, existingLeaf)
+	}
+	s.setNode(depth, path, newLeaf)
+	s.outputCount++
+
+	return s.rehash(path, depth)
+}
+
+// removeLeaf removes the leaf with the given path at the given depth. If its sibling is a leaf, the sibling is moved up
+// to the shortest prefix of its path that is still unique.
+func (s *stateTreeBatch) removeLeaf(path StateRoot, depth int) (err error) {
+	s.setNode(depth, path, nil)
+	s.outputCount--
+	if depth == 0 {
+		return nil
+	}
+
+	sibling, err := s.node(depth, stateTreeSibling(path, depth))
+	if err != nil {
+		return err
+	}
+	if sibling == nil || !sibling.leaf {
+		return s.rehash(path, depth)
+	}
+
+	s.setNode(depth, sibling.path, nil)
+	for depth--; depth > 0; depth-- {
+		uncle, uncleErr := s.node(depth, stateTreeSibling(path, depth))
+		if uncleErr != nil {
+			return uncleErr
+		}
+		if uncle != nil {
+			break
+		}
+		s.setNode(depth, path, nil)
+	}
+	s.setNode(depth, path, sibling)
+
+	return s.rehash(path, depth)
+}
+
+// rehash updates the inner nodes on the given path above the given depth.
+func (s *stateTreeBatch) rehash(path StateRoot, depth int) (err error) {
+	for depth--; depth >= 0; depth-- {
+		left, leftErr := s.node(depth+1, stateTreeChild(path, depth, 0))
+		if leftErr != nil {
+			return leftErr
+		}
+		right, rightErr := s.node(depth+1, stateTreeChild(path, depth, 1))
+		if rightErr != nil {
+			return rightErr
+		}
+
+		s.setNode(depth, path, &stateTreeNode{hash: hashStateTreeNode(left.subtreeHash(), right.subtreeHash())})
+	}
+
+	return nil
+}
+
+// node returns the node at the given depth that lies on the given path (including the updates of the batch).
+func (s *stateTreeBatch) node(depth int, path StateRoot) (node *stateTreeNode, err error) {
+	if node, exists := s.nodes[string(stateTreeNodeKey(depth, path))]; exists {
+		return node, nil
+	}
+
+	return s.stateCommitment.node(depth, path)
+}
+
+// setNode replaces the node at the given depth that lies on the given path (nil removes it).
+func (s *stateTreeBatch) setNode(depth int, path StateRoot, node *stateTreeNode) {
+	s.nodes[string(stateTreeNodeKey(depth, path))] = node
+}
+
+// commit writes the modified nodes and the output count together with the given additional mutations to the store.
+func (s *stateTreeBatch) commit(additionalMutations func(mutations kvstore.BatchedMutations) error) (err error) {
+	mutations := s.stateCommitment.store.Batched()
+	defer func() {
+		if err != nil {
+			mutations.Cancel()
+		}
+	}()
+
+	for key, node := range s.nodes {
+		if node == nil {
+			err = mutations.Delete([]byte(key))
+		} else {
+			err = mutations.Set([]byte(key), node.Bytes())
+		}
+		if err != nil {
+			return xerrors.Errorf("failed to store node: %w", err)
+		}
+	}
+	if err = mutations.Set([]byte{stateCommitmentOutputCountKey}, marshalutil.New(marshalutil.Uint64Size).WriteUint64(s.outputCount).Bytes()); err != nil {
+		return xerrors.Errorf("failed to store output count: %w", err)
+	}
+	if additionalMutations != nil {
+		if err = additionalMutations(mutations); err != nil {
+			return err
+		}
+	}
+
+	if err = mutations.Commit(); err != nil {
+		return xerrors.Errorf("failed to commit update of the StateCommitment: %w", err)
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region StateInclusionProof //////////////////////////////////////////////////////////////////////////////////////////
+
+// StateInclusionProof proves that an Output is contained in the confirmed unspent Outputs of a given StateRoot. It
+// contains the siblings of the nodes on the path from the leaf of the Output to the root.
+type StateInclusionProof struct {
+	siblings []StateRoot
+}
+
+// StateInclusionProofFromBytes unmarshals a StateInclusionProof from a sequence of bytes.
+func StateInclusionProofFromBytes(bytes []byte) (proof *StateInclusionProof, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if proof, err = StateInclusionProofFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse StateInclusionProof from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// StateInclusionProofFromMarshalUtil unmarshals a StateInclusionProof using a MarshalUtil (for easier unmarshaling).
+func StateInclusionProofFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (proof *StateInclusionProof, err error) {
+	siblingCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse sibling count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if siblingCount > stateTreeDepth {
+		err = xerrors.Errorf("sibling count %d exceeds the depth of the tree: %w", siblingCount, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	proof = &StateInclusionProof{siblings: make([]StateRoot, siblingCount)}
+	for i := range proof.siblings {
+		if proof.siblings[i], err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse sibling at depth %d: %w", i+1, err)
+			return
+		}
+	}
+
+	return
+}
+
+// Verify returns true if the StateInclusionProof proves that the given Output is contained in the given StateRoot.
+func (s *StateInclusionProof) Verify(stateRoot StateRoot, output Output) bool {
+	path := stateTreePath(output.ID())
+	node := hashStateTreeLeaf(output)
+	for depth := len(s.siblings); depth > 0; depth-- {
+		if stateTreeBit(path, depth-1) == 0 {
+			node = hashStateTreeNode(node, s.siblings[depth-1])
+		} else {
+			node = hashStateTreeNode(s.siblings[depth-1], node)
+		}
+	}
+
+	return node == stateRoot
+}
+
+// Bytes returns a marshaled version of the StateInclusionProof.
+func (s *StateInclusionProof) Bytes() []byte {
+	marshalUtil := marshalutil.New(marshalutil.Uint16Size + len(s.siblings)*StateRootLength)
+	marshalUtil.WriteUint16(uint16(len(s.siblings)))
+	for _, sibling := range s.siblings {
+		marshalUtil.WriteBytes(sibling.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Base58 returns a base58 encoded version of the StateInclusionProof.
+func (s *StateInclusionProof) Base58() string {
+	return base58.Encode(s.Bytes())
+}
+
+// String returns a human readable version of the StateInclusionProof.
+func (s *StateInclusionProof) String() string {
+	return "StateInclusionProof(" + s.Base58() + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EpochIndex ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EpochIndex is the index of a fixed length time interval whose end is used to commit to the ledger state.
+type EpochIndex uint64
+
+// EpochIndexFromTime returns the EpochIndex of the epoch of the given length that contains the given time.
+func EpochIndexFromTime(t time.Time, epochDuration time.Duration) EpochIndex {
+	return EpochIndex(t.UnixNano() / int64(epochDuration))
+}
+
+// EndTime returns the time at which the epoch of the given length ends.
+func (e EpochIndex) EndTime(epochDuration time.Duration) time.Time {
+	return time.Unix(0, int64(e+1)*int64(epochDuration))
+}
+
+// Bytes returns a marshaled version of the EpochIndex.
+func (e EpochIndex) Bytes() []byte {
+	return marshalutil.New(marshalutil.Uint64Size).WriteUint64(uint64(e)).Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EpochCommitment //////////////////////////////////////////////////////////////////////////////////////////////
+
+// EpochCommitment contains the StateRoot of the ledger state at the end of an epoch.
+type EpochCommitment struct {
+	epochIndex  EpochIndex
+	stateRoot   StateRoot
+	outputCount uint64
+	commitTime  time.Time
+}
+
+// NewEpochCommitment returns a new EpochCommitment from the given details.
+func NewEpochCommitment(epochIndex EpochIndex, stateRoot StateRoot, outputCount uint64, commitTime time.Time) *EpochCommitment {
+	return &EpochCommitment{
+		epochIndex:  epochIndex,
+		stateRoot:   stateRoot,
+		outputCount: outputCount,
+		commitTime:  commitTime,
+	}
+}
+
+// EpochCommitmentFromBytes unmarshals an EpochCommitment from a sequence of bytes.
+func EpochCommitmentFromBytes(bytes []byte) (epochCommitment *EpochCommitment, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if epochCommitment, err = EpochCommitmentFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse EpochCommitment from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// EpochCommitmentFromMarshalUtil unmarshals an EpochCommitment using a MarshalUtil (for easier unmarshaling).
+func EpochCommitmentFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (epochCommitment *EpochCommitment, err error) {
+	epochCommitment = &EpochCommitment{}
+	epochIndex, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse EpochIndex (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	epochCommitment.epochIndex = EpochIndex(epochIndex)
+	if epochCommitment.stateRoot, err = StateRootFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse StateRoot: %w", err)
+		return
+	}
+	if epochCommitment.outputCount, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse output count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if epochCommitment.commitTime, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse commit time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// EpochIndex returns the EpochIndex of the committed epoch.
+func (e *EpochCommitment) EpochIndex() EpochIndex {
+	return e.epochIndex
+}
+
+// StateRoot returns the StateRoot of the ledger state at the end of the epoch.
+func (e *EpochCommitment) StateRoot() StateRoot {
+	return e.stateRoot
+}
+
+// OutputCount returns the number of confirmed unspent Outputs at the end of the epoch.
+func (e *EpochCommitment) OutputCount() uint64 {
+	return e.outputCount
+}
+
+// CommitTime returns the time at which the epoch was committed.
+func (e *EpochCommitment) CommitTime() time.Time {
+	return e.commitTime
+}
+
+// Bytes returns a marshaled version of the EpochCommitment.
+func (e *EpochCommitment) Bytes() []byte {
+	return marshalutil.New().
+		WriteUint64(uint64(e.epochIndex)).
+		WriteBytes(e.stateRoot.Bytes()).
+		WriteUint64(e.outputCount).
+		WriteTime(e.commitTime).
+		Bytes()
+}
+
+// String returns a human readable version of the EpochCommitment.
+func (e *EpochCommitment) String() string {
+	return stringify.Struct("EpochCommitment",
+		stringify.StructField("epochIndex", uint64(e.epochIndex)),
+		stringify.StructField("stateRoot", e.stateRoot),
+		stringify.StructField("outputCount", e.outputCount),
+		stringify.StructField("commitTime", e.commitTime),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,284 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestStateCommitment_OrderIndependence(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make(Outputs, 0)
+	for i := uint16(0); i < 5; i++ {
+		output := NewSigLockedSingleOutput(uint64(100+i), address)
+		output.SetID(NewOutputID(TransactionID{1}, i))
+		outputs = append(outputs, output)
+	}
+
+	stateCommitment1 := newStateCommitment(mapdb.NewMapDB())
+	emptyRoot, err := stateCommitment1.Root()
+	require.NoError(t, err)
+	assert.Equal(t, StateRoot{}, emptyRoot)
+	require.NoError(t, stateCommitment1.addOutputs(outputs...))
+
+	stateCommitment2 := newStateCommitment(mapdb.NewMapDB())
+	require.NoError(t, stateCommitment2.addOutputs(outputs[4], outputs[2], outputs[0], outputs[3], outputs[1]))
+
+	root1, err := stateCommitment1.Root()
+	require.NoError(t, err)
+	root2, err := stateCommitment2.Root()
+	require.NoError(t, err)
+	assert.Equal(t, root1, root2)
+	assert.NotEqual(t, emptyRoot, root1)
+
+	outputCount, err := stateCommitment1.OutputCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), outputCount)
+
+	// removing all Outputs results in the empty root again
+	batch, err := stateCommitment1.newStateTreeBatch()
+	require.NoError(t, err)
+	for _, output := range outputs {
+		require.NoError(t, batch.update(output.ID(), StateRoot{}))
+	}
+	require.NoError(t, batch.commit(nil))
+	root1, err = stateCommitment1.Root()
+	require.NoError(t, err)
+	assert.Equal(t, emptyRoot, root1)
+	outputCount, err = stateCommitment1.OutputCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), outputCount)
+}
+
+func TestStateCommitment_Compaction(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make(Outputs, 0)
+	for i := uint16(0); i < 100; i++ {
+		output := NewSigLockedSingleOutput(uint64(100+i), address)
+		output.SetID(NewOutputID(TransactionID{5}, i))
+		outputs = append(outputs, output)
+	}
+
+	nodeCount := func(store kvstore.KVStore) (count int) {
+		require.NoError(t, store.IterateKeys([]byte{stateCommitmentNodePrefix}, func(kvstore.Key) bool {
+			count++
+			return true
+		}))
+		return count
+	}
+
+	// the leaves are stored at the shortest unique prefix of their path instead of at the full depth of the tree
+	store := mapdb.NewMapDB()
+	stateCommitment := newStateCommitment(store)
+	require.NoError(t, stateCommitment.addOutputs(outputs...))
+	assert.Less(t, nodeCount(store), 4*len(outputs))
+
+	for _, output := range outputs {
+		proof, stateRoot, err := stateCommitment.InclusionProof(output.ID())
+		require.NoError(t, err)
+		assert.True(t, proof.Verify(stateRoot, output))
+		assert.Less(t, len(proof.siblings), 32)
+	}
+
+	// removing the Outputs in a different order collapses the tree to the same nodes
+	store2 := mapdb.NewMapDB()
+	stateCommitment2 := newStateCommitment(store2)
+	require.NoError(t, stateCommitment2.addOutputs(outputs[50:]...))
+	batch, err := stateCommitment.newStateTreeBatch()
+	require.NoError(t, err)
+	for i := 49; i >= 0; i-- {
+		require.NoError(t, batch.update(outputs[i].ID(), StateRoot{}))
+	}
+	require.NoError(t, batch.commit(nil))
+
+	root1, err := stateCommitment.Root()
+	require.NoError(t, err)
+	root2, err := stateCommitment2.Root()
+	require.NoError(t, err)
+	assert.Equal(t, root2, root1)
+	assert.Equal(t, nodeCount(store2), nodeCount(store))
+
+	_, _, err = stateCommitment.InclusionProof(outputs[0].ID())
+	assert.True(t, xerrors.Is(err, kvstore.ErrKeyNotFound))
+}
+
+func TestStateInclusionProof(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make(Outputs, 0)
+	for i := uint16(0); i < 3; i++ {
+		output := NewSigLockedSingleOutput(uint64(100+i), address)
+		output.SetID(NewOutputID(TransactionID{2}, i))
+		outputs = append(outputs, output)
+	}
+
+	stateCommitment := newStateCommitment(mapdb.NewMapDB())
+	require.NoError(t, stateCommitment.addOutputs(outputs...))
+
+	proof, stateRoot, err := stateCommitment.InclusionProof(outputs[1].ID())
+	require.NoError(t, err)
+	assert.True(t, proof.Verify(stateRoot, outputs[1]))
+
+	// the proof survives a marshaling round trip
+	restoredProof, consumedBytes, err := StateInclusionProofFromBytes(proof.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(proof.Bytes()), consumedBytes)
+	assert.True(t, restoredProof.Verify(stateRoot, outputs[1]))
+
+	// the proof does not verify for different Outputs or roots
+	assert.False(t, proof.Verify(stateRoot, outputs[0]))
+	modifiedOutput := NewSigLockedSingleOutput(1337, address)
+	modifiedOutput.SetID(outputs[1].ID())
+	assert.False(t, proof.Verify(stateRoot, modifiedOutput))
+	assert.False(t, proof.Verify(StateRoot{}, outputs[1]))
+
+	contained, err := stateCommitment.Contains(outputs[2].ID())
+	require.NoError(t, err)
+	assert.True(t, contained)
+	contained, err = stateCommitment.Contains(NewOutputID(TransactionID{3}, 0))
+	require.NoError(t, err)
+	assert.False(t, contained)
+}
+
+func TestStateCommitment_CommitEpoch(t *testing.T) {
+	stateCommitment := newStateCommitment(mapdb.NewMapDB())
+
+	_, err := stateCommitment.LatestEpochCommitment()
+	assert.True(t, xerrors.Is(err, kvstore.ErrKeyNotFound))
+
+	epochCommitment, err := stateCommitment.CommitEpoch(10)
+	require.NoError(t, err)
+	assert.Equal(t, EpochIndex(10), epochCommitment.EpochIndex())
+	assert.Equal(t, StateRoot{}, epochCommitment.StateRoot())
+
+	output := NewSigLockedSingleOutput(100, NewED25519Address(ed25519.GenerateKeyPair().PublicKey))
+	output.SetID(NewOutputID(TransactionID{4}, 0))
+	require.NoError(t, stateCommitment.addOutputs(output))
+
+	// epochs are committed only once
+	recommittedEpoch, err := stateCommitment.CommitEpoch(10)
+	require.NoError(t, err)
+	assert.Equal(t, epochCommitment.Bytes(), recommittedEpoch.Bytes())
+
+	// older epochs can not be committed anymore
+	_, err = stateCommitment.CommitEpoch(9)
+	assert.True(t, xerrors.Is(err, cerrors.ErrFatal))
+	latestEpochCommitment, err := stateCommitment.LatestEpochCommitment()
+	require.NoError(t, err)
+	assert.Equal(t, EpochIndex(10), latestEpochCommitment.EpochIndex())
+
+	epochCommitment, err = stateCommitment.CommitEpoch(11)
+	require.NoError(t, err)
+	stateRoot, err := stateCommitment.Root()
+	require.NoError(t, err)
+	assert.Equal(t, stateRoot, epochCommitment.StateRoot())
+	assert.Equal(t, uint64(1), epochCommitment.OutputCount())
+
+	loadedEpochCommitment, err := stateCommitment.EpochCommitment(11)
+	require.NoError(t, err)
+	assert.Equal(t, epochCommitment.Bytes(), loadedEpochCommitment.Bytes())
+	_, err = stateCommitment.EpochCommitment(12)
+	assert.True(t, xerrors.Is(err, kvstore.ErrKeyNotFound))
+}
+
+func TestEpochIndex(t *testing.T) {
+	epochIndex := EpochIndexFromTime(time.Unix(125, 0), time.Minute)
+	assert.Equal(t, EpochIndex(2), epochIndex)
+	assert.Equal(t, time.Unix(180, 0), epochIndex.EndTime(time.Minute))
+}
+
+func TestUTXODAG_CommitConfirmedTransaction(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+	require.NoError(t, utxoDAG.StateCommitment().addOutputs(input))
+
+	tx := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx.ID()))
+
+	// the Transaction is only applied when the epoch that contains its timestamp is committed
+	epochIndex := EpochIndexFromTime(tx.Essence().Timestamp(), time.Minute)
+	_, err = utxoDAG.CommitEpoch(epochIndex-1, time.Minute)
+	require.NoError(t, err)
+	contained, err := utxoDAG.StateCommitment().Contains(input.ID())
+	require.NoError(t, err)
+	assert.True(t, contained)
+
+	epochCommitment, err := utxoDAG.CommitEpoch(epochIndex, time.Minute)
+	require.NoError(t, err)
+	contained, err = utxoDAG.StateCommitment().Contains(input.ID())
+	require.NoError(t, err)
+	assert.False(t, contained)
+	contained, err = utxoDAG.StateCommitment().Contains(NewOutputID(tx.ID(), 0))
+	require.NoError(t, err)
+	assert.True(t, contained)
+
+	// committing the same Transaction again does not modify the StateCommitment
+	stateRoot, err := utxoDAG.StateCommitment().Root()
+	require.NoError(t, err)
+	assert.Equal(t, stateRoot, epochCommitment.StateRoot())
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx.ID()))
+	_, err = utxoDAG.CommitEpoch(epochIndex+1, time.Minute)
+	require.NoError(t, err)
+	recommittedStateRoot, err := utxoDAG.StateCommitment().Root()
+	require.NoError(t, err)
+	assert.Equal(t, stateRoot, recommittedStateRoot)
+
+	outputCount, err := utxoDAG.StateCommitment().OutputCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), outputCount)
+}
+
+func TestUTXODAG_CommitLateConfirmedTransaction(t *testing.T) {
+	wallets := createWallets(2)
+	branchDAG1, utxoDAG1 := setupDependencies(t)
+	defer branchDAG1.Shutdown()
+	branchDAG2, utxoDAG2 := setupDependencies(t)
+	defer branchDAG2.Shutdown()
+
+	input := generateOutput(utxoDAG1, wallets[0].address, 1)
+	generateOutput(utxoDAG2, wallets[0].address, 1)
+	tx := buildTransaction(utxoDAG1, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	epochIndex := EpochIndexFromTime(tx.Essence().Timestamp(), time.Minute)
+	for _, utxoDAG := range []*UTXODAG{utxoDAG1, utxoDAG2} {
+		require.NoError(t, utxoDAG.StateCommitment().addOutputs(input))
+		_, err := utxoDAG.BookTransaction(tx)
+		require.NoError(t, err)
+	}
+
+	// the first node confirms the Transaction before its epoch is committed
+	require.NoError(t, utxoDAG1.CommitConfirmedTransaction(tx.ID()))
+	_, err := utxoDAG1.CommitEpoch(epochIndex, time.Minute)
+	require.NoError(t, err)
+	_, err = utxoDAG1.CommitEpoch(epochIndex+1, time.Minute)
+	require.NoError(t, err)
+
+	// the second node confirms it after its epoch was committed
+	lateEpochCommitment, err := utxoDAG2.CommitEpoch(epochIndex, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG2.CommitConfirmedTransaction(tx.ID()))
+	_, err = utxoDAG2.CommitEpoch(epochIndex+1, time.Minute)
+	require.NoError(t, err)
+
+	for _, index := range []EpochIndex{epochIndex, epochIndex + 1} {
+		epochCommitment1, err := utxoDAG1.StateCommitment().EpochCommitment(index)
+		require.NoError(t, err)
+		epochCommitment2, err := utxoDAG2.StateCommitment().EpochCommitment(index)
+		require.NoError(t, err)
+		assert.Equal(t, epochCommitment1.StateRoot(), epochCommitment2.StateRoot())
+		assert.Equal(t, epochCommitment1.OutputCount(), epochCommitment2.OutputCount())
+	}
+	revisedEpochCommitment, err := utxoDAG2.StateCommitment().EpochCommitment(epochIndex)
+	require.NoError(t, err)
+	assert.NotEqual(t, lateEpochCommitment.StateRoot(), revisedEpochCommitment.StateRoot())
+}
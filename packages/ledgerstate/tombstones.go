@@ -0,0 +1,46 @@
+package ledgerstate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// region tombstones ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tombstones is a persistent set of the identifiers of objects that were pruned from the ledger state. It allows us to
+// recognize late arriving references to objects that are no longer stored, so they can still be rejected.
+type tombstones struct {
+	store kvstore.KVStore
+}
+
+// newTombstones creates a new set of tombstones that is persisted in the given KVStore.
+func newTombstones(store kvstore.KVStore) *tombstones {
+	return &tombstones{
+		store: store,
+	}
+}
+
+// add marks the object with the given key as pruned by storing the time of its removal.
+func (t *tombstones) add(key []byte) (err error) {
+	return t.store.Set(key, marshalutil.New(marshalutil.TimeSize).WriteTime(time.Now()).Bytes())
+}
+
+// contains returns true if the object with the given key was pruned.
+func (t *tombstones) contains(key []byte) (contains bool) {
+	contains, err := t.store.Has(key)
+	if err != nil {
+		panic(fmt.Sprintf("failed to check tombstone of %x: %s", key, err))
+	}
+
+	return
+}
+
+// clear removes all tombstones (for testing or "node resets").
+func (t *tombstones) clear() (err error) {
+	return t.store.Clear()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
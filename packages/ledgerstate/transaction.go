@@ -647,6 +647,8 @@ type TransactionMetadata struct {
 	finalizedMutex          sync.RWMutex
 	lazyBooked              bool
 	lazyBookedMutex         sync.RWMutex
+	invalidReason           InvalidReason
+	invalidReasonMutex      sync.RWMutex
 
 	objectstorage.StorableObjectFlags
 }
@@ -697,6 +699,10 @@ func TransactionMetadataFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (t
 		err = xerrors.Errorf("failed to parse lazy booked flag (%v): %w", err, cerrors.ErrParseBytesFailed)
 		return
 	}
+	if transactionMetadata.invalidReason, err = InvalidReasonFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse InvalidReason: %w", err)
+		return
+	}
 
 	return
 }
@@ -829,6 +835,32 @@ func (t *TransactionMetadata) SetLazyBooked(lazyBooked bool) (modified bool) {
 	return
 }
 
+// InvalidReason returns the reason why the Transaction was booked into the InvalidBranch (NoInvalidReason if it was
+// not).
+func (t *TransactionMetadata) InvalidReason() (invalidReason InvalidReason) {
+	t.invalidReasonMutex.RLock()
+	defer t.invalidReasonMutex.RUnlock()
+
+	return t.invalidReason
+}
+
+// SetInvalidReason updates the reason why the Transaction was booked into the InvalidBranch. It returns true if the
+// value was modified.
+func (t *TransactionMetadata) SetInvalidReason(invalidReason InvalidReason) (modified bool) {
+	t.invalidReasonMutex.Lock()
+	defer t.invalidReasonMutex.Unlock()
+
+	if t.invalidReason == invalidReason {
+		return
+	}
+
+	t.invalidReason = invalidReason
+	t.SetModified()
+	modified = true
+
+	return
+}
+
 // Bytes marshals the TransactionMetadata into a sequence of bytes.
 func (t *TransactionMetadata) Bytes() []byte {
 	return byteutils.ConcatBytes(t.ObjectStorageKey(), t.ObjectStorageValue())
@@ -843,6 +875,7 @@ func (t *TransactionMetadata) String() string {
 		stringify.StructField("solidificationTime", t.SolidificationTime()),
 		stringify.StructField("finalized", t.Finalized()),
 		stringify.StructField("lazyBooked", t.LazyBooked()),
+		stringify.StructField("invalidReason", t.InvalidReason()),
 	)
 }
 
@@ -866,6 +899,7 @@ func (t *TransactionMetadata) ObjectStorageValue() []byte {
 		WriteTime(t.SolidificationTime()).
 		WriteBool(t.Finalized()).
 		WriteBool(t.LazyBooked()).
+		Write(t.InvalidReason()).
 		Bytes()
 }
 
@@ -0,0 +1,152 @@
+package ledgerstate
+
+import (
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region TransactionValidation ////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionValidation contains the results of all checks that the UTXODAG performs when a Transaction is booked. It
+// allows to detect problems of a Transaction before it is issued.
+type TransactionValidation struct {
+	// TransactionID contains the identifier of the validated Transaction.
+	TransactionID TransactionID
+
+	// Inputs contains the results of the checks of the individual Inputs (in the order of the Inputs).
+	Inputs []*InputValidation
+
+	// InputsSolid is true if all Outputs that are referenced by the Inputs are known.
+	InputsSolid bool
+
+	// BalancesValid is true if the consumed and created balances are equal.
+	BalancesValid bool
+
+	// UnlockBlocksValid is true if all Inputs are unlocked by their UnlockBlocks.
+	UnlockBlocksValid bool
+
+	// AliasOutputsValid is true if the created AliasOutputs are valid transitions of the consumed AliasOutputs.
+	AliasOutputsValid bool
+
+	// PastConeValid is true if none of the Inputs is in the past cone of another Input.
+	PastConeValid bool
+
+	// BranchesConflicting is true if the Inputs are booked into conflicting Branches.
+	BranchesConflicting bool
+
+	// DustProtectionError contains the violation of the dust protection rules (nil if the rules are satisfied).
+	DustProtectionError error
+
+	// Conflicts contains the existing Transactions that consume the same Outputs.
+	Conflicts []*TransactionConflict
+}
+
+// Valid returns true if the Transaction passes all checks and would neither be booked into the InvalidBranch nor into a
+// rejected or disliked Branch.
+func (t *TransactionValidation) Valid() bool {
+	if !t.InputsSolid || !t.BalancesValid || !t.UnlockBlocksValid || !t.AliasOutputsValid || !t.PastConeValid || t.BranchesConflicting || t.DustProtectionError != nil {
+		return false
+	}
+
+	for _, input := range t.Inputs {
+		if input.BranchID == InvalidBranchID || input.BranchInclusionState == Rejected || !input.BranchMonotonicallyLiked {
+			return false
+		}
+	}
+
+	for _, conflict := range t.Conflicts {
+		if conflict.InclusionState == Confirmed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a human readable version of the TransactionValidation.
+func (t *TransactionValidation) String() string {
+	return stringify.Struct("TransactionValidation",
+		stringify.StructField("transactionID", t.TransactionID),
+		stringify.StructField("inputs", t.Inputs),
+		stringify.StructField("inputsSolid", t.InputsSolid),
+		stringify.StructField("balancesValid", t.BalancesValid),
+		stringify.StructField("unlockBlocksValid", t.UnlockBlocksValid),
+		stringify.StructField("aliasOutputsValid", t.AliasOutputsValid),
+		stringify.StructField("pastConeValid", t.PastConeValid),
+		stringify.StructField("branchesConflicting", t.BranchesConflicting),
+		stringify.StructField("dustProtectionError", t.DustProtectionError),
+		stringify.StructField("conflicts", t.Conflicts),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region InputValidation //////////////////////////////////////////////////////////////////////////////////////////////
+
+// InputValidation contains the results of the checks of a single Input of a Transaction.
+type InputValidation struct {
+	// OutputID contains the identifier of the referenced Output.
+	OutputID OutputID
+
+	// Solid is true if the referenced Output is known.
+	Solid bool
+
+	// BranchID contains the Branch that the referenced Output is booked in.
+	BranchID BranchID
+
+	// BranchInclusionState contains the InclusionState of the Branch of the referenced Output.
+	BranchInclusionState InclusionState
+
+	// BranchMonotonicallyLiked is true if the Branch of the referenced Output and all of its ancestors are liked.
+	BranchMonotonicallyLiked bool
+
+	// ConsumerCount contains the number of Transactions that already consume the referenced Output.
+	ConsumerCount int
+
+	// UnlockBlockValid is true if the referenced Output is unlocked by the corresponding UnlockBlock.
+	UnlockBlockValid bool
+}
+
+// String returns a human readable version of the InputValidation.
+func (i *InputValidation) String() string {
+	return stringify.Struct("InputValidation",
+		stringify.StructField("outputID", i.OutputID),
+		stringify.StructField("solid", i.Solid),
+		stringify.StructField("branchID", i.BranchID),
+		stringify.StructField("branchInclusionState", i.BranchInclusionState),
+		stringify.StructField("branchMonotonicallyLiked", i.BranchMonotonicallyLiked),
+		stringify.StructField("consumerCount", i.ConsumerCount),
+		stringify.StructField("unlockBlockValid", i.UnlockBlockValid),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionConflict //////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionConflict represents an existing Transaction that consumes one of the Outputs that are consumed by the
+// validated Transaction.
+type TransactionConflict struct {
+	// OutputID contains the identifier of the Output that is consumed by both Transactions.
+	OutputID OutputID
+
+	// TransactionID contains the identifier of the conflicting Transaction.
+	TransactionID TransactionID
+
+	// BranchID contains the Branch that the conflicting Transaction is booked in.
+	BranchID BranchID
+
+	// InclusionState contains the InclusionState of the conflicting Transaction.
+	InclusionState InclusionState
+}
+
+// String returns a human readable version of the TransactionConflict.
+func (t *TransactionConflict) String() string {
+	return stringify.Struct("TransactionConflict",
+		stringify.StructField("outputID", t.OutputID),
+		stringify.StructField("transactionID", t.TransactionID),
+		stringify.StructField("branchID", t.BranchID),
+		stringify.StructField("inclusionState", t.InclusionState),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,63 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTXODAG_ValidateTransaction(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+
+	tx1 := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	validation, err := utxoDAG.ValidateTransaction(tx1)
+	require.NoError(t, err)
+	assert.True(t, validation.Valid())
+	assert.Equal(t, tx1.ID(), validation.TransactionID)
+	require.Len(t, validation.Inputs, 1)
+	assert.True(t, validation.Inputs[0].Solid)
+	assert.True(t, validation.Inputs[0].UnlockBlockValid)
+	assert.Equal(t, MasterBranchID, validation.Inputs[0].BranchID)
+	assert.Equal(t, 0, validation.Inputs[0].ConsumerCount)
+	assert.Empty(t, validation.Conflicts)
+
+	// validating does not book the Transaction
+	assert.False(t, utxoDAG.TransactionMetadata(tx1.ID()).Consume(func(*TransactionMetadata) {}))
+
+	_, err = utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+
+	// a double spend reports the existing consumer
+	tx2 := buildTransaction(utxoDAG, wallets[0], wallets[2], []*SigLockedSingleOutput{input})
+	validation, err = utxoDAG.ValidateTransaction(tx2)
+	require.NoError(t, err)
+	assert.True(t, validation.Valid())
+	assert.Equal(t, 1, validation.Inputs[0].ConsumerCount)
+	require.Len(t, validation.Conflicts, 1)
+	assert.Equal(t, input.ID(), validation.Conflicts[0].OutputID)
+	assert.Equal(t, tx1.ID(), validation.Conflicts[0].TransactionID)
+	assert.Equal(t, MasterBranchID, validation.Conflicts[0].BranchID)
+	assert.Equal(t, Pending, validation.Conflicts[0].InclusionState)
+
+	// an Input that is not unlocked by its owner
+	tx3 := buildTransaction(utxoDAG, wallets[1], wallets[2], []*SigLockedSingleOutput{generateOutput(utxoDAG, wallets[0].address, 2)})
+	validation, err = utxoDAG.ValidateTransaction(tx3)
+	require.NoError(t, err)
+	assert.False(t, validation.Valid())
+	assert.False(t, validation.UnlockBlocksValid)
+	assert.False(t, validation.Inputs[0].UnlockBlockValid)
+	assert.True(t, validation.BalancesValid)
+
+	// an Input that references an unknown Output
+	tx4 := buildTransaction(utxoDAG, wallets[0], wallets[2], []*SigLockedSingleOutput{NewSigLockedSingleOutput(100, wallets[0].address).SetID(NewOutputID(GenesisTransactionID, 3)).(*SigLockedSingleOutput)})
+	validation, err = utxoDAG.ValidateTransaction(tx4)
+	require.NoError(t, err)
+	assert.False(t, validation.Valid())
+	assert.False(t, validation.InputsSolid)
+	assert.False(t, validation.Inputs[0].Solid)
+}
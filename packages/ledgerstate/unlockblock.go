@@ -1,11 +1,13 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"strconv"
 
 	"github.com/iotaledger/hive.go/bytesfilter"
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/stringify"
 	"golang.org/x/xerrors"
@@ -19,6 +21,12 @@ const (
 
 	// ReferenceUnlockBlockType represents the type of a ReferenceUnlockBlock.
 	ReferenceUnlockBlockType
+
+	// AliasUnlockBlockType represents the type of an AliasUnlockBlock.
+	AliasUnlockBlockType
+
+	// MultiSignatureUnlockBlockType represents the type of a MultiSignatureUnlockBlock.
+	MultiSignatureUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -30,6 +38,8 @@ func (a UnlockBlockType) String() string {
 	return [...]string{
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
+		"AliasUnlockBlockType",
+		"MultiSignatureUnlockBlockType",
 	}[a]
 }
 
@@ -82,6 +92,16 @@ func UnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlo
 			err = xerrors.Errorf("failed to parse ReferenceUnlockBlock from MarshalUtil: %w", err)
 			return
 		}
+	case AliasUnlockBlockType:
+		if unlockBlock, err = AliasUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
+	case MultiSignatureUnlockBlockType:
+		if unlockBlock, err = MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse MultiSignatureUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
 	default:
 		err = xerrors.Errorf("unsupported UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
 		return
@@ -133,7 +153,7 @@ func UnlockBlocksFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBl
 			return
 		}
 
-		if unlockBlock.Type() != ReferenceUnlockBlockType && !seenUnlockBlocks.Add(unlockBlockBytes) {
+		if (unlockBlock.Type() == SignatureUnlockBlockType || unlockBlock.Type() == MultiSignatureUnlockBlockType) && !seenUnlockBlocks.Add(unlockBlockBytes) {
 			err = xerrors.Errorf("duplicate UnlockBlock detected at index %d: %w", i, cerrors.ErrParseBytesFailed)
 			return
 		}
@@ -316,3 +336,254 @@ func (r *ReferenceUnlockBlock) String() string {
 var _ UnlockBlock = &ReferenceUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasUnlockBlock /////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasUnlockBlock defines an UnlockBlock which unlocks an Output that is owned by an AliasAddress. It references the
+// input of the same Transaction that consumes the AliasOutput of the chain (which needs to be unlocked on its own).
+type AliasUnlockBlock struct {
+	referencedIndex uint16
+}
+
+// NewAliasUnlockBlock is the constructor for AliasUnlockBlocks.
+func NewAliasUnlockBlock(referencedIndex uint16) *AliasUnlockBlock {
+	return &AliasUnlockBlock{
+		referencedIndex: referencedIndex,
+	}
+}
+
+// AliasUnlockBlockFromBytes unmarshals an AliasUnlockBlock from a sequence of bytes.
+func AliasUnlockBlockFromBytes(bytes []byte) (unlockBlock *AliasUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = AliasUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasUnlockBlockFromMarshalUtil unmarshals an AliasUnlockBlock using a MarshalUtil (for easier unmarshaling).
+func AliasUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *AliasUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != AliasUnlockBlockType {
+		err = xerrors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &AliasUnlockBlock{}
+	if unlockBlock.referencedIndex, err = marshalUtil.ReadUint16(); err != nil {
+		err = xerrors.Errorf("failed to parse referencedIndex (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	return
+}
+
+// AliasInputIndex returns the index of the input that consumes the AliasOutput of the chain.
+func (a *AliasUnlockBlock) AliasInputIndex() uint16 {
+	return a.referencedIndex
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (a *AliasUnlockBlock) Type() UnlockBlockType {
+	return AliasUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (a *AliasUnlockBlock) Bytes() []byte {
+	return marshalutil.New(1 + marshalutil.Uint16Size).
+		WriteByte(byte(AliasUnlockBlockType)).
+		WriteUint16(a.referencedIndex).
+		Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (a *AliasUnlockBlock) String() string {
+	return stringify.Struct("AliasUnlockBlock",
+		stringify.StructField("referencedIndex", int(a.referencedIndex)),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &AliasUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultiSignatureUnlockBlock ////////////////////////////////////////////////////////////////////////////////////
+
+// MultiSignatureUnlockBlock represents an UnlockBlock that unlocks a ThresholdAddress. It reveals the threshold and the
+// sorted set of public keys that the Address commits to and contains the ED25519Signatures of a subset of them.
+type MultiSignatureUnlockBlock struct {
+	threshold  uint8
+	publicKeys []ed25519.PublicKey
+	signatures []*ED25519Signature
+}
+
+// NewMultiSignatureUnlockBlock is the constructor for MultiSignatureUnlockBlocks.
+func NewMultiSignatureUnlockBlock(threshold uint8, publicKeys []ed25519.PublicKey, signatures ...*ED25519Signature) (unlockBlock *MultiSignatureUnlockBlock, err error) {
+	sortedPublicKeys, err := sortThresholdPublicKeys(threshold, publicKeys)
+	if err != nil {
+		return
+	}
+	if len(signatures) > len(publicKeys) {
+		err = xerrors.Errorf("amount of signatures (%d) exceeds the amount of public keys (%d): %w", len(signatures), len(publicKeys), cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &MultiSignatureUnlockBlock{
+		threshold:  threshold,
+		publicKeys: sortedPublicKeys,
+		signatures: signatures,
+	}
+
+	return
+}
+
+// MultiSignatureUnlockBlockFromBytes unmarshals a MultiSignatureUnlockBlock from a sequence of bytes.
+func MultiSignatureUnlockBlockFromBytes(bytes []byte) (unlockBlock *MultiSignatureUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MultiSignatureUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MultiSignatureUnlockBlockFromMarshalUtil unmarshals a MultiSignatureUnlockBlock using a MarshalUtil (for easier
+// unmarshaling).
+func MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *MultiSignatureUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != MultiSignatureUnlockBlockType {
+		err = xerrors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	threshold, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse threshold (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	publicKeysCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse public keys count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	publicKeys := make([]ed25519.PublicKey, publicKeysCount)
+	for i := range publicKeys {
+		if publicKeys[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse public key (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if i > 0 && bytes.Compare(publicKeys[i-1][:], publicKeys[i][:]) >= 0 {
+			err = xerrors.Errorf("public keys need to be sorted and unique: %w", cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	signaturesCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse signatures count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	signatures := make([]*ED25519Signature, signaturesCount)
+	for i := range signatures {
+		if signatures[i], err = ED25519SignatureFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse ED25519Signature from MarshalUtil: %w", err)
+			return
+		}
+	}
+
+	if unlockBlock, err = NewMultiSignatureUnlockBlock(threshold, publicKeys, signatures...); err != nil {
+		err = xerrors.Errorf("failed to create MultiSignatureUnlockBlock: %w", err)
+		return
+	}
+
+	return
+}
+
+// AddressSignatureValid returns true if the UnlockBlock reveals the public keys of the given ThresholdAddress and
+// contains valid signatures of at least threshold of them.
+func (m *MultiSignatureUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	if address.Type() != ThresholdAddressType || !bytes.Equal(thresholdAddressDigest(m.threshold, m.publicKeys), address.Digest()) {
+		return false
+	}
+
+	requiredSigners := make(map[ed25519.PublicKey]bool, len(m.publicKeys))
+	for _, publicKey := range m.publicKeys {
+		requiredSigners[publicKey] = false
+	}
+
+	validSignatures := 0
+	for _, signature := range m.signatures {
+		signed, required := requiredSigners[signature.PublicKey]
+		if !required || signed || !signature.SignatureValid(signedData) {
+			continue
+		}
+
+		requiredSigners[signature.PublicKey] = true
+		validSignatures++
+	}
+
+	return validSignatures >= int(m.threshold)
+}
+
+// Threshold returns the amount of signatures that are required to unlock the ThresholdAddress.
+func (m *MultiSignatureUnlockBlock) Threshold() uint8 {
+	return m.threshold
+}
+
+// PublicKeys returns the sorted set of public keys that the ThresholdAddress commits to.
+func (m *MultiSignatureUnlockBlock) PublicKeys() []ed25519.PublicKey {
+	return m.publicKeys
+}
+
+// Signatures returns the signatures that are contained in the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Signatures() []*ED25519Signature {
+	return m.signatures
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Type() UnlockBlockType {
+	return MultiSignatureUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(MultiSignatureUnlockBlockType)).
+		WriteUint8(m.threshold).
+		WriteUint8(uint8(len(m.publicKeys)))
+	for _, publicKey := range m.publicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+	marshalUtil.WriteUint8(uint8(len(m.signatures)))
+	for _, signature := range m.signatures {
+		marshalUtil.WriteBytes(signature.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) String() string {
+	return stringify.Struct("MultiSignatureUnlockBlock",
+		stringify.StructField("threshold", m.threshold),
+		stringify.StructField("publicKeys", m.publicKeys),
+		stringify.StructField("signatures", m.signatures),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &MultiSignatureUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
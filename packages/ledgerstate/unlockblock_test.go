@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnlockBlockFromMarshalUtil(t *testing.T) {
@@ -15,6 +16,8 @@ func TestUnlockBlockFromMarshalUtil(t *testing.T) {
 		unlockBlocks := UnlockBlocks{
 			NewSignatureUnlockBlock(NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("testdata")))),
 			NewReferenceUnlockBlock(0),
+			NewAliasUnlockBlock(0),
+			NewAliasUnlockBlock(0),
 		}
 		marshaledUnlockBlocks := unlockBlocks.Bytes()
 		parsedUnlockBlocks, consumedBytes, err := UnlockBlocksFromBytes(marshaledUnlockBlocks)
@@ -34,3 +37,48 @@ func TestUnlockBlockFromMarshalUtil(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestMultiSignatureUnlockBlock(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	publicKeys := []ed25519.PublicKey{keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey}
+	address, err := NewThresholdAddress(2, publicKeys)
+	require.NoError(t, err)
+
+	data := []byte("testdata")
+	sign := func(keyPair ed25519.KeyPair) *ED25519Signature {
+		return NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(data))
+	}
+
+	// test marshaling
+	unlockBlock, err := NewMultiSignatureUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[2]))
+	require.NoError(t, err)
+	parsedUnlockBlock, consumedBytes, err := UnlockBlockFromBytes(unlockBlock.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(unlockBlock.Bytes()), consumedBytes)
+	assert.Equal(t, unlockBlock, parsedUnlockBlock)
+
+	// test reaching the threshold
+	assert.True(t, unlockBlock.AddressSignatureValid(address, data))
+	assert.False(t, unlockBlock.AddressSignatureValid(address, []byte("otherdata")))
+
+	// test missing the threshold
+	unlockBlock, err = NewMultiSignatureUnlockBlock(2, publicKeys, sign(keyPairs[1]))
+	require.NoError(t, err)
+	assert.False(t, unlockBlock.AddressSignatureValid(address, data))
+
+	// test counting the same signer twice
+	unlockBlock, err = NewMultiSignatureUnlockBlock(2, publicKeys, sign(keyPairs[1]), sign(keyPairs[1]))
+	require.NoError(t, err)
+	assert.False(t, unlockBlock.AddressSignatureValid(address, data))
+
+	// test signatures of keys that the address does not commit to
+	otherKeyPair := ed25519.GenerateKeyPair()
+	unlockBlock, err = NewMultiSignatureUnlockBlock(2, publicKeys, sign(keyPairs[1]), sign(otherKeyPair))
+	require.NoError(t, err)
+	assert.False(t, unlockBlock.AddressSignatureValid(address, data))
+
+	// test revealing a different set of public keys
+	unlockBlock, err = NewMultiSignatureUnlockBlock(1, publicKeys, sign(keyPairs[0]), sign(keyPairs[1]))
+	require.NoError(t, err)
+	assert.False(t, unlockBlock.AddressSignatureValid(address, data))
+}
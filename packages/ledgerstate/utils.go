@@ -2,6 +2,10 @@ package ledgerstate
 
 import (
 	"math"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/xerrors"
 )
 
 // TransactionBalancesValid is an internal utility function that checks if the sum of the balance changes equals to 0.
@@ -49,15 +53,97 @@ func TransactionBalancesValid(inputs Outputs, outputs Outputs) (valid bool) {
 
 // UnlockBlocksValid is an internal utility function that checks if the UnlockBlocks are matching the referenced Inputs.
 func UnlockBlocksValid(inputs Outputs, transaction *Transaction) (valid bool) {
+	for i := range inputs {
+		if !UnlockBlockValid(inputs, transaction, i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnlockBlockValid is an internal utility function that checks if the UnlockBlock at the given index is unlocking the
+// referenced Input.
+func UnlockBlockValid(inputs Outputs, transaction *Transaction, index int) (valid bool) {
 	unlockBlocks := transaction.UnlockBlocks()
-	for i, input := range inputs {
-		currentUnlockBlock := unlockBlocks[i]
-		if currentUnlockBlock.Type() == ReferenceUnlockBlockType {
-			currentUnlockBlock = unlockBlocks[unlockBlocks[i].(*ReferenceUnlockBlock).ReferencedIndex()]
+	currentUnlockBlock := unlockBlocks[index]
+	if currentUnlockBlock.Type() == ReferenceUnlockBlockType {
+		currentUnlockBlock = unlockBlocks[unlockBlocks[index].(*ReferenceUnlockBlock).ReferencedIndex()]
+	}
+
+	// aliases must not unlock each other in a cycle
+	if aliasUnlockCycle(unlockBlocks, index) {
+		return false
+	}
+
+	unlockValid, unlockErr := inputs[index].UnlockValid(transaction, currentUnlockBlock, inputs)
+
+	return unlockValid && unlockErr == nil
+}
+
+// aliasUnlockCycle is an internal utility function that follows the AliasUnlockBlocks starting at the given index and
+// returns true if they loop back to an input that was visited before (or reference an input that does not exist).
+func aliasUnlockCycle(unlockBlocks UnlockBlocks, index int) (cycle bool) {
+	visited := make(map[int]types.Empty)
+	for {
+		if index < 0 || index >= len(unlockBlocks) {
+			return true
+		}
+		if _, seen := visited[index]; seen {
+			return true
+		}
+		visited[index] = types.Void
+
+		unlockBlock := unlockBlocks[index]
+		if referenceUnlockBlock, isReference := unlockBlock.(*ReferenceUnlockBlock); isReference {
+			if int(referenceUnlockBlock.ReferencedIndex()) >= len(unlockBlocks) {
+				return true
+			}
+			unlockBlock = unlockBlocks[referenceUnlockBlock.ReferencedIndex()]
 		}
 
-		unlockValid, unlockErr := input.UnlockValid(transaction, currentUnlockBlock)
-		if !unlockValid || unlockErr != nil {
+		aliasUnlockBlock, isAliasUnlockBlock := unlockBlock.(*AliasUnlockBlock)
+		if !isAliasUnlockBlock {
+			return false
+		}
+		index = int(aliasUnlockBlock.AliasInputIndex())
+	}
+}
+
+// AliasOutputsValid is an internal utility function that checks if the AliasOutputs that are created by a Transaction
+// are valid transitions of the chains of the consumed AliasOutputs.
+func AliasOutputsValid(inputs Outputs, outputs Outputs) (valid bool) {
+	consumedAliases := make(map[[AddressLength]byte]*AliasOutput)
+	for _, input := range inputs {
+		if aliasOutput, isAliasOutput := input.(*AliasOutput); isAliasOutput {
+			consumedAliases[aliasOutput.AliasAddress().Array()] = aliasOutput
+		}
+	}
+
+	continuedAliases := make(map[[AddressLength]byte]types.Empty)
+	for _, output := range outputs {
+		aliasOutput, isAliasOutput := output.(*AliasOutput)
+		if !isAliasOutput {
+			continue
+		}
+
+		// the color of minted coins is not replaced in AliasOutputs
+		if _, mintsCoins := aliasOutput.Balances().Get(ColorMint); mintsCoins {
+			return false
+		}
+
+		if aliasOutput.IsOrigin() {
+			continue
+		}
+
+		aliasAddress := aliasOutput.AliasAddress().Array()
+		if _, continued := continuedAliases[aliasAddress]; continued {
+			return false
+		}
+		continuedAliases[aliasAddress] = types.Void
+
+		previousAliasOutput, consumed := consumedAliases[aliasAddress]
+		if !consumed || !previousAliasOutput.transitionValid(aliasOutput) {
 			return false
 		}
 	}
@@ -65,6 +151,29 @@ func UnlockBlocksValid(inputs Outputs, transaction *Transaction) (valid bool) {
 	return true
 }
 
+// addressUnlockValid is an internal utility function that checks if the given UnlockBlock unlocks the given Address.
+// AliasAddresses are unlocked by an AliasUnlockBlock that references the consumed AliasOutput of the chain.
+func addressUnlockValid(address Address, tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	switch typedUnlockBlock := unlockBlock.(type) {
+	case *SignatureUnlockBlock:
+		unlockValid = typedUnlockBlock.AddressSignatureValid(address, tx.Essence().Bytes())
+	case *MultiSignatureUnlockBlock:
+		unlockValid = typedUnlockBlock.AddressSignatureValid(address, tx.Essence().Bytes())
+	case *AliasUnlockBlock:
+		aliasAddress, isAliasAddress := address.(*AliasAddress)
+		if !isAliasAddress || int(typedUnlockBlock.AliasInputIndex()) >= len(inputs) {
+			return
+		}
+
+		aliasOutput, isAliasOutput := inputs[typedUnlockBlock.AliasInputIndex()].(*AliasOutput)
+		unlockValid = isAliasOutput && aliasOutput.AliasAddress().Array() == aliasAddress.Array()
+	default:
+		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
+	}
+
+	return
+}
+
 // SafeAddUint64 adds two uint64 values. It returns the result and a valid flag that indicates whether the addition is
 // valid without causing an overflow.
 func SafeAddUint64(a uint64, b uint64) (result uint64, valid bool) {
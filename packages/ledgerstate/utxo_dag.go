@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
@@ -34,16 +35,26 @@ type UTXODAG struct {
 	outputMetadataStorage       *objectstorage.ObjectStorage
 	consumerStorage             *objectstorage.ObjectStorage
 	addressOutputMappingStorage *objectstorage.ObjectStorage
+	addressHistory              *addressHistory
+	colorMetadataStorage        *objectstorage.ObjectStorage
+	colorSupplyChangeStorage    *objectstorage.ObjectStorage
+	transactionTombstones       *tombstones
+	stateCommitment             *StateCommitment
+	dustBalances                *dustBalances
+	colorSupplies               *colorSupplies
+	snapshotOutputPledges       kvstore.KVStore
 	branchDAG                   *BranchDAG
+	options                     *UTXODAGOptions
 	shutdownOnce                sync.Once
 }
 
 // NewUTXODAG create a new UTXODAG from the given details.
-func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG) (utxoDAG *UTXODAG) {
+func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG, options ...UTXODAGOption) (utxoDAG *UTXODAG) {
 	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
 	utxoDAG = &UTXODAG{
 		Events: &UTXODAGEvents{
 			TransactionBranchIDUpdated: events.NewEvent(transactionIDEventHandler),
+			Error:                      events.NewEvent(events.ErrorCaller),
 		},
 		transactionStorage:          osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, transactionStorageOptions...),
 		transactionMetadataStorage:  osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, transactionMetadataStorageOptions...),
@@ -51,7 +62,19 @@ func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG) (utxoDAG *UTXODAG)
 		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, outputMetadataStorageOptions...),
 		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, consumerStorageOptions...),
 		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, addressOutputMappingStorageOptions...),
+		addressHistory:              newAddressHistory(store.WithRealm([]byte{database.PrefixLedgerState, PrefixAddressTransactionMappingStorage})),
+		colorMetadataStorage:        osFactory.New(PrefixColorMetadataStorage, ColorMetadataFromObjectStorage, colorMetadataStorageOptions...),
+		colorSupplyChangeStorage:    osFactory.New(PrefixColorSupplyChangeStorage, ColorSupplyChangeFromObjectStorage, colorSupplyChangeStorageOptions...),
+		transactionTombstones:       newTombstones(store.WithRealm([]byte{database.PrefixLedgerState, PrefixTransactionTombstoneStorage})),
+		stateCommitment:             newStateCommitment(store.WithRealm([]byte{database.PrefixLedgerState, PrefixStateCommitmentStorage})),
+		dustBalances:                newDustBalances(store.WithRealm([]byte{database.PrefixLedgerState, PrefixDustBalanceStorage})),
+		colorSupplies:               newColorSupplies(store.WithRealm([]byte{database.PrefixLedgerState, PrefixColorSupplyStorage})),
+		snapshotOutputPledges:       store.WithRealm([]byte{database.PrefixLedgerState, PrefixSnapshotOutputPledgeStorage}),
 		branchDAG:                   branchDAG,
+		options:                     &UTXODAGOptions{},
+	}
+	for _, option := range options {
+		option(utxoDAG.options)
 	}
 	return
 }
@@ -65,6 +88,8 @@ func (u *UTXODAG) Shutdown() {
 		u.outputMetadataStorage.Shutdown()
 		u.consumerStorage.Shutdown()
 		u.addressOutputMappingStorage.Shutdown()
+		u.colorMetadataStorage.Shutdown()
+		u.colorSupplyChangeStorage.Shutdown()
 	})
 }
 
@@ -75,7 +100,16 @@ func (u *UTXODAG) CheckTransaction(transaction *Transaction) (valid bool, err er
 	consumedOutputs := cachedConsumedOutputs.Unwrap()
 
 	// perform cheap checks
+	if u.TransactionPruned(transaction.ID()) {
+		err = xerrors.Errorf("transaction with %s was pruned after being rejected: %w", transaction.ID(), ErrTransactionInvalid)
+		return
+	}
 	if !u.allOutputsExist(consumedOutputs) {
+		if u.inputsPruned(transaction) {
+			err = xerrors.Errorf("transaction consumes outputs of a pruned transaction: %w", ErrTransactionInvalid)
+			return
+		}
+
 		err = xerrors.Errorf("not all consumedOutputs of transaction are solid: %w", ErrTransactionNotSolid)
 		return
 	}
@@ -87,13 +121,115 @@ func (u *UTXODAG) CheckTransaction(transaction *Transaction) (valid bool, err er
 		err = xerrors.Errorf("spending of referenced consumedOutputs is not authorized: %w", ErrTransactionInvalid)
 		return
 	}
+	if !AliasOutputsValid(consumedOutputs, transaction.Essence().Outputs()) {
+		err = xerrors.Errorf("created AliasOutputs are not valid transitions of their chains: %w", ErrTransactionInvalid)
+		return
+	}
 
 	valid = true
 	return
 }
 
+// CheckDustProtection checks if the given Transaction complies with the dust protection rules. Transactions that
+// violate the minimum deposit or create dust Outputs without creating the dust allowance for them are booked into the
+// InvalidBranch. The dust allowance of the confirmed unspent Outputs of an Address depends on the ledger state of the
+// node, so it is only used as an additional local filter for the Transactions that are issued by the node.
+func (u *UTXODAG) CheckDustProtection(transaction *Transaction) (err error) {
+	if err = u.checkTransactionDust(transaction); err != nil {
+		return err
+	}
+
+	cachedConsumedOutputs := u.ConsumedOutputs(transaction)
+	defer cachedConsumedOutputs.Release()
+
+	return u.checkDustAllowance(transaction, cachedConsumedOutputs.Unwrap())
+}
+
+// ValidateTransaction runs the given Transaction through all checks that are performed when it is booked without
+// modifying the ledger state and returns the detailed results.
+func (u *UTXODAG) ValidateTransaction(transaction *Transaction) (validation *TransactionValidation, err error) {
+	cachedConsumedOutputs := u.ConsumedOutputs(transaction)
+	defer cachedConsumedOutputs.Release()
+	consumedOutputs := cachedConsumedOutputs.Unwrap()
+
+	cachedInputsMetadata := u.transactionInputsMetadata(transaction)
+	defer cachedInputsMetadata.Release()
+	inputsMetadata := cachedInputsMetadata.Unwrap()
+
+	validation = &TransactionValidation{
+		TransactionID: transaction.ID(),
+		Inputs:        make([]*InputValidation, len(consumedOutputs)),
+		InputsSolid:   u.allOutputsExist(consumedOutputs),
+	}
+
+	for i, input := range transaction.Essence().Inputs() {
+		inputValidation := &InputValidation{
+			OutputID: input.(*UTXOInput).ReferencedOutputID(),
+		}
+		validation.Inputs[i] = inputValidation
+
+		if typeutils.IsInterfaceNil(consumedOutputs[i]) || inputsMetadata[i] == nil {
+			continue
+		}
+
+		inputValidation.Solid = true
+		inputValidation.BranchID = inputsMetadata[i].BranchID()
+		inputValidation.ConsumerCount = inputsMetadata[i].ConsumerCount()
+		inputValidation.UnlockBlockValid = u.allOutputsExist(consumedOutputs) && UnlockBlockValid(consumedOutputs, transaction, i)
+		u.branchDAG.Branch(inputValidation.BranchID).Consume(func(branch Branch) {
+			inputValidation.BranchInclusionState = branch.InclusionState()
+			inputValidation.BranchMonotonicallyLiked = branch.MonotonicallyLiked()
+		})
+
+		conflictingTransactionIDs := make([]TransactionID, 0)
+		u.Consumers(inputValidation.OutputID).Consume(func(consumer *Consumer) {
+			if consumer.TransactionID() != transaction.ID() {
+				conflictingTransactionIDs = append(conflictingTransactionIDs, consumer.TransactionID())
+			}
+		})
+		for _, conflictingTransactionID := range conflictingTransactionIDs {
+			conflict := &TransactionConflict{
+				OutputID:      inputValidation.OutputID,
+				TransactionID: conflictingTransactionID,
+			}
+			u.TransactionMetadata(conflictingTransactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+				conflict.BranchID = transactionMetadata.BranchID()
+			})
+			if conflict.InclusionState, err = u.InclusionState(conflictingTransactionID); err != nil {
+				err = xerrors.Errorf("failed to determine InclusionState of Transaction with %s: %w", conflictingTransactionID, err)
+				return
+			}
+			validation.Conflicts = append(validation.Conflicts, conflict)
+		}
+	}
+
+	// the remaining checks require all consumed Outputs to be known
+	if !validation.InputsSolid {
+		return
+	}
+
+	validation.BalancesValid = TransactionBalancesValid(consumedOutputs, transaction.Essence().Outputs())
+	validation.UnlockBlocksValid = UnlockBlocksValid(consumedOutputs, transaction)
+	validation.AliasOutputsValid = AliasOutputsValid(consumedOutputs, transaction.Essence().Outputs())
+	validation.PastConeValid = u.consumedOutputsPastConeValid(consumedOutputs, inputsMetadata)
+	if validation.DustProtectionError = u.checkTransactionDust(transaction); validation.DustProtectionError == nil {
+		validation.DustProtectionError = u.checkDustAllowance(transaction, consumedOutputs)
+	}
+	if validation.BranchesConflicting, _, _, err = u.determineBookingDetails(inputsMetadata); err != nil {
+		err = xerrors.Errorf("failed to determine booking details of Transaction with %s: %w", transaction.ID(), err)
+		return
+	}
+
+	return
+}
+
 // BookTransaction books a Transaction into the ledger state.
 func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch BranchID, err error) {
+	if u.TransactionPruned(transaction.ID()) {
+		err = xerrors.Errorf("transaction with %s was pruned after being rejected: %w", transaction.ID(), ErrTransactionInvalid)
+		return
+	}
+
 	cachedConsumedOutputs := u.ConsumedOutputs(transaction)
 	defer cachedConsumedOutputs.Release()
 	consumedOutputs := cachedConsumedOutputs.Unwrap()
@@ -130,7 +266,7 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 
 	// check if Transaction is attaching to something invalid
 	if u.inputsInInvalidBranch(inputsMetadata) {
-		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata)
+		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata, InvalidReasonInputsInvalid)
 		targetBranch = InvalidBranchID
 		return
 	}
@@ -153,7 +289,14 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 
 	// mark transaction as "permanently rejected"
 	if !u.consumedOutputsPastConeValid(consumedOutputs, inputsMetadata) {
-		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata)
+		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata, InvalidReasonPastConeInvalid)
+		targetBranch = InvalidBranchID
+		return
+	}
+
+	// mark transaction as "permanently rejected" if it bloats the ledger state with dust
+	if u.checkTransactionDust(transaction) != nil {
+		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata, InvalidReasonDustProtection)
 		targetBranch = InvalidBranchID
 		return
 	}
@@ -167,7 +310,7 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 
 	// are branches of inputs conflicting
 	if branchesOfInputsConflicting {
-		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata)
+		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata, InvalidReasonBranchesConflicting)
 		targetBranch = InvalidBranchID
 		return
 	}
@@ -179,9 +322,68 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 		targetBranch = u.bookConflictingTransaction(transaction, transactionMetadata, inputsMetadata, normalizedBranchIDs, conflictingInputs.ByID())
 	}
 
+	u.storeColorSupplyChanges(transaction, consumedOutputs)
+
+	return
+}
+
+// PruneRejectedBranch removes a Rejected Branch and its future cone from the BranchDAG together with the Transactions
+// that created the pruned ConflictBranches and the Transactions and Outputs in their future cone. A tombstone is kept
+// for every pruned Transaction so late arriving attachments and spends of its Outputs are still rejected.
+func (u *UTXODAG) PruneRejectedBranch(branchID BranchID) (prunedTransactionIDs TransactionIDs, err error) {
+	prunedBranchIDs, err := u.branchDAG.PruneRejectedBranch(branchID)
+	if err != nil {
+		err = xerrors.Errorf("failed to prune Branch with %s: %w", branchID, err)
+		return
+	}
+
+	// determine the Transactions that created the pruned ConflictBranches
+	prunedTransactionIDs = make(TransactionIDs)
+	entryPoints := make([]OutputID, 0)
+	for prunedBranchID := range prunedBranchIDs {
+		transactionID := TransactionID(prunedBranchID)
+
+		createdConflictBranch := false
+		u.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+			createdConflictBranch = transactionMetadata.BranchID() == prunedBranchID
+		})
+		if !createdConflictBranch {
+			continue
+		}
+
+		prunedTransactionIDs[transactionID] = types.Void
+		entryPoints = append(entryPoints, u.createdOutputIDsOfTransaction(transactionID)...)
+	}
+
+	// every Transaction that spends the Outputs of a pruned Transaction can never become valid
+	u.walkFutureCone(entryPoints, func(transactionID TransactionID) (nextOutputsToVisit []OutputID) {
+		prunedTransactionIDs[transactionID] = types.Void
+
+		return u.createdOutputIDsOfTransaction(transactionID)
+	})
+
+	// the indexes have to be cleaned up first as they rely on the consumed Outputs of the Transactions
+	for transactionID := range prunedTransactionIDs {
+		if err = u.pruneTransactionIndexes(transactionID); err != nil {
+			err = xerrors.Errorf("failed to prune indexes of Transaction with %s: %w", transactionID, err)
+			return
+		}
+	}
+	for transactionID := range prunedTransactionIDs {
+		if err = u.pruneTransaction(transactionID); err != nil {
+			err = xerrors.Errorf("failed to prune Transaction with %s: %w", transactionID, err)
+			return
+		}
+	}
+
 	return
 }
 
+// TransactionPruned returns true if the Transaction with the given TransactionID was removed by PruneRejectedBranch.
+func (u *UTXODAG) TransactionPruned(transactionID TransactionID) (pruned bool) {
+	return u.transactionTombstones.contains(transactionID.Bytes())
+}
+
 // InclusionState returns the InclusionState of the Transaction with the given TransactionID which can either be
 // Pending, Confirmed or Rejected.
 func (u *UTXODAG) InclusionState(transactionID TransactionID) (inclusionState InclusionState, err error) {
@@ -189,6 +391,11 @@ func (u *UTXODAG) InclusionState(transactionID TransactionID) (inclusionState In
 	defer cachedTransactionMetadata.Release()
 	transactionMetadata := cachedTransactionMetadata.Unwrap()
 	if transactionMetadata == nil {
+		// Transactions are only pruned after their Branch has been rejected
+		if u.TransactionPruned(transactionID) {
+			return Rejected, nil
+		}
+
 		err = xerrors.Errorf("failed to load TransactionMetadata with %s: %w", transactionID, cerrors.ErrFatal)
 		return
 	}
@@ -197,6 +404,10 @@ func (u *UTXODAG) InclusionState(transactionID TransactionID) (inclusionState In
 	defer cachedBranch.Release()
 	branch := cachedBranch.Unwrap()
 	if branch == nil {
+		if u.branchDAG.BranchPruned(transactionMetadata.BranchID()) {
+			return Rejected, nil
+		}
+
 		err = xerrors.Errorf("failed to load Branch with %s: %w", transactionMetadata.BranchID(), cerrors.ErrFatal)
 		return
 	}
@@ -225,6 +436,15 @@ func (u *UTXODAG) TransactionMetadata(transactionID TransactionID) (cachedTransa
 	return &CachedTransactionMetadata{CachedObject: u.transactionMetadataStorage.Load(transactionID.Bytes())}
 }
 
+// ForEachTransaction iterates over all of the Transactions in the object storage and executes the consumer.
+func (u *UTXODAG) ForEachTransaction(consumer func(transaction *Transaction)) {
+	u.transactionStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedTransaction{CachedObject: cachedObject}).Consume(consumer)
+
+		return true
+	})
+}
+
 // Output retrieves the Output with the given OutputID from the object storage.
 func (u *UTXODAG) Output(outputID OutputID) (cachedOutput *CachedOutput) {
 	return &CachedOutput{CachedObject: u.outputStorage.Load(outputID.Bytes())}
@@ -247,11 +467,130 @@ func (u *UTXODAG) Consumers(outputID OutputID) (cachedConsumers CachedConsumers)
 	return
 }
 
+// StateCommitment returns the StateCommitment of the confirmed unspent Outputs.
+func (u *UTXODAG) StateCommitment() *StateCommitment {
+	return u.stateCommitment
+}
+
+// CommitConfirmedTransaction stages the given confirmed Transaction, so that it is applied to the StateCommitment when
+// the epoch that contains its timestamp is committed, and applies it to the dust balances of the affected Addresses and
+// to the supplies of the Colors that it mints or burns.
+func (u *UTXODAG) CommitConfirmedTransaction(transactionID TransactionID) (err error) {
+	if !u.Transaction(transactionID).Consume(func(transaction *Transaction) {
+		if err = u.stateCommitment.stageTransaction(transactionID, transaction.Essence().Timestamp()); err != nil {
+			return
+		}
+
+		cachedConsumedOutputs := u.ConsumedOutputs(transaction)
+		defer cachedConsumedOutputs.Release()
+		consumedOutputs := make(Outputs, 0, len(cachedConsumedOutputs))
+		for _, consumedOutput := range cachedConsumedOutputs.Unwrap() {
+			if consumedOutput != nil {
+				consumedOutputs = append(consumedOutputs, consumedOutput)
+			}
+		}
+
+		createdOutputs := make(Outputs, 0, len(transaction.Essence().Outputs()))
+		for index := range transaction.Essence().Outputs() {
+			outputID := NewOutputID(transactionID, uint16(index))
+			if !u.Output(outputID).Consume(func(output Output) {
+				createdOutputs = append(createdOutputs, output)
+			}) {
+				err = xerrors.Errorf("failed to load Output with %s: %w", outputID, cerrors.ErrFatal)
+				return
+			}
+		}
+
+		if err = u.dustBalances.applyTransaction(u.options.DustParams, transaction, consumedOutputs, createdOutputs); err != nil {
+			return
+		}
+
+		err = u.colorSupplies.applyTransaction(transaction, consumedOutputs)
+	}) {
+		return xerrors.Errorf("failed to load Transaction with %s: %w", transactionID, cerrors.ErrFatal)
+	}
+
+	return err
+}
+
+// CommitEpoch applies the staged Transactions with a timestamp before the end of the given epoch to the
+// StateCommitment and persists the resulting StateRoot as the EpochCommitment of the epoch. Transactions that are
+// confirmed after their epoch was committed are recorded as part of their own epoch and the EpochCommitments from that
+// epoch on are revised, so the committed StateRoots do not depend on the time at which the Transactions were confirmed.
+func (u *UTXODAG) CommitEpoch(epochIndex EpochIndex, epochDuration time.Duration) (epochCommitment *EpochCommitment, err error) {
+	if epochCommitment, err = u.stateCommitment.EpochCommitment(epochIndex); err == nil || !xerrors.Is(err, kvstore.ErrKeyNotFound) {
+		return
+	}
+
+	latestEpochCommitment, err := u.stateCommitment.LatestEpochCommitment()
+	if err != nil {
+		if !xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, err
+		}
+		latestEpochCommitment = nil
+	}
+	if latestEpochCommitment != nil && epochIndex < latestEpochCommitment.EpochIndex() {
+		return nil, xerrors.Errorf("epoch %d is older than the latest committed epoch %d: %w", epochIndex, latestEpochCommitment.EpochIndex(), cerrors.ErrFatal)
+	}
+
+	stagedTransactions, err := u.stateCommitment.pendingTransactions(epochIndex.EndTime(epochDuration))
+	if err != nil {
+		return nil, err
+	}
+	revisedEpochIndex := epochIndex
+	for _, stagedTransaction := range stagedTransactions {
+		transactionEpochIndex := epochIndex
+		if latestEpochCommitment != nil {
+			if timestampEpochIndex := EpochIndexFromTime(stagedTransaction.timestamp, epochDuration); timestampEpochIndex <= latestEpochCommitment.EpochIndex() {
+				transactionEpochIndex = timestampEpochIndex
+			}
+		}
+		if transactionEpochIndex < revisedEpochIndex {
+			revisedEpochIndex = transactionEpochIndex
+		}
+
+		if err = u.applyTransaction(stagedTransaction.transactionID, transactionEpochIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.stateCommitment.commitEpoch(epochIndex, revisedEpochIndex)
+}
+
+// applyTransaction applies the given staged Transaction to the StateCommitment by removing its consumed Outputs and
+// adding its created Outputs. The changes are recorded as part of the given epoch.
+func (u *UTXODAG) applyTransaction(transactionID TransactionID, epochIndex EpochIndex) (err error) {
+	if !u.Transaction(transactionID).Consume(func(transaction *Transaction) {
+		createdOutputs := make(Outputs, 0, len(transaction.Essence().Outputs()))
+		for index := range transaction.Essence().Outputs() {
+			outputID := NewOutputID(transactionID, uint16(index))
+			if !u.Output(outputID).Consume(func(output Output) {
+				createdOutputs = append(createdOutputs, output)
+			}) {
+				err = xerrors.Errorf("failed to load Output with %s: %w", outputID, cerrors.ErrFatal)
+				return
+			}
+		}
+
+		err = u.stateCommitment.commitTransaction(transaction, createdOutputs, func(outputID OutputID) (consumers []TransactionID) {
+			u.Consumers(outputID).Consume(func(consumer *Consumer) {
+				consumers = append(consumers, consumer.TransactionID())
+			})
+
+			return
+		}, epochIndex)
+	}) {
+		return xerrors.Errorf("failed to load Transaction with %s: %w", transactionID, cerrors.ErrFatal)
+	}
+
+	return err
+}
+
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
-func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBalances) {
+func (u *UTXODAG) LoadSnapshot(snapshot *Snapshot) {
 	index := uint16(0)
 	fmt.Println("Loading snapshot...")
-	for transactionID, addressBalance := range snapshot {
+	for transactionID, addressBalance := range snapshot.Transactions {
 		fmt.Println("TransactionID: ", transactionID.Base58())
 		for address, balance := range addressBalance {
 			fmt.Println("Address: ", address)
@@ -259,41 +598,176 @@ func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBa
 			fmt.Println("Balance: ", balance)
 			output := NewSigLockedColoredOutput(balance, address)
 			output.SetID(NewOutputID(transactionID, index))
-			cachedOutput, stored := u.outputStorage.StoreIfAbsent(output)
-			if stored {
-				cachedOutput.Release()
+			u.storeSnapshotOutput(output)
+
+			index++
+		}
+
+		u.storeSnapshotTransactionMetadata(transactionID)
+	}
+
+	for _, output := range snapshot.Outputs {
+		u.storeSnapshotOutput(output)
+		u.storeSnapshotTransactionMetadata(output.ID().TransactionID())
+	}
+}
+
+// Snapshot returns a Snapshot of the Outputs that were created by the Transactions that are accepted by the given
+// filter and that are not spent by any valid Transaction that is accepted by the filter. It is used to create the
+// ledger state of a local snapshot.
+func (u *UTXODAG) Snapshot(filter func(transactionID TransactionID) bool) (snapshot *Snapshot) {
+	snapshot = NewSnapshot()
+	u.forEachSnapshotOutput(filter, func(output Output) bool {
+		snapshot.Outputs = append(snapshot.Outputs, output.Clone())
+
+		return true
+	})
+
+	return
+}
+
+// WriteSnapshot streams the Outputs that would be part of the Snapshot with the given filter to the SnapshotWriter.
+// The Outputs are written with the OutputMetadata of the master branch, as the Branches of the UTXODAG are not part of
+// the snapshot.
+func (u *UTXODAG) WriteSnapshot(writer *SnapshotWriter, filter func(transactionID TransactionID) bool) (err error) {
+	u.forEachSnapshotOutput(filter, func(output Output) bool {
+		outputMetadata := newSnapshotOutputMetadata(output.ID())
+		u.OutputMetadata(output.ID()).Consume(func(storedOutputMetadata *OutputMetadata) {
+			outputMetadata = storedOutputMetadata
+		})
+
+		err = writer.WriteOutput(output, normalizedSnapshotOutputMetadata(outputMetadata), u.OutputPledge(output.ID()))
+
+		return err == nil
+	})
+
+	return
+}
+
+// LoadSnapshotOutput stores an Output that was read from a snapshot together with its OutputMetadata and the
+// OutputPledge of the Transaction that created it (nil if the snapshot does not contain OutputPledges). It is used to
+// load the ledger state from a SnapshotReader without holding it in memory.
+func (u *UTXODAG) LoadSnapshotOutput(output Output, outputMetadata *OutputMetadata, outputPledge *OutputPledge) {
+	u.storeSnapshotOutputWithMetadata(output, outputMetadata)
+	u.storeSnapshotTransactionMetadata(output.ID().TransactionID())
+	if outputPledge != nil {
+		if err := u.snapshotOutputPledges.Set(output.ID().Bytes(), outputPledge.Bytes()); err != nil {
+			u.Events.Error.Trigger(xerrors.Errorf("failed to store OutputPledge of snapshot Output with %s: %w", output.ID(), err))
+		}
+	}
+}
+
+// OutputPledge returns the OutputPledge of the Transaction that created the Output with the given OutputID. It is
+// derived from the Transaction if it is stored, or from the snapshot that the Output was loaded from otherwise. It
+// returns nil if the OutputPledge is unknown (e.g. for the genesis or for snapshots without OutputPledges).
+func (u *UTXODAG) OutputPledge(outputID OutputID) (outputPledge *OutputPledge) {
+	if u.Transaction(outputID.TransactionID()).Consume(func(transaction *Transaction) {
+		outputPledge = NewOutputPledge(transaction)
+	}) {
+		return outputPledge
+	}
+
+	outputPledgeBytes, err := u.snapshotOutputPledges.Get(outputID.Bytes())
+	if err != nil {
+		return nil
+	}
+	if outputPledge, _, err = OutputPledgeFromBytes(outputPledgeBytes); err != nil {
+		u.Events.Error.Trigger(xerrors.Errorf("failed to parse OutputPledge of snapshot Output with %s: %w", outputID, err))
+		return nil
+	}
+
+	return outputPledge
+}
+
+// forEachSnapshotOutput is an internal utility function that iterates over the Outputs that were created by the
+// Transactions that are accepted by the given filter and that are not spent by any valid Transaction that is accepted
+// by the filter.
+func (u *UTXODAG) forEachSnapshotOutput(filter func(transactionID TransactionID) bool, consumer func(output Output) bool) {
+	u.outputStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedOutput{CachedObject: cachedObject}).Consume(func(output Output) {
+			if !filter(output.ID().TransactionID()) {
+				return
 			}
 
-			// store addressOutputMapping
-			u.StoreAddressOutputMapping(address, output.ID())
-
-			// store OutputMetadata
-			metadata := NewOutputMetadata(output.ID())
-			metadata.SetBranchID(MasterBranchID)
-			metadata.SetSolid(true)
-			metadata.SetFinalized(true)
-			cachedMetadata, stored := u.outputMetadataStorage.StoreIfAbsent(metadata)
-			if stored {
-				cachedMetadata.Release()
+			spent := false
+			u.Consumers(output.ID()).Consume(func(consumer *Consumer) {
+				if !spent && consumer.Valid() == types.True && filter(consumer.TransactionID()) {
+					spent = true
+				}
+			})
+			if !spent {
+				continueIteration = consumer(output)
 			}
+		})
 
-			index++
+		return continueIteration
+	})
+}
+
+// storeSnapshotOutput is an internal utility function that stores an Output of a Snapshot together with its
+// OutputMetadata and its AddressOutputMapping.
+func (u *UTXODAG) storeSnapshotOutput(output Output) {
+	u.storeSnapshotOutputWithMetadata(output, newSnapshotOutputMetadata(output.ID()))
+}
+
+// storeSnapshotOutputWithMetadata is an internal utility function that stores an Output of a snapshot together with
+// the given OutputMetadata and its AddressOutputMapping.
+func (u *UTXODAG) storeSnapshotOutputWithMetadata(output Output, outputMetadata *OutputMetadata) {
+	cachedOutput, stored := u.outputStorage.StoreIfAbsent(output)
+	if stored {
+		cachedOutput.Release()
+
+		// snapshots are loaded on every start, so only Outputs that were not known before are committed
+		if err := u.stateCommitment.addOutputs(output); err != nil {
+			u.Events.Error.Trigger(xerrors.Errorf("failed to commit snapshot Output with %s: %w", output.ID(), err))
+		}
+		if err := u.dustBalances.addOutputs(u.options.DustParams, output); err != nil {
+			u.Events.Error.Trigger(xerrors.Errorf("failed to add snapshot Output with %s to the dust balances: %w", output.ID(), err))
 		}
+	}
 
-		// store TransactionMetadata
-		transactionMetadata := NewTransactionMetadata(transactionID)
-		transactionMetadata.SetSolid(true)
-		transactionMetadata.SetBranchID(MasterBranchID)
-		transactionMetadata.SetFinalized(true)
+	// store addressOutputMapping
+	u.StoreAddressOutputMapping(output.Address(), output.ID())
 
-		(&CachedTransactionMetadata{CachedObject: u.transactionMetadataStorage.ComputeIfAbsent(transactionID.Bytes(), func(key []byte) objectstorage.StorableObject {
-			transactionMetadata.Persist()
-			transactionMetadata.SetModified()
-			return transactionMetadata
-		})}).Release()
+	// store OutputMetadata
+	cachedMetadata, stored := u.outputMetadataStorage.StoreIfAbsent(normalizedSnapshotOutputMetadata(outputMetadata))
+	if stored {
+		cachedMetadata.Release()
 	}
 }
 
+// normalizedSnapshotOutputMetadata is an internal utility function that returns a copy of the given OutputMetadata that
+// is booked in the master branch, marked as solid and finalized and that has no consumers, as the Transactions that
+// consumed the Output are not part of the snapshot.
+func normalizedSnapshotOutputMetadata(outputMetadata *OutputMetadata) (normalizedOutputMetadata *OutputMetadata) {
+	normalizedOutputMetadata = NewOutputMetadata(outputMetadata.ID())
+	normalizedOutputMetadata.branchID = MasterBranchID
+	normalizedOutputMetadata.solid = true
+	normalizedOutputMetadata.solidificationTime = outputMetadata.SolidificationTime()
+	normalizedOutputMetadata.finalized = true
+	if normalizedOutputMetadata.solidificationTime.IsZero() {
+		normalizedOutputMetadata.solidificationTime = time.Now()
+	}
+
+	return normalizedOutputMetadata
+}
+
+// storeSnapshotTransactionMetadata is an internal utility function that stores the TransactionMetadata of a Transaction
+// that created Outputs of a Snapshot.
+func (u *UTXODAG) storeSnapshotTransactionMetadata(transactionID TransactionID) {
+	transactionMetadata := NewTransactionMetadata(transactionID)
+	transactionMetadata.SetSolid(true)
+	transactionMetadata.SetBranchID(MasterBranchID)
+	transactionMetadata.SetFinalized(true)
+
+	(&CachedTransactionMetadata{CachedObject: u.transactionMetadataStorage.ComputeIfAbsent(transactionID.Bytes(), func(key []byte) objectstorage.StorableObject {
+		transactionMetadata.Persist()
+		transactionMetadata.SetModified()
+		return transactionMetadata
+	})}).Release()
+}
+
 // AddressOutputMapping retrieves the outputs for the given address.
 func (u *UTXODAG) AddressOutputMapping(address Address) (cachedAddressOutputMappings CachedAddressOutputMappings) {
 	u.addressOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
@@ -303,12 +777,128 @@ func (u *UTXODAG) AddressOutputMapping(address Address) (cachedAddressOutputMapp
 	return
 }
 
+// AddressTransactionHistory returns a page of the transaction history of the given Address that contains at most limit
+// entries ordered from the newest to the oldest Transaction. If a cursor is provided, the page starts with the first
+// entry after the cursor. The returned cursor points to the last entry of the page and is nil if there are no further
+// entries.
+func (u *UTXODAG) AddressTransactionHistory(address Address, cursor *AddressHistoryCursor, limit int) (addressTransactionMappings []*AddressTransactionMapping, nextCursor *AddressHistoryCursor, err error) {
+	addressTransactionMappings, hasMore, err := u.addressHistory.page(address, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hasMore && len(addressTransactionMappings) > 0 {
+		lastCursor := addressTransactionMappings[len(addressTransactionMappings)-1].Cursor()
+		nextCursor = &lastCursor
+	}
+
+	return
+}
+
+// ColorMetadata retrieves the AssetMetadata that was attached to the Transaction that minted the given Color.
+func (u *UTXODAG) ColorMetadata(color Color) (cachedColorMetadata *CachedColorMetadata) {
+	return &CachedColorMetadata{CachedObject: u.colorMetadataStorage.Load(color.Bytes())}
+}
+
+// ColorSupplyChanges returns the changes of the supply of the given Color that were caused by the booked Transactions.
+func (u *UTXODAG) ColorSupplyChanges(color Color) (colorSupplyChanges []*ColorSupplyChange) {
+	u.colorSupplyChangeStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			colorSupplyChanges = append(colorSupplyChanges, object.(*ColorSupplyChange))
+		})
+		return true
+	}, objectstorage.WithIteratorPrefix(color.Bytes()))
+	return
+}
+
+// ColorSupply returns the total, burned and circulating supply of the given Color. Only the changes of confirmed
+// Transactions are counted.
+func (u *UTXODAG) ColorSupply(color Color) (colorSupply *ColorSupply, err error) {
+	if colorSupply, err = u.colorSupplies.supply(color); err != nil {
+		return nil, xerrors.Errorf("failed to retrieve supply of %s: %w", color, err)
+	}
+
+	return colorSupply, nil
+}
+
 // region booking functions ////////////////////////////////////////////////////////////////////////////////////////////
 
+// pruneTransactionIndexes is an internal utility function that removes the entries of the given Transaction from the
+// address and color indexes.
+func (u *UTXODAG) pruneTransactionIndexes(transactionID TransactionID) (err error) {
+	u.Transaction(transactionID).Consume(func(transaction *Transaction) {
+		cursor := NewAddressHistoryCursor(transaction.Essence().Timestamp(), transactionID)
+		for index := range transaction.Essence().Outputs() {
+			u.Output(NewOutputID(transactionID, uint16(index))).Consume(func(output Output) {
+				u.addressOutputMappingStorage.Delete(NewAddressOutputMapping(output.Address(), output.ID()).ObjectStorageKey())
+				if err == nil {
+					err = u.addressHistory.delete(output.Address(), cursor)
+				}
+
+				if extendedLockedOutput, isExtendedLockedOutput := output.(*ExtendedLockedOutput); isExtendedLockedOutput && extendedLockedOutput.FallbackAddress() != nil {
+					u.addressOutputMappingStorage.Delete(NewAddressOutputMapping(extendedLockedOutput.FallbackAddress(), output.ID()).ObjectStorageKey())
+					if err == nil {
+						err = u.addressHistory.delete(extendedLockedOutput.FallbackAddress(), cursor)
+					}
+				}
+			})
+		}
+		if err != nil {
+			return
+		}
+
+		cachedConsumedOutputs := u.ConsumedOutputs(transaction)
+		defer cachedConsumedOutputs.Release()
+		consumedOutputs := cachedConsumedOutputs.Unwrap()
+		for _, consumedOutput := range consumedOutputs {
+			if consumedOutput != nil {
+				if err = u.addressHistory.delete(consumedOutput.Address(), cursor); err != nil {
+					return
+				}
+			}
+		}
+
+		minted, burned := colorSupplyChanges(transaction, consumedOutputs)
+		for color := range minted {
+			u.colorSupplyChangeStorage.Delete(byteutils.ConcatBytes(color.Bytes(), transactionID.Bytes()))
+			u.colorMetadataStorage.Delete(color.Bytes())
+		}
+		for color := range burned {
+			u.colorSupplyChangeStorage.Delete(byteutils.ConcatBytes(color.Bytes(), transactionID.Bytes()))
+		}
+	})
+
+	return
+}
+
+// pruneTransaction is an internal utility function that removes the given Transaction together with its metadata, its
+// Outputs and its Consumer references from the object storage and stores a tombstone for it.
+func (u *UTXODAG) pruneTransaction(transactionID TransactionID) (err error) {
+	u.Transaction(transactionID).Consume(func(transaction *Transaction) {
+		for _, input := range transaction.Essence().Inputs() {
+			u.consumerStorage.Delete(byteutils.ConcatBytes(input.(*UTXOInput).ReferencedOutputID().Bytes(), transactionID.Bytes()))
+		}
+
+		for index := range transaction.Essence().Outputs() {
+			outputID := NewOutputID(transactionID, uint16(index))
+			u.outputStorage.Delete(outputID.Bytes())
+			u.outputMetadataStorage.Delete(outputID.Bytes())
+		}
+	})
+
+	if err = u.transactionTombstones.add(transactionID.Bytes()); err != nil {
+		return xerrors.Errorf("failed to store tombstone of Transaction with %s: %w", transactionID, err)
+	}
+	u.transactionMetadataStorage.Delete(transactionID.Bytes())
+	u.transactionStorage.Delete(transactionID.Bytes())
+
+	return
+}
+
 // bookInvalidTransaction is an internal utility function that books the given Transaction into the Branch identified by
-// the InvalidBranchID.
-func (u *UTXODAG) bookInvalidTransaction(transaction *Transaction, transactionMetadata *TransactionMetadata, inputsMetadata OutputsMetadata) {
+// the InvalidBranchID and records the reason for its invalidity.
+func (u *UTXODAG) bookInvalidTransaction(transaction *Transaction, transactionMetadata *TransactionMetadata, inputsMetadata OutputsMetadata, invalidReason InvalidReason) {
 	transactionMetadata.SetBranchID(InvalidBranchID)
+	transactionMetadata.SetInvalidReason(invalidReason)
 	transactionMetadata.SetSolid(true)
 	transactionMetadata.SetFinalized(true)
 
@@ -510,12 +1100,33 @@ func (u *UTXODAG) bookConsumers(inputsMetadata OutputsMetadata, transactionID Tr
 	}
 }
 
+// storeColorSupplyChanges is an internal utility function that indexes the minted and burned colored tokens of the given
+// Transaction and the AssetMetadata of the Colors that it mints.
+func (u *UTXODAG) storeColorSupplyChanges(transaction *Transaction, consumedOutputs Outputs) {
+	minted, burned := colorSupplyChanges(transaction, consumedOutputs)
+	for color, balance := range minted {
+		u.colorSupplyChangeStorage.Store(NewColorSupplyChange(color, transaction.ID(), balance, 0)).Release()
+
+		if assetMetadata, isAssetMetadata := transaction.Essence().Payload().(*AssetMetadata); isAssetMetadata {
+			if cachedColorMetadata, stored := u.colorMetadataStorage.StoreIfAbsent(NewColorMetadata(color, transaction.ID(), assetMetadata)); stored {
+				cachedColorMetadata.Release()
+			}
+		}
+	}
+	for color, balance := range burned {
+		u.colorSupplyChangeStorage.Store(NewColorSupplyChange(color, transaction.ID(), 0, balance)).Release()
+	}
+}
+
 // bookOutputs creates the Outputs and their corresponding OutputsMetadata in the object storage.
 func (u *UTXODAG) bookOutputs(transaction *Transaction, targetBranch BranchID) {
 	for _, output := range transaction.Essence().Outputs() {
 		// replace ColorMint color with unique color based on OutputID
-		if output.Type() == SigLockedColoredOutputType {
-			output = output.(*SigLockedColoredOutput).UpdateMintingColor()
+		switch typedOutput := output.(type) {
+		case *SigLockedColoredOutput:
+			output = typedOutput.UpdateMintingColor()
+		case *ExtendedLockedOutput:
+			output = typedOutput.UpdateMintingColor()
 		}
 
 		// store Output
@@ -582,6 +1193,18 @@ func (u *UTXODAG) allOutputsExist(inputs Outputs) (solid bool) {
 	return true
 }
 
+// inputsPruned is an internal utility function that checks if any of the Inputs of the given Transaction references
+// an Output of a pruned Transaction.
+func (u *UTXODAG) inputsPruned(transaction *Transaction) (pruned bool) {
+	for _, input := range transaction.Essence().Inputs() {
+		if u.TransactionPruned(input.(*UTXOInput).ReferencedOutputID().TransactionID()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // transactionInputsMetadata is an internal utility function that returns the Metadata of the Outputs that are used as
 // Inputs by the given Transaction.
 func (u *UTXODAG) transactionInputsMetadata(transaction *Transaction) (cachedInputsMetadata CachedOutputsMetadata) {
@@ -678,6 +1301,111 @@ func (u *UTXODAG) consumedOutputsPastConeValid(outputs Outputs, outputsMetadata
 	return true
 }
 
+// checkTransactionDust is an internal utility function that checks if the Outputs created by the given Transaction hold
+// the minimum deposit and if every Address that receives dust Outputs also receives the dust allowance for them in the
+// same Transaction. The checks only depend on the Transaction itself, so all nodes reach the same verdict.
+func (u *UTXODAG) checkTransactionDust(transaction *Transaction) (err error) {
+	dustParams := u.options.DustParams
+	for _, output := range transaction.Essence().Outputs() {
+		if deposit := outputDeposit(output); deposit < dustParams.MinDeposit {
+			return xerrors.Errorf("Output with %s holds %d tokens which is less than the minimum deposit of %d: %w", output.ID(), deposit, dustParams.MinDeposit, ErrTransactionInvalid)
+		}
+	}
+	if dustParams.DustThreshold == 0 {
+		return nil
+	}
+
+	balances := make(map[string]*dustBalance)
+	addresses := make(map[string]Address)
+	for _, output := range transaction.Essence().Outputs() {
+		addressBase58 := output.Address().Base58()
+		if _, exists := balances[addressBase58]; !exists {
+			balances[addressBase58] = &dustBalance{}
+			addresses[addressBase58] = output.Address()
+		}
+		balances[addressBase58].add(output, dustParams)
+	}
+	for addressBase58, balance := range balances {
+		if !balance.valid(dustParams) {
+			return xerrors.Errorf("Transaction with %s creates %d dust Outputs for %s with a dust allowance of %d: %w", transaction.ID(), balance.dustOutputs, addresses[addressBase58], balance.dustAllowance, ErrTransactionInvalid)
+		}
+	}
+
+	return nil
+}
+
+// checkDustAllowance is an internal utility function that checks if none of the Addresses that are affected by the
+// given Transaction would own more dust Outputs than its dust allowance permits. It uses the dustBalances of the
+// confirmed unspent Outputs.
+func (u *UTXODAG) checkDustAllowance(transaction *Transaction, consumedOutputs Outputs) (err error) {
+	dustParams := u.options.DustParams
+	if dustParams.DustThreshold == 0 {
+		return nil
+	}
+
+	// only the Addresses that receive dust or spend parts of their dust allowance need to be checked
+	balances := make(map[string]*dustBalance)
+	addresses := make(map[string]Address)
+	balance := func(address Address) (balance *dustBalance, err error) {
+		if balance, exists := balances[address.Base58()]; exists {
+			return balance, nil
+		}
+		if balance, err = u.dustBalances.balance(address); err != nil {
+			return nil, err
+		}
+		balances[address.Base58()] = balance
+		addresses[address.Base58()] = address
+
+		return balance, nil
+	}
+
+	for _, output := range transaction.Essence().Outputs() {
+		if !dustParams.isDust(output) {
+			continue
+		}
+		if _, err = balance(output.Address()); err != nil {
+			return err
+		}
+	}
+	for _, output := range consumedOutputs {
+		if output == nil || dustParams.isDust(output) {
+			continue
+		}
+		if _, err = balance(output.Address()); err != nil {
+			return err
+		}
+	}
+
+	// Outputs that are not confirmed, yet, are not part of the balances
+	for _, output := range consumedOutputs {
+		if output == nil {
+			continue
+		}
+		if addressBalance, exists := balances[output.Address().Base58()]; exists {
+			confirmed, err := u.dustBalances.contains(output.ID())
+			if err != nil {
+				return xerrors.Errorf("failed to check if Output with %s is part of the dust balances: %w", output.ID(), err)
+			}
+			if confirmed {
+				addressBalance.remove(output, dustParams)
+			}
+		}
+	}
+	for _, output := range transaction.Essence().Outputs() {
+		if addressBalance, exists := balances[output.Address().Base58()]; exists {
+			addressBalance.add(output, dustParams)
+		}
+	}
+
+	for addressBase58, addressBalance := range balances {
+		if !addressBalance.valid(dustParams) {
+			return xerrors.Errorf("%s would own %d dust Outputs with a dust allowance of %d: %w", addresses[addressBase58], addressBalance.dustOutputs, addressBalance.dustAllowance, ErrTransactionInvalid)
+		}
+	}
+
+	return nil
+}
+
 // outputsUnspent is an internal utility function that checks if the given outputs are unspent (do not have a valid
 // Consumer, yet).
 func (u *UTXODAG) outputsUnspent(outputsMetadata OutputsMetadata) (outputsUnspent bool) {
@@ -793,6 +1521,37 @@ func (u *UTXODAG) consumedBranchIDs(transactionID TransactionID) (branchIDs Bran
 	return
 }
 
+// StoreAddressTransactionMappings adds the given Transaction to the transaction history of all Addresses that are
+// credited or debited by it.
+func (u *UTXODAG) StoreAddressTransactionMappings(transaction *Transaction) (err error) {
+	addresses := make(map[string]Address)
+	credited := make(map[string]bool)
+	debited := make(map[string]bool)
+
+	for _, output := range transaction.Essence().Outputs() {
+		addresses[output.Address().Base58()] = output.Address()
+		credited[output.Address().Base58()] = true
+
+		// outputs that return to a fallback address can be claimed by it as well
+		if extendedLockedOutput, isExtendedLockedOutput := output.(*ExtendedLockedOutput); isExtendedLockedOutput && extendedLockedOutput.FallbackAddress() != nil {
+			addresses[extendedLockedOutput.FallbackAddress().Base58()] = extendedLockedOutput.FallbackAddress()
+			credited[extendedLockedOutput.FallbackAddress().Base58()] = true
+		}
+	}
+	u.ConsumedOutputs(transaction).Consume(func(output Output) {
+		addresses[output.Address().Base58()] = output.Address()
+		debited[output.Address().Base58()] = true
+	})
+
+	for addressBase58, address := range addresses {
+		if err = u.addressHistory.add(NewAddressTransactionMapping(address, transaction.ID(), transaction.Essence().Timestamp(), credited[addressBase58], debited[addressBase58])); err != nil {
+			return xerrors.Errorf("failed to add Transaction with %s to the history of %s: %w", transaction.ID(), address, err)
+		}
+	}
+
+	return nil
+}
+
 // StoreAddressOutputMapping stores the address-output mapping.
 func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
 	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
@@ -826,6 +1585,9 @@ func (u *UTXODAG) lockTransaction(transaction *Transaction) {
 type UTXODAGEvents struct {
 	// TransactionBranchIDUpdated gets triggered when the BranchID of a Transaction is changed after the initial booking.
 	TransactionBranchIDUpdated *events.Event
+
+	// Error gets triggered when the UTXODAG fails to update its persisted state (i.e. the StateCommitment).
+	Error *events.Event
 }
 
 func transactionIDEventHandler(handler interface{}, params ...interface{}) {
@@ -834,6 +1596,27 @@ func transactionIDEventHandler(handler interface{}, params ...interface{}) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region UTXODAGOptions ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// UTXODAGOption represents the return type of optional parameters that can be handed into the constructor of the
+// UTXODAG to configure its behavior.
+type UTXODAGOption func(*UTXODAGOptions)
+
+// UTXODAGOptions is a container for all configurable parameters of the UTXODAG.
+type UTXODAGOptions struct {
+	DustParams DustParams
+}
+
+// DustProtection is an UTXODAGOption that allows to define the dust protection rules that are enforced when booking
+// Transactions.
+func DustProtection(dustParams DustParams) UTXODAGOption {
+	return func(options *UTXODAGOptions) {
+		options.DustParams = dustParams
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region AddressOutputMapping /////////////////////////////////////////////////////////////////////////////////////////
 
 // AddressOutputMapping represents a mapping between Addresses and their corresponding Outputs. Since an Address can have a
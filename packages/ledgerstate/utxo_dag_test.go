@@ -1,10 +1,14 @@
 package ledgerstate
 
 import (
+	"errors"
+	"io"
+	"io/ioutil"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
@@ -284,7 +288,7 @@ func TestBookInvalidTransaction(t *testing.T) {
 		inputsMetadata = append(inputsMetadata, metadata)
 	})
 
-	utxoDAG.bookInvalidTransaction(tx, txMetadata, inputsMetadata)
+	utxoDAG.bookInvalidTransaction(tx, txMetadata, inputsMetadata, InvalidReasonPastConeInvalid)
 
 	assert.Equal(t, InvalidBranchID, txMetadata.branchID)
 	assert.True(t, txMetadata.Solid())
@@ -760,6 +764,260 @@ func TestUnlockBlocksValid(t *testing.T) {
 	assert.False(t, UnlockBlocksValid(Outputs{input}, tx))
 }
 
+func TestAliasOutput(t *testing.T) {
+	wallets := createWallets(2)
+
+	origin, err := NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), wallets[0].address, wallets[1].address, []byte("state"))
+	require.NoError(t, err)
+	origin.SetID(NewOutputID(GenesisTransactionID, 1))
+	assert.True(t, origin.IsOrigin())
+	assert.Equal(t, NewAliasAddress(origin.ID().Bytes()).Bytes(), origin.Address().Bytes())
+
+	// the origin derives its AliasAddress from the OutputID after parsing
+	parsedOrigin, consumedBytes, err := OutputFromBytes(origin.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(origin.Bytes()), consumedBytes)
+	parsedOrigin.SetID(origin.ID())
+	assert.Equal(t, origin.Bytes(), parsedOrigin.Bytes())
+	assert.Equal(t, origin.Address().Bytes(), parsedOrigin.Address().Bytes())
+
+	// consecutive AliasOutputs carry the AliasAddress of the chain
+	next := origin.NewAliasOutputNext(false)
+	assert.False(t, next.IsOrigin())
+	assert.Equal(t, uint32(1), next.StateIndex())
+	assert.Equal(t, origin.Address().Bytes(), next.Address().Bytes())
+	parsedNext, _, err := AliasOutputFromBytes(next.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, next.Bytes(), parsedNext.Bytes())
+	assert.Equal(t, origin.Address().Bytes(), parsedNext.Address().Bytes())
+
+	// the state data is limited in size
+	_, err = NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), wallets[0].address, wallets[1].address, make([]byte, MaxAliasStateDataSize+1))
+	assert.Error(t, err)
+	assert.Error(t, next.SetStateData(make([]byte, MaxAliasStateDataSize+1)))
+}
+
+func TestAliasOutputsValid(t *testing.T) {
+	wallets := createWallets(3)
+
+	origin, err := NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), wallets[0].address, wallets[1].address, []byte("state"))
+	require.NoError(t, err)
+	origin.SetID(NewOutputID(GenesisTransactionID, 1))
+
+	// testing the creation of a new chain
+	assert.True(t, AliasOutputsValid(Outputs{}, Outputs{origin}))
+
+	// testing a state update
+	stateUpdate := origin.NewAliasOutputNext(false)
+	require.NoError(t, stateUpdate.SetStateData([]byte("next state")))
+	stateUpdate.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 200}))
+	assert.True(t, AliasOutputsValid(Outputs{origin}, Outputs{stateUpdate}))
+
+	// testing a state update that changes the controllers
+	stateUpdate.SetStateAddress(wallets[2].address)
+	assert.False(t, AliasOutputsValid(Outputs{origin}, Outputs{stateUpdate}))
+
+	// testing a governance update that changes the controllers
+	governanceUpdate := origin.NewAliasOutputNext(true)
+	governanceUpdate.SetStateAddress(wallets[2].address)
+	assert.True(t, AliasOutputsValid(Outputs{origin}, Outputs{governanceUpdate}))
+
+	// testing a governance update that changes the state
+	require.NoError(t, governanceUpdate.SetStateData([]byte("next state")))
+	assert.False(t, AliasOutputsValid(Outputs{origin}, Outputs{governanceUpdate}))
+
+	// testing the continuation of a chain that is not consumed
+	assert.False(t, AliasOutputsValid(Outputs{}, Outputs{origin.NewAliasOutputNext(false)}))
+
+	// testing the continuation of a chain by two AliasOutputs
+	assert.False(t, AliasOutputsValid(Outputs{origin}, Outputs{origin.NewAliasOutputNext(false), origin.NewAliasOutputNext(true)}))
+
+	// testing an AliasOutput that mints coins
+	minting, err := NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorMint: 100}), wallets[0].address, wallets[1].address, nil)
+	require.NoError(t, err)
+	assert.False(t, AliasOutputsValid(Outputs{}, Outputs{minting}))
+}
+
+func TestCheckTransaction_AliasOutput(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	stateController, governanceController, receiver := wallets[0], wallets[1], wallets[2]
+
+	origin, err := NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), stateController.address, governanceController.address, []byte("state"))
+	require.NoError(t, err)
+	storeOutput(utxoDAG, origin.SetID(NewOutputID(GenesisTransactionID, 1)))
+	ownedOutput := NewSigLockedSingleOutput(50, origin.AliasAddress())
+	storeOutput(utxoDAG, ownedOutput.SetID(NewOutputID(GenesisTransactionID, 2)))
+
+	buildAliasTransaction := func(signer wallet, outputs ...Output) *Transaction {
+		txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(origin.Input(), ownedOutput.Input()), NewOutputs(outputs...))
+
+		return NewTransaction(txEssence, UnlockBlocks{NewSignatureUnlockBlock(signer.sign(txEssence)), NewAliasUnlockBlock(0)})
+	}
+
+	// testing a state update that collects the funds owned by the AliasAddress
+	stateUpdate := origin.NewAliasOutputNext(false)
+	require.NoError(t, stateUpdate.SetStateData([]byte("next state")))
+	stateUpdate.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 150}))
+	valid, err := utxoDAG.CheckTransaction(buildAliasTransaction(stateController, stateUpdate))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// testing a state update that is signed by the governance controller
+	valid, err = utxoDAG.CheckTransaction(buildAliasTransaction(governanceController, stateUpdate))
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+
+	// testing the destruction of the chain by the state controller
+	payout := NewSigLockedSingleOutput(150, receiver.address)
+	valid, err = utxoDAG.CheckTransaction(buildAliasTransaction(stateController, payout))
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+
+	// testing the destruction of the chain by the governance controller
+	valid, err = utxoDAG.CheckTransaction(buildAliasTransaction(governanceController, payout))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// testing an AliasUnlockBlock that does not reference the AliasOutput of the chain
+	txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(origin.Input(), ownedOutput.Input()), NewOutputs(payout))
+	valid, err = utxoDAG.CheckTransaction(NewTransaction(txEssence, UnlockBlocks{NewSignatureUnlockBlock(governanceController.sign(txEssence)), NewAliasUnlockBlock(1)}))
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+}
+
+func TestCheckTransaction_AliasOwnedOutputSortedFirst(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	stateController, governanceController, receiver := wallets[0], wallets[1], wallets[2]
+
+	// the owned Output sorts before the AliasOutput of the chain, so its AliasUnlockBlock references a later input
+	origin, err := NewAliasOutputMint(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), stateController.address, governanceController.address, []byte("state"))
+	require.NoError(t, err)
+	storeOutput(utxoDAG, origin.SetID(NewOutputID(GenesisTransactionID, 2)))
+	ownedOutput := NewSigLockedSingleOutput(50, origin.AliasAddress())
+	storeOutput(utxoDAG, ownedOutput.SetID(NewOutputID(GenesisTransactionID, 1)))
+
+	inputs := NewInputs(origin.Input(), ownedOutput.Input())
+	require.Equal(t, ownedOutput.ID(), inputs[0].(*UTXOInput).ReferencedOutputID())
+
+	txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, inputs, NewOutputs(NewSigLockedSingleOutput(150, receiver.address)))
+	valid, err := utxoDAG.CheckTransaction(NewTransaction(txEssence, UnlockBlocks{NewAliasUnlockBlock(1), NewSignatureUnlockBlock(governanceController.sign(txEssence))}))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// AliasUnlockBlocks that reference each other in a cycle are invalid
+	assert.True(t, aliasUnlockCycle(UnlockBlocks{NewAliasUnlockBlock(1), NewAliasUnlockBlock(0)}, 0))
+	assert.True(t, aliasUnlockCycle(UnlockBlocks{NewAliasUnlockBlock(0)}, 0))
+	assert.False(t, aliasUnlockCycle(UnlockBlocks{NewAliasUnlockBlock(1), NewSignatureUnlockBlock(governanceController.sign(txEssence))}, 0))
+}
+
+func TestExtendedLockedOutput(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	recipient, fallback, receiver := wallets[0], wallets[1], wallets[2]
+
+	now := time.Now()
+	timelock := now.Add(time.Hour)
+	deadline := now.Add(2 * time.Hour)
+
+	output := NewExtendedLockedOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), recipient.address).
+		WithTimeLock(timelock).
+		WithFallbackOptions(fallback.address, deadline)
+	storeOutput(utxoDAG, output.SetID(NewOutputID(GenesisTransactionID, 1)))
+
+	// testing marshaling
+	parsedOutput, consumedBytes, err := OutputFromBytes(output.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(output.Bytes()), consumedBytes)
+	assert.Equal(t, output.Bytes(), parsedOutput.Bytes())
+	assert.Equal(t, timelock.UnixNano(), parsedOutput.(*ExtendedLockedOutput).TimeLock().UnixNano())
+	assert.Equal(t, deadline.UnixNano(), parsedOutput.(*ExtendedLockedOutput).FallbackDeadline().UnixNano())
+
+	// testing marshaling of an Output whose fallback deadline does not lie after the timelock
+	unreachableOutput := NewExtendedLockedOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), recipient.address).
+		WithTimeLock(deadline).
+		WithFallbackOptions(fallback.address, timelock)
+	_, _, err = OutputFromBytes(unreachableOutput.Bytes())
+	assert.ErrorIs(t, err, cerrors.ErrParseBytesFailed)
+
+	// testing the Address that is able to unlock the Output over time
+	assert.Nil(t, output.UnlockAddressNow(now))
+	assert.Equal(t, recipient.address, output.UnlockAddressNow(timelock))
+	assert.Equal(t, fallback.address, output.UnlockAddressNow(deadline))
+
+	checkTransaction := func(signer wallet, timestamp time.Time) (bool, error) {
+		txEssence := NewTransactionEssence(0, timestamp, identity.ID{}, identity.ID{}, NewInputs(output.Input()), NewOutputs(NewSigLockedSingleOutput(100, receiver.address)))
+
+		return utxoDAG.CheckTransaction(NewTransaction(txEssence, signer.unlockBlocks(txEssence)))
+	}
+
+	// testing the recipient before the timelock
+	valid, err := checkTransaction(recipient, now)
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+
+	// testing the recipient between the timelock and the fallback deadline
+	valid, err = checkTransaction(recipient, timelock.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// testing the fallback address before the fallback deadline
+	valid, err = checkTransaction(fallback, timelock.Add(time.Minute))
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+
+	// testing the recipient after the fallback deadline
+	valid, err = checkTransaction(recipient, deadline)
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+
+	// testing the fallback address after the fallback deadline
+	valid, err = checkTransaction(fallback, deadline)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestCheckTransaction_ThresholdAddress(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	address, err := NewThresholdAddress(2, []ed25519.PublicKey{wallets[0].publicKey(), wallets[1].publicKey(), wallets[2].publicKey()})
+	require.NoError(t, err)
+
+	output := NewSigLockedSingleOutput(100, address)
+	storeOutput(utxoDAG, output.SetID(NewOutputID(GenesisTransactionID, 1)))
+
+	checkTransaction := func(signers ...wallet) (bool, error) {
+		txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(output.Input()), NewOutputs(NewSigLockedSingleOutput(100, wallets[0].address)))
+		signatures := make([]*ED25519Signature, len(signers))
+		for i, signer := range signers {
+			signatures[i] = signer.sign(txEssence)
+		}
+		unlockBlock, unlockBlockErr := NewMultiSignatureUnlockBlock(2, []ed25519.PublicKey{wallets[2].publicKey(), wallets[1].publicKey(), wallets[0].publicKey()}, signatures...)
+		require.NoError(t, unlockBlockErr)
+
+		return utxoDAG.CheckTransaction(NewTransaction(txEssence, UnlockBlocks{unlockBlock}))
+	}
+
+	// testing enough signatures
+	valid, err := checkTransaction(wallets[0], wallets[2])
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// testing too few signatures
+	valid, err = checkTransaction(wallets[1])
+	assert.ErrorIs(t, err, ErrTransactionInvalid)
+	assert.False(t, valid)
+}
+
 func TestAddressOutputMapping(t *testing.T) {
 	branchDAG, utxoDAG := setupDependencies(t)
 	defer branchDAG.Shutdown()
@@ -776,13 +1034,141 @@ func TestAddressOutputMapping(t *testing.T) {
 	assert.Equal(t, 1, len(res))
 }
 
-func setupDependencies(t *testing.T) (*BranchDAG, *UTXODAG) {
+func TestUTXODAG_WriteSnapshot(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	outputs := make(Outputs, 0)
+	for i, w := range wallets {
+		output := NewSigLockedSingleOutput(uint64(100*(i+1)), w.address)
+		output.SetID(NewOutputID(TransactionID{1}, uint16(i)))
+		storeOutput(utxoDAG, output)
+		outputs = append(outputs, output)
+	}
+
+	file, err := ioutil.TempFile(t.TempDir(), "snapshot")
+	require.NoError(t, err)
+	defer file.Close()
+
+	writer, err := NewSnapshotWriter(file, 22, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG.WriteSnapshot(writer, func(TransactionID) bool { return true }))
+	header, err := writer.Close()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(outputs)), header.OutputCount)
+
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	reader, err := NewSnapshotReader(file)
+	require.NoError(t, err)
+
+	loadedBranchDAG, loadedUTXODAG := setupDependencies(t)
+	defer loadedBranchDAG.Shutdown()
+	for {
+		output, outputMetadata, outputPledge, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		loadedUTXODAG.LoadSnapshotOutput(output, outputMetadata, outputPledge)
+	}
+
+	for _, output := range outputs {
+		assert.True(t, loadedUTXODAG.Output(output.ID()).Consume(func(loadedOutput Output) {
+			assert.Equal(t, output.Bytes(), loadedOutput.Bytes())
+		}))
+		assert.True(t, loadedUTXODAG.OutputMetadata(output.ID()).Consume(func(outputMetadata *OutputMetadata) {
+			assert.Equal(t, MasterBranchID, outputMetadata.BranchID())
+			assert.True(t, outputMetadata.Finalized())
+		}))
+		// the Transactions of the Outputs are not stored, so they were written with empty OutputPledges
+		assert.Equal(t, (&OutputPledge{}).Bytes(), loadedUTXODAG.OutputPledge(output.ID()).Bytes())
+		addressOutputMappings := loadedUTXODAG.AddressOutputMapping(output.Address())
+		assert.Len(t, addressOutputMappings, 1)
+		addressOutputMappings.Release()
+	}
+}
+
+func TestUTXODAG_PruneRejectedBranch(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+
+	// book two Transactions that double spend the same Output and a Transaction that spends the second one
+	tx1 := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	_, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+	tx2 := buildTransaction(utxoDAG, wallets[0], wallets[2], []*SigLockedSingleOutput{input})
+	targetBranch2, err := utxoDAG.BookTransaction(tx2)
+	require.NoError(t, err)
+	assert.Equal(t, NewBranchID(tx2.ID()), targetBranch2)
+	tx3 := buildTransaction(utxoDAG, wallets[2], wallets[0], []*SigLockedSingleOutput{tx2.Essence().Outputs()[0].(*SigLockedSingleOutput)})
+	targetBranch3, err := utxoDAG.BookTransaction(tx3)
+	require.NoError(t, err)
+	assert.Equal(t, targetBranch2, targetBranch3)
+
+	// only Rejected Branches can be pruned
+	_, err = utxoDAG.PruneRejectedBranch(targetBranch2)
+	assert.Error(t, err)
+
+	_, err = branchDAG.SetBranchFinalized(targetBranch2, true)
+	require.NoError(t, err)
+	inclusionState, err := utxoDAG.InclusionState(tx2.ID())
+	require.NoError(t, err)
+	require.Equal(t, Rejected, inclusionState)
+
+	prunedTransactionIDs, err := utxoDAG.PruneRejectedBranch(targetBranch2)
+	require.NoError(t, err)
+	assert.Equal(t, TransactionIDs{tx2.ID(): types.Void, tx3.ID(): types.Void}, prunedTransactionIDs)
+
+	// the pruned objects are gone but their tombstones are kept
+	assert.False(t, branchDAG.Branch(targetBranch2).Consume(func(Branch) {}))
+	assert.True(t, branchDAG.BranchPruned(targetBranch2))
+	for _, transaction := range []*Transaction{tx2, tx3} {
+		assert.False(t, utxoDAG.Transaction(transaction.ID()).Consume(func(*Transaction) {}))
+		assert.False(t, utxoDAG.TransactionMetadata(transaction.ID()).Consume(func(*TransactionMetadata) {}))
+		assert.False(t, utxoDAG.Output(transaction.Essence().Outputs()[0].ID()).Consume(func(Output) {}))
+		assert.True(t, utxoDAG.TransactionPruned(transaction.ID()))
+
+		inclusionState, inclusionStateErr := utxoDAG.InclusionState(transaction.ID())
+		require.NoError(t, inclusionStateErr)
+		assert.Equal(t, Rejected, inclusionState)
+	}
+	cachedConsumers := utxoDAG.Consumers(input.ID())
+	assert.Len(t, cachedConsumers, 1)
+	cachedConsumers.Release()
+	cachedConflictMembers := branchDAG.ConflictMembers(NewConflictID(input.ID()))
+	assert.Len(t, cachedConflictMembers, 1)
+	cachedConflictMembers.Release()
+
+	// the winning Transaction is untouched
+	assert.True(t, utxoDAG.Transaction(tx1.ID()).Consume(func(*Transaction) {}))
+	assert.False(t, utxoDAG.TransactionPruned(tx1.ID()))
+
+	// late arriving attachments and spends of pruned Outputs are rejected
+	_, err = utxoDAG.CheckTransaction(tx2)
+	assert.True(t, errors.Is(err, ErrTransactionInvalid))
+	_, err = utxoDAG.BookTransaction(tx2)
+	assert.True(t, errors.Is(err, ErrTransactionInvalid))
+	tx4 := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{tx3.Essence().Outputs()[0].(*SigLockedSingleOutput)})
+	_, err = utxoDAG.CheckTransaction(tx4)
+	assert.True(t, errors.Is(err, ErrTransactionInvalid))
+
+	rejected, rejectedBranchID := branchDAG.BranchIDsContainRejectedBranch(NewBranchIDs(targetBranch2))
+	assert.True(t, rejected)
+	assert.Equal(t, targetBranch2, rejectedBranchID)
+}
+
+func setupDependencies(t *testing.T, options ...UTXODAGOption) (*BranchDAG, *UTXODAG) {
 	store := mapdb.NewMapDB()
 	branchDAG := NewBranchDAG(store)
 	err := branchDAG.Prune()
 	require.NoError(t, err)
 
-	return branchDAG, NewUTXODAG(store, branchDAG)
+	return branchDAG, NewUTXODAG(store, branchDAG, options...)
 }
 
 type wallet struct {
@@ -837,6 +1223,16 @@ func generateOutput(utxoDAG *UTXODAG, address Address, index uint16) *SigLockedS
 	return output
 }
 
+func storeOutput(utxoDAG *UTXODAG, output Output) {
+	utxoDAG.outputStorage.Store(output).Release()
+
+	// store OutputMetadata
+	metadata := NewOutputMetadata(output.ID())
+	metadata.SetBranchID(MasterBranchID)
+	metadata.SetSolid(true)
+	utxoDAG.outputMetadataStorage.Store(metadata).Release()
+}
+
 func generateOutputs(utxoDAG *UTXODAG, address Address, branchIDs BranchIDs) (outputs []*SigLockedSingleOutput) {
 	i := 0
 	outputs = make([]*SigLockedSingleOutput, len(branchIDs))
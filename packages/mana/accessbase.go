@@ -1,9 +1,6 @@
 package mana
 
-import (
-	"math"
-	"time"
-)
+import "time"
 
 // AccessBaseMana holds information about the access base mana values of a single node.
 type AccessBaseMana struct {
@@ -34,7 +31,7 @@ func (a *AccessBaseMana) updateBM2(n time.Duration) {
 		a.BaseMana2 = 0
 		return
 	}
-	a.BaseMana2 *= math.Pow(math.E, -Decay*n.Seconds())
+	a.BaseMana2 = GetManaFunction(AccessMana).Decay(a.BaseMana2, n)
 }
 
 func (a *AccessBaseMana) updateEBM2(n time.Duration) {
@@ -48,14 +45,7 @@ func (a *AccessBaseMana) updateEBM2(n time.Duration) {
 		return
 	}
 
-	if emaCoeff2 != Decay {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -emaCoeff2*n.Seconds())*a.EffectiveBaseMana2 +
-			(math.Pow(math.E, -Decay*n.Seconds())-math.Pow(math.E, -emaCoeff2*n.Seconds()))/
-				(emaCoeff2-Decay)*emaCoeff2/math.Pow(math.E, -Decay*n.Seconds())*a.BaseMana2
-	} else {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -Decay*n.Seconds())*a.EffectiveBaseMana2 +
-			Decay*n.Seconds()*a.BaseMana2
-	}
+	a.EffectiveBaseMana2 = GetManaFunction(AccessMana).Effective(a.BaseMana2, a.EffectiveBaseMana2, n)
 }
 
 func (a *AccessBaseMana) revoke(float64, time.Time) error {
@@ -64,6 +54,7 @@ func (a *AccessBaseMana) revoke(float64, time.Time) error {
 
 func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
 	t := tx.TimeStamp
+	manaFunction := GetManaFunction(AccessMana)
 
 	if t.After(a.LastUpdated) {
 		// regular update
@@ -74,7 +65,7 @@ func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
 		a.LastUpdated = t
 		// pending mana awarded, need to see how long funds sat
 		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds())))
+			bm2Add := manaFunction.Accrue(input.Amount, t.Sub(input.TimeStamp))
 			a.BaseMana2 += bm2Add
 			pledged += bm2Add
 		}
@@ -84,18 +75,12 @@ func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
 		// update  BM2 at `t`
 		oldMana2 := a.BaseMana2
 		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds()))) *
-				math.Pow(math.E, -Decay*n.Seconds())
+			bm2Add := manaFunction.Decay(manaFunction.Accrue(input.Amount, t.Sub(input.TimeStamp)), n)
 			a.BaseMana2 += bm2Add
 			pledged += bm2Add
 		}
 		// update EBM2 to `bm.LastUpdated`
-		if emaCoeff2 != Decay {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * emaCoeff2 * (math.Pow(math.E, -Decay*n.Seconds()) -
-				math.Pow(math.E, -emaCoeff2*n.Seconds())) / (emaCoeff2 - Decay) / math.Pow(math.E, -Decay*n.Seconds())
-		} else {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * Decay * n.Seconds()
-		}
+		a.EffectiveBaseMana2 += manaFunction.Effective(a.BaseMana2-oldMana2, 0, n)
 	}
 	return
 }
@@ -0,0 +1,110 @@
+package mana
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// ActivityTracker keeps track of the nodes that are active, i.e. that issued a message within a sliding time window.
+type ActivityTracker struct {
+	// Events contains the events that are triggered when nodes join or leave the set of active nodes.
+	Events *ActivityTrackerEvents
+
+	window       time.Duration
+	lastActivity map[identity.ID]time.Time
+	mutex        sync.RWMutex
+}
+
+// NewActivityTracker creates a new ActivityTracker that considers nodes active for the given window after the issuing
+// time of their latest message.
+func NewActivityTracker(window time.Duration) *ActivityTracker {
+	return &ActivityTracker{
+		Events: &ActivityTrackerEvents{
+			NodeJoined: events.NewEvent(activityEventCaller),
+			NodeLeft:   events.NewEvent(activityEventCaller),
+		},
+		window:       window,
+		lastActivity: make(map[identity.ID]time.Time),
+	}
+}
+
+// Update records a message of the given node that was issued at the given time. Messages that are older than the window
+// (e.g. while the node is syncing) do not make the issuer active.
+func (a *ActivityTracker) Update(nodeID identity.ID, issuingTime time.Time, now time.Time) {
+	if !issuingTime.Add(a.window).After(now) {
+		return
+	}
+
+	a.mutex.Lock()
+	lastActivity, active := a.lastActivity[nodeID]
+	if active && !issuingTime.After(lastActivity) {
+		a.mutex.Unlock()
+		return
+	}
+	a.lastActivity[nodeID] = issuingTime
+	a.mutex.Unlock()
+
+	if !active {
+		a.Events.NodeJoined.Trigger(&ActivityEvent{NodeID: nodeID, Time: issuingTime})
+	}
+}
+
+// Prune removes the nodes that did not issue a message within the window before the given time.
+func (a *ActivityTracker) Prune(now time.Time) {
+	leftNodes := make([]*ActivityEvent, 0)
+
+	a.mutex.Lock()
+	for nodeID, lastActivity := range a.lastActivity {
+		if !lastActivity.Add(a.window).After(now) {
+			delete(a.lastActivity, nodeID)
+			leftNodes = append(leftNodes, &ActivityEvent{NodeID: nodeID, Time: now})
+		}
+	}
+	a.mutex.Unlock()
+
+	for _, activityEvent := range leftNodes {
+		a.Events.NodeLeft.Trigger(activityEvent)
+	}
+}
+
+// IsActive returns true if the given node is part of the set of active nodes.
+func (a *ActivityTracker) IsActive(nodeID identity.ID) bool {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	_, active := a.lastActivity[nodeID]
+	return active
+}
+
+// ActiveNodes returns the set of active nodes.
+func (a *ActivityTracker) ActiveNodes() (activeNodes []identity.ID) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	activeNodes = make([]identity.ID, 0, len(a.lastActivity))
+	for nodeID := range a.lastActivity {
+		activeNodes = append(activeNodes, nodeID)
+	}
+	return
+}
+
+// ActivityTrackerEvents represents the events of an ActivityTracker.
+type ActivityTrackerEvents struct {
+	// Fired when a node joined the set of active nodes.
+	NodeJoined *events.Event
+	// Fired when a node left the set of active nodes.
+	NodeLeft *events.Event
+}
+
+// ActivityEvent is the struct that is passed along with the events of an ActivityTracker.
+type ActivityEvent struct {
+	NodeID identity.ID
+	Time   time.Time
+}
+
+func activityEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(ev *ActivityEvent))(params[0].(*ActivityEvent))
+}
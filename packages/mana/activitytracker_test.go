@@ -0,0 +1,44 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivityTracker(t *testing.T) {
+	activityTracker := NewActivityTracker(time.Minute)
+
+	var joined, left []identity.ID
+	activityTracker.Events.NodeJoined.Attach(events.NewClosure(func(ev *ActivityEvent) {
+		joined = append(joined, ev.NodeID)
+	}))
+	activityTracker.Events.NodeLeft.Attach(events.NewClosure(func(ev *ActivityEvent) {
+		left = append(left, ev.NodeID)
+	}))
+
+	now := time.Now()
+	nodeID1, nodeID2, nodeID3 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+
+	activityTracker.Update(nodeID1, now.Add(-30*time.Second), now)
+	activityTracker.Update(nodeID1, now, now)
+	activityTracker.Update(nodeID2, now.Add(-50*time.Second), now)
+	// messages that are older than the window do not count
+	activityTracker.Update(nodeID3, now.Add(-2*time.Minute), now)
+
+	assert.Equal(t, []identity.ID{nodeID1, nodeID2}, joined)
+	assert.ElementsMatch(t, []identity.ID{nodeID1, nodeID2}, activityTracker.ActiveNodes())
+	assert.False(t, activityTracker.IsActive(nodeID3))
+
+	activityTracker.Prune(now.Add(30 * time.Second))
+	assert.Equal(t, []identity.ID{nodeID2}, left)
+	assert.True(t, activityTracker.IsActive(nodeID1))
+	assert.False(t, activityTracker.IsActive(nodeID2))
+
+	activityTracker.Prune(now.Add(time.Minute))
+	assert.Equal(t, []identity.ID{nodeID2, nodeID1}, left)
+	assert.Empty(t, activityTracker.ActiveNodes())
+}
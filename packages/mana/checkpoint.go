@@ -0,0 +1,286 @@
+package mana
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region Checkpoint ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Checkpoint records the state of the persisted base mana vectors. It holds the index and the ID of the last confirmed
+// transaction that was booked into the vectors when they were written, and the names of the ManaFunctions that the
+// vectors were computed with.
+type Checkpoint struct {
+	LastBookedIndex         uint64
+	LastBookedTransactionID ledgerstate.TransactionID
+	Timestamp               time.Time
+	AccessManaFunction      string
+	ConsensusManaFunction   string
+}
+
+// CheckpointFromBytes unmarshals a Checkpoint from a sequence of bytes.
+func CheckpointFromBytes(bytes []byte) (checkpoint *Checkpoint, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	checkpoint, err = CheckpointFromMarshalUtil(marshalUtil)
+	consumedBytes = marshalUtil.ReadOffset()
+	return
+}
+
+// CheckpointFromMarshalUtil unmarshals a Checkpoint using a MarshalUtil (for easier unmarshaling).
+func CheckpointFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (checkpoint *Checkpoint, err error) {
+	checkpoint = &Checkpoint{}
+	if checkpoint.LastBookedIndex, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, xerrors.Errorf("failed to parse last booked index: %w", err)
+	}
+	if checkpoint.LastBookedTransactionID, err = ledgerstate.TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse last booked transaction ID: %w", err)
+	}
+	if checkpoint.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		return nil, xerrors.Errorf("failed to parse timestamp: %w", err)
+	}
+	if checkpoint.AccessManaFunction, err = readManaFunctionName(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse access mana function: %w", err)
+	}
+	if checkpoint.ConsensusManaFunction, err = readManaFunctionName(marshalUtil); err != nil {
+		return nil, xerrors.Errorf("failed to parse consensus mana function: %w", err)
+	}
+	return
+}
+
+// readManaFunctionName reads a length prefixed name of a ManaFunction using a MarshalUtil.
+func readManaFunctionName(marshalUtil *marshalutil.MarshalUtil) (name string, err error) {
+	length, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return "", err
+	}
+	nameBytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(nameBytes), nil
+}
+
+// Bytes marshals the Checkpoint into a sequence of bytes.
+func (c *Checkpoint) Bytes() []byte {
+	return marshalutil.New(marshalutil.Uint64Size + ledgerstate.TransactionIDLength + marshalutil.TimeSize +
+		2*marshalutil.Uint8Size + len(c.AccessManaFunction) + len(c.ConsensusManaFunction)).
+		WriteUint64(c.LastBookedIndex).
+		Write(c.LastBookedTransactionID).
+		WriteTime(c.Timestamp).
+		WriteUint8(uint8(len(c.AccessManaFunction))).
+		WriteBytes([]byte(c.AccessManaFunction)).
+		WriteUint8(uint8(len(c.ConsensusManaFunction))).
+		WriteBytes([]byte(c.ConsensusManaFunction)).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region VectorStorage ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// VectorStorage persists the base mana vectors together with a Checkpoint. It also keeps a log of the confirmed
+// transactions that were booked into the vectors since the last Checkpoint, so the vectors can be restored after a
+// crash by replaying the log.
+type VectorStorage struct {
+	store      kvstore.KVStore
+	nextIndex  uint64
+	indexMutex sync.Mutex
+}
+
+// NewVectorStorage creates a new VectorStorage that is persisted in the given KVStore.
+func NewVectorStorage(store kvstore.KVStore) (vectorStorage *VectorStorage, err error) {
+	vectorStorage = &VectorStorage{
+		store: store,
+	}
+
+	checkpoint, err := vectorStorage.Checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	vectorStorage.nextIndex = checkpoint.LastBookedIndex + 1
+
+	if err = vectorStorage.ForEachBookedTransaction(checkpoint.LastBookedIndex, func(index uint64, _ ledgerstate.TransactionID) bool {
+		vectorStorage.nextIndex = index + 1
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	return vectorStorage, nil
+}
+
+// LogBookedTransaction appends the given confirmed transaction to the log of booked transactions and returns its index.
+func (v *VectorStorage) LogBookedTransaction(transactionID ledgerstate.TransactionID) (index uint64, err error) {
+	v.indexMutex.Lock()
+	defer v.indexMutex.Unlock()
+
+	index = v.nextIndex
+	if err = v.store.Set(bookedTransactionKey(index), transactionID.Bytes()); err != nil {
+		return 0, xerrors.Errorf("failed to log booked transaction with %s: %w", transactionID, err)
+	}
+	v.nextIndex++
+
+	return index, nil
+}
+
+// ForEachBookedTransaction iterates over the logged transactions that were booked after the given index in the order
+// they were booked. The indexes of the log are consecutive, so the entries are loaded one after the other (starting
+// after the pruned part that is covered by the Checkpoint) until the end of the log is reached.
+func (v *VectorStorage) ForEachBookedTransaction(afterIndex uint64, consumer func(index uint64, transactionID ledgerstate.TransactionID) bool) (err error) {
+	checkpoint, err := v.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if checkpoint.LastBookedIndex > afterIndex {
+		afterIndex = checkpoint.LastBookedIndex
+	}
+
+	for index := afterIndex + 1; ; index++ {
+		transactionIDBytes, getErr := v.store.Get(bookedTransactionKey(index))
+		if getErr != nil {
+			if errors.Is(getErr, kvstore.ErrKeyNotFound) {
+				return nil
+			}
+			return xerrors.Errorf("failed to load logged transaction with index %d: %w", index, getErr)
+		}
+
+		transactionID, _, parseErr := ledgerstate.TransactionIDFromBytes(transactionIDBytes)
+		if parseErr != nil {
+			return xerrors.Errorf("failed to parse logged transaction with index %d: %w", index, parseErr)
+		}
+		if !consumer(index, transactionID) {
+			return nil
+		}
+	}
+}
+
+// Checkpoint returns the latest Checkpoint. It returns an empty Checkpoint if the vectors were never persisted.
+func (v *VectorStorage) Checkpoint() (checkpoint *Checkpoint, err error) {
+	checkpointBytes, err := v.store.Get([]byte{PrefixCheckpoint})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return &Checkpoint{}, nil
+		}
+		return nil, xerrors.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	if checkpoint, _, err = CheckpointFromBytes(checkpointBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// WriteCheckpoint atomically replaces the persisted base mana vectors with the given ones and stores the Checkpoint
+// that describes them. Logged transactions that are covered by the Checkpoint are removed.
+func (v *VectorStorage) WriteCheckpoint(baseManaVectors map[Type]BaseManaVector, checkpoint *Checkpoint) (err error) {
+	batch := v.store.Batched()
+	defer func() {
+		if err != nil {
+			batch.Cancel()
+		}
+	}()
+
+	for vectorType, baseManaVector := range baseManaVectors {
+		prefix, prefixErr := vectorStoragePrefix(vectorType)
+		if prefixErr != nil {
+			return prefixErr
+		}
+
+		// nodes might have been removed from the vector since it was written
+		if err = v.store.IterateKeys([]byte{prefix}, func(key kvstore.Key) bool {
+			err = batch.Delete(byteutils.ConcatBytes(key))
+			return err == nil
+		}); err != nil {
+			return xerrors.Errorf("failed to remove persisted %s mana vector: %w", vectorType.String(), err)
+		}
+
+		for _, persistableBaseMana := range baseManaVector.ToPersistables() {
+			if err = batch.Set(byteutils.ConcatBytes([]byte{prefix}, persistableBaseMana.ObjectStorageKey()), persistableBaseMana.ObjectStorageValue()); err != nil {
+				return xerrors.Errorf("failed to persist %s mana vector: %w", vectorType.String(), err)
+			}
+		}
+	}
+
+	if err = batch.Set([]byte{PrefixCheckpoint}, checkpoint.Bytes()); err != nil {
+		return xerrors.Errorf("failed to persist checkpoint: %w", err)
+	}
+
+	if err = v.store.IterateKeys([]byte{PrefixBookedTransactionLog}, func(key kvstore.Key) bool {
+		if binary.BigEndian.Uint64(key[1:]) <= checkpoint.LastBookedIndex {
+			err = batch.Delete(byteutils.ConcatBytes(key))
+		}
+		return err == nil
+	}); err != nil {
+		return xerrors.Errorf("failed to prune booked transaction log: %w", err)
+	}
+
+	return batch.Commit()
+}
+
+// LoadVectors fills the given base mana vectors with the values that were persisted by the last Checkpoint.
+func (v *VectorStorage) LoadVectors(baseManaVectors map[Type]BaseManaVector) (err error) {
+	for vectorType, baseManaVector := range baseManaVectors {
+		prefix, prefixErr := vectorStoragePrefix(vectorType)
+		if prefixErr != nil {
+			return prefixErr
+		}
+
+		if iterateErr := v.store.Iterate([]byte{prefix}, func(key kvstore.Key, value kvstore.Value) bool {
+			persistableBaseMana, _, parseErr := FromBytes(value)
+			if parseErr != nil {
+				err = xerrors.Errorf("failed to parse persisted %s mana: %w", vectorType.String(), parseErr)
+				return false
+			}
+			if err = baseManaVector.FromPersistable(persistableBaseMana); err != nil {
+				err = xerrors.Errorf("failed to restore %s mana vector: %w", vectorType.String(), err)
+				return false
+			}
+			return true
+		}); iterateErr != nil {
+			return xerrors.Errorf("failed to iterate persisted %s mana vector: %w", vectorType.String(), iterateErr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// vectorStoragePrefix returns the storage prefix of the base mana vector with the given type.
+func vectorStoragePrefix(vectorType Type) (prefix byte, err error) {
+	switch vectorType {
+	case AccessMana:
+		return PrefixAccess, nil
+	case ConsensusMana:
+		return PrefixConsensus, nil
+	case ResearchAccess:
+		return PrefixAccessResearch, nil
+	case ResearchConsensus:
+		return PrefixConsensusResearch, nil
+	default:
+		return 0, xerrors.Errorf("no storage for mana vector with type %d: %w", vectorType, ErrUnknownManaType)
+	}
+}
+
+// bookedTransactionKey returns the storage key of the logged transaction with the given index (big endian keeps the
+// log ordered).
+func bookedTransactionKey(index uint64) []byte {
+	key := make([]byte, 1+marshalutil.Uint64Size)
+	key[0] = PrefixBookedTransactionLog
+	binary.BigEndian.PutUint64(key[1:], index)
+
+	return key
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,139 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestCheckpoint_Bytes(t *testing.T) {
+	checkpoint := &Checkpoint{
+		LastBookedIndex:         42,
+		LastBookedTransactionID: randomTxID(),
+		Timestamp:               time.Now(),
+		AccessManaFunction:      EMAManaFunctionName,
+		ConsensusManaFunction:   InstantManaFunctionName,
+	}
+
+	restoredCheckpoint, _, err := CheckpointFromBytes(checkpoint.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.LastBookedIndex, restoredCheckpoint.LastBookedIndex)
+	assert.Equal(t, checkpoint.LastBookedTransactionID, restoredCheckpoint.LastBookedTransactionID)
+	assert.True(t, checkpoint.Timestamp.Equal(restoredCheckpoint.Timestamp))
+	assert.Equal(t, checkpoint.AccessManaFunction, restoredCheckpoint.AccessManaFunction)
+	assert.Equal(t, checkpoint.ConsensusManaFunction, restoredCheckpoint.ConsensusManaFunction)
+}
+
+func TestVectorStorage_WriteCheckpoint(t *testing.T) {
+	store := mapdb.NewMapDB()
+	vectorStorage, err := NewVectorStorage(store)
+	require.NoError(t, err)
+
+	checkpoint, err := vectorStorage.Checkpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), checkpoint.LastBookedIndex)
+
+	// log three booked transactions
+	transactionIDs := []ledgerstate.TransactionID{randomTxID(), randomTxID(), randomTxID()}
+	for i, transactionID := range transactionIDs {
+		index, logErr := vectorStorage.LogBookedTransaction(transactionID)
+		require.NoError(t, logErr)
+		assert.Equal(t, uint64(i+1), index)
+	}
+
+	// checkpoint a vector that covers the first two transactions
+	nodeID1, nodeID2 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	accessVector := newTestAccessVector(t, map[identity.ID]float64{nodeID1: 10, nodeID2: 20})
+	require.NoError(t, vectorStorage.WriteCheckpoint(map[Type]BaseManaVector{AccessMana: accessVector}, &Checkpoint{
+		LastBookedIndex:         2,
+		LastBookedTransactionID: transactionIDs[1],
+		Timestamp:               time.Now(),
+	}))
+
+	// a restarted node only has to replay the third transaction
+	vectorStorage, err = NewVectorStorage(store)
+	require.NoError(t, err)
+	checkpoint, err = vectorStorage.Checkpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), checkpoint.LastBookedIndex)
+	assert.Equal(t, transactionIDs[1], checkpoint.LastBookedTransactionID)
+
+	replayedTransactionIDs := make([]ledgerstate.TransactionID, 0)
+	require.NoError(t, vectorStorage.ForEachBookedTransaction(0, func(index uint64, transactionID ledgerstate.TransactionID) bool {
+		replayedTransactionIDs = append(replayedTransactionIDs, transactionID)
+		return true
+	}))
+	assert.Equal(t, []ledgerstate.TransactionID{transactionIDs[2]}, replayedTransactionIDs)
+
+	index, err := vectorStorage.LogBookedTransaction(randomTxID())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), index)
+
+	restoredVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	require.NoError(t, vectorStorage.LoadVectors(map[Type]BaseManaVector{AccessMana: restoredVector}))
+	assert.Equal(t, 2, restoredVector.Size())
+	assert.True(t, restoredVector.Has(nodeID1))
+	assert.True(t, restoredVector.Has(nodeID2))
+
+	// nodes that left the vector are removed from the storage as well
+	accessVector = newTestAccessVector(t, map[identity.ID]float64{nodeID2: 20})
+	require.NoError(t, vectorStorage.WriteCheckpoint(map[Type]BaseManaVector{AccessMana: accessVector}, &Checkpoint{
+		LastBookedIndex: 4,
+		Timestamp:       time.Now(),
+	}))
+
+	restoredVector, err = NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	require.NoError(t, vectorStorage.LoadVectors(map[Type]BaseManaVector{AccessMana: restoredVector}))
+	assert.Equal(t, 1, restoredVector.Size())
+	assert.False(t, restoredVector.Has(nodeID1))
+
+	require.NoError(t, vectorStorage.ForEachBookedTransaction(0, func(uint64, ledgerstate.TransactionID) bool {
+		t.Fatal("the log should have been pruned")
+		return false
+	}))
+}
+
+func TestVectorStorage_ForEachBookedTransaction(t *testing.T) {
+	vectorStorage, err := NewVectorStorage(mapdb.NewMapDB())
+	require.NoError(t, err)
+
+	transactionIDs := make([]ledgerstate.TransactionID, 300)
+	for i := range transactionIDs {
+		transactionIDs[i] = randomTxID()
+		_, err = vectorStorage.LogBookedTransaction(transactionIDs[i])
+		require.NoError(t, err)
+	}
+
+	// the log is iterated in the order of the bookings and stops when the consumer returns false
+	replayedTransactionIDs := make([]ledgerstate.TransactionID, 0)
+	require.NoError(t, vectorStorage.ForEachBookedTransaction(100, func(index uint64, transactionID ledgerstate.TransactionID) bool {
+		assert.Equal(t, uint64(101+len(replayedTransactionIDs)), index)
+		replayedTransactionIDs = append(replayedTransactionIDs, transactionID)
+		return index < 250
+	}))
+	assert.Equal(t, transactionIDs[100:250], replayedTransactionIDs)
+}
+
+func newTestAccessVector(t *testing.T, values map[identity.ID]float64) BaseManaVector {
+	vector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	for nodeID, value := range values {
+		require.NoError(t, vector.FromPersistable(&PersistableBaseMana{
+			ManaType:        AccessMana,
+			BaseValues:      []float64{value},
+			EffectiveValues: []float64{value},
+			LastUpdated:     time.Now(),
+			NodeID:          nodeID,
+		}))
+	}
+
+	return vector
+}
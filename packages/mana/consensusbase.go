@@ -34,8 +34,7 @@ func (c *ConsensusBaseMana) updateEBM1(n time.Duration) {
 		return
 	}
 	// normal update
-	c.EffectiveBaseMana1 = math.Pow(math.E, -emaCoeff1*n.Seconds())*c.EffectiveBaseMana1 +
-		(1-math.Pow(math.E, -emaCoeff1*n.Seconds()))*c.BaseMana1
+	c.EffectiveBaseMana1 = GetManaFunction(ConsensusMana).Effective(c.BaseMana1, c.EffectiveBaseMana1, n)
 }
 
 func (c *ConsensusBaseMana) revoke(amount float64, t time.Time) error {
@@ -56,7 +55,7 @@ func (c *ConsensusBaseMana) revoke(amount float64, t time.Time) error {
 		// revoke BM1 at `t`
 		c.BaseMana1 -= amount
 		// update EBM1 to `bm.LastUpdated`
-		EBM1Compensation := amount * (1 - math.Pow(math.E, -emaCoeff1*n.Seconds()))
+		EBM1Compensation := GetManaFunction(ConsensusMana).Effective(amount, 0, n)
 		if c.EffectiveBaseMana1-EBM1Compensation < 0.0 {
 			return ErrEffBaseManaNegative
 		}
@@ -82,7 +81,7 @@ func (c *ConsensusBaseMana) pledge(tx *TxInfo) (pledged float64) {
 		// update BM1 at `t`
 		c.BaseMana1 += pledged
 		// update EBM1 to `bm.LastUpdated`
-		c.EffectiveBaseMana1 += pledged * (1 - math.Pow(math.E, -emaCoeff1*n.Seconds()))
+		c.EffectiveBaseMana1 += GetManaFunction(ConsensusMana).Effective(pledged, 0, n)
 	}
 	return pledged
 }
@@ -11,6 +11,8 @@ var (
 	ErrEffBaseManaNegative = errors.New("effective base mana should never be negative")
 	// ErrUnknownManaType is returned if mana type could not be identified.
 	ErrUnknownManaType = errors.New("unknown mana type")
+	// ErrUnknownManaFunction is returned if the name of a mana function could not be identified.
+	ErrUnknownManaFunction = errors.New("unknown mana function")
 	// ErrNodeNotFoundInBaseManaVector is returned if the node is not found in the base mana vector.
 	ErrNodeNotFoundInBaseManaVector = errors.New("node not present in base mana vector")
 	// ErrInvalidWeightParameter is returned if an invalid weight parameter is passed.
@@ -0,0 +1,158 @@
+package mana
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// EMAManaFunctionName is the name of the default ManaFunction. Access mana accrues and decays exponentially and the
+	// effective mana of both types is an exponential moving average of the base mana.
+	EMAManaFunctionName = "ema"
+
+	// InstantManaFunctionName is the name of the ManaFunction that accrues the full amount of funds immediately, never
+	// decays and whose effective mana always equals the base mana.
+	InstantManaFunctionName = "instant"
+)
+
+var (
+	manaFunctions = map[Type]ManaFunction{
+		AccessMana:    &emaAccessManaFunction{},
+		ConsensusMana: &emaConsensusManaFunction{},
+	}
+	manaFunctionsMutex sync.RWMutex
+)
+
+// ManaFunction defines how base mana accrues and decays over time and how the effective base mana follows it.
+// Consensus base mana always equals the pledged balance, so that it can be revoked again, which is why only Effective is
+// used for consensus mana.
+type ManaFunction interface {
+	// Name returns the name that the ManaFunction is configured by.
+	Name() string
+	// Accrue returns the base mana that funds of the given amount accrued while they were held for the given duration.
+	Accrue(amount float64, holdingTime time.Duration) float64
+	// Decay returns what is left of the given base mana after the given duration.
+	Decay(baseMana float64, n time.Duration) float64
+	// Effective returns the effective base mana after the given duration. It receives the base mana at the end and the
+	// effective base mana at the start of the duration.
+	Effective(baseMana float64, effectiveBaseMana float64, n time.Duration) float64
+}
+
+// NewManaFunction returns the ManaFunction with the given name for the given type of mana.
+func NewManaFunction(name string, manaType Type) (ManaFunction, error) {
+	if manaType != AccessMana && manaType != ConsensusMana {
+		return nil, xerrors.Errorf("no mana function for mana type %s: %w", manaType.String(), ErrUnknownManaType)
+	}
+
+	switch name {
+	case EMAManaFunctionName:
+		if manaType == AccessMana {
+			return &emaAccessManaFunction{}, nil
+		}
+		return &emaConsensusManaFunction{}, nil
+	case InstantManaFunctionName:
+		return &instantManaFunction{}, nil
+	default:
+		return nil, xerrors.Errorf("mana function %s: %w", name, ErrUnknownManaFunction)
+	}
+}
+
+// SetManaFunction sets the ManaFunction that is used for the given type of mana. The research vectors are composed of
+// access and consensus base mana, so they use the functions of both types. The consensus mana function has to be the
+// same on all nodes of the network, as they would disagree on the weights of the opinions otherwise.
+func SetManaFunction(manaType Type, manaFunction ManaFunction) error {
+	if manaType != AccessMana && manaType != ConsensusMana {
+		return xerrors.Errorf("can not set mana function for mana type %s: %w", manaType.String(), ErrUnknownManaType)
+	}
+
+	manaFunctionsMutex.Lock()
+	defer manaFunctionsMutex.Unlock()
+	manaFunctions[manaType] = manaFunction
+	return nil
+}
+
+// GetManaFunction returns the ManaFunction that is used for the given type of mana.
+func GetManaFunction(manaType Type) ManaFunction {
+	manaFunctionsMutex.RLock()
+	defer manaFunctionsMutex.RUnlock()
+	return manaFunctions[manaType]
+}
+
+// emaAccessManaFunction is the default ManaFunction of access mana. Funds accrue base mana exponentially while they are
+// held, base mana decays exponentially and the effective base mana is its exponential moving average.
+type emaAccessManaFunction struct{}
+
+// Name returns the name of the ManaFunction.
+func (e *emaAccessManaFunction) Name() string {
+	return EMAManaFunctionName
+}
+
+// Accrue returns the base mana that funds of the given amount accrued while they were held for the given duration.
+func (e *emaAccessManaFunction) Accrue(amount float64, holdingTime time.Duration) float64 {
+	return amount * (1 - math.Pow(math.E, -Decay*holdingTime.Seconds()))
+}
+
+// Decay returns what is left of the given base mana after the given duration.
+func (e *emaAccessManaFunction) Decay(baseMana float64, n time.Duration) float64 {
+	return baseMana * math.Pow(math.E, -Decay*n.Seconds())
+}
+
+// Effective returns the effective base mana after the given duration.
+func (e *emaAccessManaFunction) Effective(baseMana float64, effectiveBaseMana float64, n time.Duration) float64 {
+	if emaCoeff2 != Decay {
+		return math.Pow(math.E, -emaCoeff2*n.Seconds())*effectiveBaseMana +
+			(math.Pow(math.E, -Decay*n.Seconds())-math.Pow(math.E, -emaCoeff2*n.Seconds()))/
+				(emaCoeff2-Decay)*emaCoeff2/math.Pow(math.E, -Decay*n.Seconds())*baseMana
+	}
+	return math.Pow(math.E, -Decay*n.Seconds())*effectiveBaseMana + Decay*n.Seconds()*baseMana
+}
+
+// emaConsensusManaFunction is the default ManaFunction of consensus mana. The effective base mana is the exponential
+// moving average of the base mana.
+type emaConsensusManaFunction struct{}
+
+// Name returns the name of the ManaFunction.
+func (e *emaConsensusManaFunction) Name() string {
+	return EMAManaFunctionName
+}
+
+// Accrue returns the amount of the funds, as they accrue base mana immediately.
+func (e *emaConsensusManaFunction) Accrue(amount float64, _ time.Duration) float64 {
+	return amount
+}
+
+// Decay returns the given base mana, as it does not decay.
+func (e *emaConsensusManaFunction) Decay(baseMana float64, _ time.Duration) float64 {
+	return baseMana
+}
+
+// Effective returns the effective base mana after the given duration.
+func (e *emaConsensusManaFunction) Effective(baseMana float64, effectiveBaseMana float64, n time.Duration) float64 {
+	return math.Pow(math.E, -emaCoeff1*n.Seconds())*effectiveBaseMana + (1-math.Pow(math.E, -emaCoeff1*n.Seconds()))*baseMana
+}
+
+// instantManaFunction is a ManaFunction without any dynamics, which serves as a baseline for comparisons.
+type instantManaFunction struct{}
+
+// Name returns the name of the ManaFunction.
+func (i *instantManaFunction) Name() string {
+	return InstantManaFunctionName
+}
+
+// Accrue returns the amount of the funds, as they accrue base mana immediately.
+func (i *instantManaFunction) Accrue(amount float64, _ time.Duration) float64 {
+	return amount
+}
+
+// Decay returns the given base mana, as it does not decay.
+func (i *instantManaFunction) Decay(baseMana float64, _ time.Duration) float64 {
+	return baseMana
+}
+
+// Effective returns the base mana, as the effective base mana follows it immediately.
+func (i *instantManaFunction) Effective(baseMana float64, _ float64, _ time.Duration) float64 {
+	return baseMana
+}
@@ -0,0 +1,57 @@
+package mana
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManaFunction(t *testing.T) {
+	accessManaFunction, err := NewManaFunction(EMAManaFunctionName, AccessMana)
+	require.NoError(t, err)
+	assert.IsType(t, &emaAccessManaFunction{}, accessManaFunction)
+
+	consensusManaFunction, err := NewManaFunction(EMAManaFunctionName, ConsensusMana)
+	require.NoError(t, err)
+	assert.IsType(t, &emaConsensusManaFunction{}, consensusManaFunction)
+
+	_, err = NewManaFunction("unknown", AccessMana)
+	assert.True(t, errors.Is(err, ErrUnknownManaFunction))
+
+	_, err = NewManaFunction(EMAManaFunctionName, WeightedMana)
+	assert.True(t, errors.Is(err, ErrUnknownManaType))
+	assert.True(t, errors.Is(SetManaFunction(ResearchAccess, &instantManaFunction{}), ErrUnknownManaType))
+}
+
+func TestSetManaFunction(t *testing.T) {
+	defaultManaFunction := GetManaFunction(AccessMana)
+	defer func() {
+		require.NoError(t, SetManaFunction(AccessMana, defaultManaFunction))
+	}()
+	require.NoError(t, SetManaFunction(AccessMana, &instantManaFunction{}))
+
+	baseTime := time.Now()
+	bm := &AccessBaseMana{
+		BaseMana2:          1.0,
+		EffectiveBaseMana2: 0.0,
+		LastUpdated:        baseTime,
+	}
+
+	// funds accrue their full amount and nothing decays
+	pledged := bm.pledge(&TxInfo{
+		TimeStamp: baseTime.Add(time.Hour),
+		InputInfos: []InputInfo{
+			{TimeStamp: baseTime, Amount: 10.0},
+		},
+	})
+	assert.Equal(t, 10.0, pledged)
+	assert.Equal(t, 11.0, bm.BaseMana2)
+	assert.Equal(t, 1.0, bm.EffectiveBaseMana2)
+
+	require.NoError(t, bm.update(baseTime.Add(2*time.Hour)))
+	assert.Equal(t, 11.0, bm.BaseMana2)
+	assert.Equal(t, 11.0, bm.EffectiveBaseMana2)
+}
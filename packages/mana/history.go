@@ -0,0 +1,138 @@
+package mana
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/xerrors"
+)
+
+// HistorySample is the effective mana of a node at a point in time.
+type HistorySample struct {
+	Time time.Time
+	Mana float64
+}
+
+// BuildHistory samples the effective mana of the given nodes every interval between start and end (both included) by
+// replaying the given events on top of the given base mana vector, which gets modified in the process. Events need to
+// be sorted and must not be contained in the vector already. If no nodes are given, the history of all nodes that
+// appear in the vector or in the events is built.
+func BuildHistory(baseManaVector BaseManaVector, eventLogs EventSlice, nodeIDs []identity.ID, start, end time.Time, interval time.Duration) (history map[identity.ID][]*HistorySample, err error) {
+	if interval <= 0 {
+		return nil, xerrors.Errorf("sampling interval has to be positive: %s", interval)
+	}
+	if end.Before(start) {
+		return nil, xerrors.Errorf("end of the history %s is before its start %s", end, start)
+	}
+
+	var replay historyReplay
+	switch vector := baseManaVector.(type) {
+	case *AccessBaseManaVector:
+		replay = &accessHistoryReplay{vector: vector}
+	case *ConsensusBaseManaVector:
+		replay = &consensusHistoryReplay{vector: vector}
+	default:
+		return nil, xerrors.Errorf("can not build history of mana type %s: %w", baseManaVector.Type().String(), ErrUnknownManaType)
+	}
+
+	lookup := make(map[identity.ID]bool)
+	history = make(map[identity.ID][]*HistorySample)
+	for _, nodeID := range nodeIDs {
+		lookup[nodeID] = true
+		history[nodeID] = make([]*HistorySample, 0)
+	}
+
+	sampleCount := 0
+	for sampleTime := start; !sampleTime.After(end); sampleTime = sampleTime.Add(interval) {
+		for len(eventLogs) > 0 && !eventLogs[0].Timestamp().After(sampleTime) {
+			if err = replay.apply(eventLogs[0]); err != nil {
+				return nil, xerrors.Errorf("failed to replay mana event: %w", err)
+			}
+			eventLogs = eventLogs[1:]
+		}
+
+		samples := replay.sample(sampleTime)
+		for nodeID := range samples {
+			if len(nodeIDs) > 0 && !lookup[nodeID] {
+				continue
+			}
+			if _, exists := history[nodeID]; !exists {
+				// nodes that appear later on had no mana at the previous samples
+				history[nodeID] = make([]*HistorySample, sampleCount)
+				for i := range history[nodeID] {
+					history[nodeID][i] = &HistorySample{Time: start.Add(time.Duration(i) * interval)}
+				}
+			}
+		}
+		for nodeID := range history {
+			history[nodeID] = append(history[nodeID], &HistorySample{Time: sampleTime, Mana: samples[nodeID]})
+		}
+		sampleCount++
+	}
+
+	return history, nil
+}
+
+// historyReplay is the type specific part of replaying mana events on top of a base mana vector.
+type historyReplay interface {
+	apply(ev Event) error
+	sample(t time.Time) NodeMap
+}
+
+// accessHistoryReplay replays access mana events. The pledged events of access mana contain the base mana that was
+// accrued by the pledge, so it is added to the base mana directly.
+type accessHistoryReplay struct {
+	vector *AccessBaseManaVector
+}
+
+func (a *accessHistoryReplay) apply(ev Event) error {
+	pledgedEvent, ok := ev.(*PledgedEvent)
+	if !ok {
+		return xerrors.Errorf("access mana can not be revoked: %w", ErrUnknownManaEvent)
+	}
+
+	if a.vector.vector == nil {
+		a.vector.vector = make(map[identity.ID]*AccessBaseMana)
+	}
+	baseMana, exists := a.vector.vector[pledgedEvent.NodeID]
+	if !exists {
+		baseMana = &AccessBaseMana{LastUpdated: pledgedEvent.Time}
+		a.vector.vector[pledgedEvent.NodeID] = baseMana
+	}
+	if pledgedEvent.Time.After(baseMana.LastUpdated) {
+		_ = baseMana.update(pledgedEvent.Time)
+	}
+	baseMana.BaseMana2 += pledgedEvent.Amount
+	return nil
+}
+
+func (a *accessHistoryReplay) sample(t time.Time) (samples NodeMap) {
+	samples = make(NodeMap)
+	for nodeID, baseMana := range a.vector.vector {
+		if t.After(baseMana.LastUpdated) {
+			_ = baseMana.update(t)
+		}
+		samples[nodeID] = baseMana.EffectiveBaseMana2
+	}
+	return
+}
+
+// consensusHistoryReplay replays consensus mana events.
+type consensusHistoryReplay struct {
+	vector *ConsensusBaseManaVector
+}
+
+func (c *consensusHistoryReplay) apply(ev Event) error {
+	return c.vector.BuildPastBaseVector([]Event{ev}, ev.Timestamp())
+}
+
+func (c *consensusHistoryReplay) sample(t time.Time) (samples NodeMap) {
+	samples = make(NodeMap)
+	for nodeID, baseMana := range c.vector.vector {
+		if t.After(baseMana.LastUpdated) {
+			_ = baseMana.update(t)
+		}
+		samples[nodeID] = baseMana.EffectiveBaseMana1
+	}
+	return
+}
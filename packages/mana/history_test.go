@@ -0,0 +1,84 @@
+package mana
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildHistory_Consensus(t *testing.T) {
+	baseManaVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+
+	start := time.Now()
+	nodeID1, nodeID2 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	eventLogs := EventSlice{
+		&PledgedEvent{NodeID: nodeID1, Amount: 100, Time: start.Add(-time.Hour), ManaType: ConsensusMana, TransactionID: randomTxID()},
+		&PledgedEvent{NodeID: nodeID2, Amount: 50, Time: start.Add(90 * time.Second), ManaType: ConsensusMana, TransactionID: randomTxID()},
+		&RevokedEvent{NodeID: nodeID1, Amount: 100, Time: start.Add(90 * time.Second), ManaType: ConsensusMana, TransactionID: randomTxID()},
+	}
+	eventLogs.Sort()
+
+	history, err := BuildHistory(baseManaVector, eventLogs, nil, start, start.Add(3*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Len(t, history[nodeID1], 4)
+	require.Len(t, history[nodeID2], 4)
+
+	for i, sample := range history[nodeID1] {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Minute), sample.Time)
+		assert.Equal(t, history[nodeID2][i].Time, sample.Time)
+	}
+	// the effective mana of node1 converges towards its base mana and drops after the revoke
+	assert.Greater(t, history[nodeID1][1].Mana, history[nodeID1][0].Mana)
+	assert.Less(t, history[nodeID1][3].Mana, history[nodeID1][2].Mana)
+	// node2 had no mana before its pledge
+	assert.Equal(t, 0.0, history[nodeID2][0].Mana)
+	assert.Equal(t, 0.0, history[nodeID2][1].Mana)
+	assert.Greater(t, history[nodeID2][2].Mana, 0.0)
+	assert.Greater(t, history[nodeID2][3].Mana, history[nodeID2][2].Mana)
+}
+
+func TestBuildHistory_Access(t *testing.T) {
+	baseManaVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+
+	start := time.Now()
+	nodeID1, nodeID2 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	eventLogs := EventSlice{
+		&PledgedEvent{NodeID: nodeID1, Amount: 100, Time: start, ManaType: AccessMana, TransactionID: randomTxID()},
+		&PledgedEvent{NodeID: nodeID2, Amount: 100, Time: start, ManaType: AccessMana, TransactionID: randomTxID()},
+	}
+
+	history, err := BuildHistory(baseManaVector, eventLogs, []identity.ID{nodeID1}, start, start.Add(2*time.Hour), time.Hour)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Len(t, history[nodeID1], 3)
+	assert.Equal(t, 0.0, history[nodeID1][0].Mana)
+	assert.Greater(t, history[nodeID1][1].Mana, 0.0)
+
+	_, err = BuildHistory(baseManaVector, EventSlice{
+		&RevokedEvent{NodeID: nodeID1, Amount: 100, Time: start, ManaType: AccessMana, TransactionID: randomTxID()},
+	}, nil, start, start, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestBuildHistory_InvalidRange(t *testing.T) {
+	baseManaVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = BuildHistory(baseManaVector, nil, nil, start, start.Add(-time.Minute), time.Minute)
+	assert.Error(t, err)
+	_, err = BuildHistory(baseManaVector, nil, nil, start, start.Add(time.Minute), 0)
+	assert.Error(t, err)
+
+	researchVector, err := NewResearchBaseManaVector(WeightedMana, AccessMana, Mixed)
+	require.NoError(t, err)
+	_, err = BuildHistory(researchVector, nil, nil, start, start.Add(time.Minute), time.Minute)
+	assert.True(t, errors.Is(err, ErrUnknownManaType))
+}
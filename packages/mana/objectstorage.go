@@ -21,4 +21,13 @@ const (
 
 	// PrefixConsensusPastMetadata is the storage prefix for consensus mana past vector metadata storage.
 	PrefixConsensusPastMetadata
+
+	// PrefixCheckpoint is the storage prefix for the checkpoint of the persisted mana vectors.
+	PrefixCheckpoint
+
+	// PrefixBookedTransactionLog is the storage prefix for the log of confirmed transactions booked since the checkpoint.
+	PrefixBookedTransactionLog
+
+	// PrefixAccessEventStorage is the storage prefix for access mana event storage.
+	PrefixAccessEventStorage
 )
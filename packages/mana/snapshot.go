@@ -0,0 +1,53 @@
+package mana
+
+import (
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// LoadSnapshotPledges seeds the given base mana vectors with the initial mana that a genesis snapshot pledges to the
+// nodes of the network. The pledged mana is fully effective from the time of the pledge on.
+func LoadSnapshotPledges(baseManaVectors map[Type]BaseManaVector, manaPledges []*ledgerstate.ManaPledge) {
+	for _, baseManaVector := range baseManaVectors {
+		for _, manaPledge := range manaPledges {
+			accessMana := &AccessBaseMana{
+				BaseMana2:          float64(manaPledge.AccessMana),
+				EffectiveBaseMana2: float64(manaPledge.AccessMana),
+				LastUpdated:        manaPledge.Timestamp,
+			}
+			consensusMana := &ConsensusBaseMana{
+				BaseMana1:          float64(manaPledge.ConsensusMana),
+				EffectiveBaseMana1: float64(manaPledge.ConsensusMana),
+				LastUpdated:        manaPledge.Timestamp,
+			}
+
+			switch vector := baseManaVector.(type) {
+			case *AccessBaseManaVector:
+				if manaPledge.AccessMana == 0 {
+					continue
+				}
+				vector.SetMana(manaPledge.NodeID, accessMana)
+				triggerSnapshotPledge(manaPledge, AccessMana, accessMana.BaseMana2)
+			case *ConsensusBaseManaVector:
+				if manaPledge.ConsensusMana == 0 {
+					continue
+				}
+				vector.SetMana(manaPledge.NodeID, consensusMana)
+				triggerSnapshotPledge(manaPledge, ConsensusMana, consensusMana.BaseMana1)
+			case *WeightedBaseManaVector:
+				vector.SetMana1(manaPledge.NodeID, consensusMana)
+				vector.SetMana2(manaPledge.NodeID, accessMana)
+			}
+		}
+	}
+}
+
+// triggerSnapshotPledge triggers the events of a mana pledge that stems from the genesis snapshot.
+func triggerSnapshotPledge(manaPledge *ledgerstate.ManaPledge, manaType Type, amount float64) {
+	Events().Pledged.Trigger(&PledgedEvent{
+		NodeID:        manaPledge.NodeID,
+		Amount:        amount,
+		Time:          manaPledge.Timestamp,
+		ManaType:      manaType,
+		TransactionID: ledgerstate.GenesisTransactionID,
+	})
+}
@@ -0,0 +1,89 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestLoadSnapshotPledges(t *testing.T) {
+	accessVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	consensusVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	researchVector, err := NewResearchBaseManaVector(WeightedMana, ConsensusMana, Mixed)
+	require.NoError(t, err)
+
+	pledgeTime := time.Now()
+	nodeID1, nodeID2 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	LoadSnapshotPledges(map[Type]BaseManaVector{
+		AccessMana:        accessVector,
+		ConsensusMana:     consensusVector,
+		ResearchConsensus: researchVector,
+	}, []*ledgerstate.ManaPledge{
+		{NodeID: nodeID1, AccessMana: 100, ConsensusMana: 50, Timestamp: pledgeTime},
+		{NodeID: nodeID2, AccessMana: 0, ConsensusMana: 30, Timestamp: pledgeTime},
+	})
+
+	assert.Equal(t, 1, accessVector.Size())
+	assert.False(t, accessVector.Has(nodeID2))
+	accessMana, _, err := accessVector.GetMana(nodeID1, pledgeTime)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, accessMana)
+
+	assert.Equal(t, 2, consensusVector.Size())
+	consensusMana, _, err := consensusVector.GetMana(nodeID1, pledgeTime)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, consensusMana)
+	consensusMana, _, err = consensusVector.GetMana(nodeID2, pledgeTime)
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, consensusMana)
+
+	assert.Equal(t, 2, researchVector.Size())
+}
+
+func TestLoadSnapshotPledges_Revoke(t *testing.T) {
+	consensusVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+
+	pledgeTime := time.Now()
+	nodeID1, nodeID2 := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	LoadSnapshotPledges(map[Type]BaseManaVector{ConsensusMana: consensusVector}, []*ledgerstate.ManaPledge{
+		{NodeID: nodeID1, ConsensusMana: 50, Timestamp: pledgeTime},
+	})
+
+	// the consensus mana of the pledge is backed by a snapshot Output that is pledged to the same node
+	store := mapdb.NewMapDB()
+	branchDAG := ledgerstate.NewBranchDAG(store)
+	defer branchDAG.Shutdown()
+	utxoDAG := ledgerstate.NewUTXODAG(store, branchDAG)
+	defer utxoDAG.Shutdown()
+	address := ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	output := ledgerstate.NewSigLockedSingleOutput(50, address)
+	output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	outputMetadata := ledgerstate.NewOutputMetadata(output.ID())
+	utxoDAG.LoadSnapshotOutput(output, outputMetadata, &ledgerstate.OutputPledge{
+		AccessPledgeID:    nodeID1,
+		ConsensusPledgeID: nodeID1,
+		Timestamp:         pledgeTime,
+	})
+
+	// spending the Output revokes the pledged mana from the node
+	transaction := ledgerstate.NewTransaction(ledgerstate.NewTransactionEssence(0, pledgeTime.Add(time.Second), nodeID2, nodeID2,
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(output.ID())),
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(50, address)),
+	), ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
+	txInfo := NewTxInfo(transaction, utxoDAG)
+	require.Len(t, txInfo.InputInfos, 1)
+	assert.Equal(t, nodeID1, txInfo.InputInfos[0].PledgeID[ConsensusMana])
+	consensusVector.Book(txInfo)
+
+	assert.Equal(t, 0.0, consensusVector.(*ConsensusBaseManaVector).vector[nodeID1].BaseMana1)
+}
@@ -22,6 +22,70 @@ type TxInfo struct {
 	InputInfos []InputInfo
 }
 
+// LedgerState is the part of the ledger state that is needed to derive the mana related info of a transaction.
+type LedgerState interface {
+	// Output retrieves the Output with the given OutputID.
+	Output(outputID ledgerstate.OutputID) *ledgerstate.CachedOutput
+	// OutputPledge retrieves the OutputPledge of the transaction that created the Output with the given OutputID.
+	OutputPledge(outputID ledgerstate.OutputID) *ledgerstate.OutputPledge
+}
+
+// NewTxInfo derives the TxInfo of the given transaction from the outputs it consumes and the OutputPledges of the
+// transactions that created them (which are part of the snapshot for the outputs that were loaded from one).
+func NewTxInfo(tx *ledgerstate.Transaction, ledgerState LedgerState) *TxInfo {
+	var totalAmount float64
+	var inputInfos []InputInfo
+
+	// iterate over all inputs within the transaction
+	for _, input := range tx.Essence().Inputs() {
+		i := input.(*ledgerstate.UTXOInput)
+
+		var amount float64
+		var inputTimestamp time.Time
+		var accessManaNodeID identity.ID
+		var consensusManaNodeID identity.ID
+		var _inputInfo InputInfo
+
+		ledgerState.Output(i.ReferencedOutputID()).Consume(func(o ledgerstate.Output) {
+			// first, sum balances of the input, calculate total amount as well for later
+			o.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+				amount += float64(balance)
+				totalAmount += amount
+				return true
+			})
+			// look up the pledge of the transaction that created this input, we need timestamp and access & consensus
+			// pledge IDs
+			if outputPledge := ledgerState.OutputPledge(o.ID()); outputPledge != nil {
+				inputTimestamp = outputPledge.Timestamp
+				accessManaNodeID = outputPledge.AccessPledgeID
+				consensusManaNodeID = outputPledge.ConsensusPledgeID
+			}
+			// build InputInfo for this particular input in the transaction
+			_inputInfo = InputInfo{
+				TimeStamp: inputTimestamp,
+				Amount:    amount,
+				PledgeID: map[Type]identity.ID{
+					AccessMana:    accessManaNodeID,
+					ConsensusMana: consensusManaNodeID,
+				},
+				InputID: o.ID(),
+			}
+		})
+		inputInfos = append(inputInfos, _inputInfo)
+	}
+
+	return &TxInfo{
+		TimeStamp:     tx.Essence().Timestamp(),
+		TransactionID: tx.ID(),
+		TotalBalance:  totalAmount,
+		PledgeID: map[Type]identity.ID{
+			AccessMana:    tx.Essence().AccessPledgeID(),
+			ConsensusMana: tx.Essence().ConsensusPledgeID(),
+		},
+		InputInfos: inputInfos,
+	}
+}
+
 func (t *TxInfo) sumInputs() float64 {
 	t.TotalBalance = 0
 	for _, input := range t.InputInfos {
@@ -239,6 +239,52 @@ func (m *Manager) Sequence(sequenceID SequenceID) *CachedSequence {
 	return &CachedSequence{CachedObject: m.sequenceStore.Load(sequenceID.Bytes())}
 }
 
+// ForEachSequence iterates through all Sequences that are stored in the Manager (i.e. to export them in a snapshot).
+func (m *Manager) ForEachSequence(consumer func(sequence *Sequence) bool) {
+	m.sequenceStore.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedSequence{CachedObject: cachedObject}).Consume(func(sequence *Sequence) {
+			continueIteration = consumer(sequence)
+		})
+
+		return continueIteration
+	})
+}
+
+// ForEachSequenceAliasMapping iterates through all SequenceAliasMappings that are stored in the Manager.
+func (m *Manager) ForEachSequenceAliasMapping(consumer func(sequenceAliasMapping *SequenceAliasMapping) bool) {
+	m.sequenceAliasMappingStore.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedSequenceAliasMapping{CachedObject: cachedObject}).Consume(func(sequenceAliasMapping *SequenceAliasMapping) {
+			continueIteration = consumer(sequenceAliasMapping)
+		})
+
+		return continueIteration
+	})
+}
+
+// ImportSequence stores a Sequence that was created by another Manager (i.e. loaded from a snapshot) if it does not
+// exist, yet. It makes sure that newly created Sequences do not reuse the SequenceID of the imported Sequence.
+func (m *Manager) ImportSequence(sequence *Sequence) {
+	m.sequenceIDCounterMutex.Lock()
+	if sequence.ID() >= m.sequenceIDCounter {
+		m.sequenceIDCounter = sequence.ID() + 1
+	}
+	m.sequenceIDCounterMutex.Unlock()
+
+	if cachedSequence, stored := m.sequenceStore.StoreIfAbsent(sequence); stored {
+		cachedSequence.Release()
+	}
+}
+
+// ImportSequenceAliasMapping stores a SequenceAliasMapping that was created by another Manager (i.e. loaded from a
+// snapshot) if it does not exist, yet.
+func (m *Manager) ImportSequenceAliasMapping(sequenceAliasMapping *SequenceAliasMapping) {
+	if cachedSequenceAliasMapping, stored := m.sequenceAliasMappingStore.StoreIfAbsent(sequenceAliasMapping); stored {
+		cachedSequenceAliasMapping.Release()
+	}
+}
+
 // Shutdown shuts down the Manager and persists its state.
 func (m *Manager) Shutdown() {
 	m.shutdownOnce.Do(func() {
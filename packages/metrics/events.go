@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/events"
 
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
@@ -28,6 +30,8 @@ type CollectionEvents struct {
 	QueryReceived *events.Event
 	// QueryReplyError defines the local FPC query finalization event.
 	QueryReplyError *events.Event
+	// QueryReplyReceived defines the local FPC query reply event.
+	QueryReplyReceived *events.Event
 	// AnalysisFPCFinalized defines the global FPC finalization event.
 	AnalysisFPCFinalized *events.Event
 }
@@ -46,6 +50,16 @@ type QueryReplyErrorEvent struct {
 	OpinionCount int
 }
 
+// QueryReplyReceivedEvent is used to pass information through a QueryReplyReceived event.
+type QueryReplyReceivedEvent struct {
+	// ID defines the ID on the queried node.
+	ID string
+	// OpinionCount defines the local FPC number of opinions requested within the query.
+	OpinionCount int
+	// Latency defines the time it took to receive the reply.
+	Latency time.Duration
+}
+
 // AnalysisFPCFinalizedEvent is triggered by the analysis-server to
 // notify a finalized FPC vote from one node.
 type AnalysisFPCFinalizedEvent struct {
@@ -69,6 +83,10 @@ func queryReplyErrorEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(ev *QueryReplyErrorEvent))(params[0].(*QueryReplyErrorEvent))
 }
 
+func queryReplyReceivedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(ev *QueryReplyReceivedEvent))(params[0].(*QueryReplyReceivedEvent))
+}
+
 func uint64Caller(handler interface{}, params ...interface{}) {
 	handler.(func(uint64))(params[0].(uint64))
 }
@@ -23,6 +23,7 @@ func newEvents() *CollectionEvents {
 		MessageTips:           events.NewEvent(uint64Caller),
 		QueryReceived:         events.NewEvent(queryReceivedEventCaller),
 		QueryReplyError:       events.NewEvent(queryReplyErrorEventCaller),
+		QueryReplyReceived:    events.NewEvent(queryReplyReceivedEventCaller),
 		AnalysisFPCFinalized:  events.NewEvent(fpcFinalizedEventCaller),
 	}
 }
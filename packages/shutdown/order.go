@@ -7,6 +7,10 @@ const (
 	PriorityMana
 	// PriorityTangle defines the shutdown priority for the tangle.
 	PriorityTangle
+	// PriorityLocalSnapshot defines the shutdown priority for the local snapshots.
+	PriorityLocalSnapshot
+	// PriorityStateCommitment defines the shutdown priority for the epoch commitments of the ledger state.
+	PriorityStateCommitment
 	// PriorityFPC defines the shutdown priority for the FPC.
 	PriorityFPC
 	// PriorityFaucet defines the shutdown priority for the faucet.
@@ -0,0 +1,996 @@
+package tangle
+
+import (
+	"math"
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+)
+
+const (
+	// DefaultConfirmationThreshold defines the default share of the active consensus mana that needs to support a
+	// Marker or Branch before it is considered to be confirmed.
+	DefaultConfirmationThreshold = 0.66
+)
+
+// region ApprovalWeightManager ////////////////////////////////////////////////////////////////////////////////////////
+
+// ApprovalWeightManager is a Tangle component that keeps track of the issuers that support the Markers and Branches
+// (by directly or indirectly referencing them) and that confirms them once the consensus mana of their supporters
+// reaches the configured threshold of the consensus mana of the active nodes.
+type ApprovalWeightManager struct {
+	Events *ApprovalWeightManagerEvents
+
+	tangle                 *Tangle
+	confirmationThreshold  float64
+	consensusManaRetriever ConsensusManaRetrieveFunc
+	manaRetrieverMutex     sync.RWMutex
+}
+
+// NewApprovalWeightManager is the constructor for the ApprovalWeightManager.
+func NewApprovalWeightManager(tangle *Tangle) (approvalWeightManager *ApprovalWeightManager) {
+	params := tangle.Options.ApprovalWeightParams
+	if params.ConfirmationThreshold <= 0 {
+		params.ConfirmationThreshold = DefaultConfirmationThreshold
+	}
+
+	approvalWeightManager = &ApprovalWeightManager{
+		Events: &ApprovalWeightManagerEvents{
+			MessageConfirmed: events.NewEvent(MessageIDCaller),
+			BranchConfirmed:  events.NewEvent(branchIDEventHandler),
+		},
+
+		tangle:                 tangle,
+		confirmationThreshold:  params.ConfirmationThreshold,
+		consensusManaRetriever: params.ConsensusManaRetrieveFunc,
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (a *ApprovalWeightManager) Setup() {
+	a.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(a.ProcessMessage))
+}
+
+// SetConsensusManaRetriever sets the function that is used to retrieve the consensus mana of the active nodes. It
+// allows to connect the ApprovalWeightManager to a mana provider that is only available after the Tangle has been
+// created.
+func (a *ApprovalWeightManager) SetConsensusManaRetriever(consensusManaRetriever ConsensusManaRetrieveFunc) {
+	a.manaRetrieverMutex.Lock()
+	defer a.manaRetrieverMutex.Unlock()
+
+	a.consensusManaRetriever = consensusManaRetriever
+}
+
+// ProcessMessage adds the issuer of the given Message as a supporter of all Markers and Branches in its past cone and
+// confirms the ones that reach the confirmation threshold.
+func (a *ApprovalWeightManager) ProcessMessage(messageID MessageID) {
+	a.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		a.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			structureDetails := messageMetadata.StructureDetails()
+			if structureDetails == nil {
+				return
+			}
+
+			if structureDetails.IsPastMarker {
+				a.tangle.Storage.StoreMarkerMessageMapping(NewMarkerMessageMapping(structureDetails.PastMarkers.FirstMarker(), messageID))
+			}
+
+			// the mana vector is retrieved once and used for all Markers and Branches that are affected by the Message
+			manaVector := a.consensusManaVector()
+			supporter := identity.NewID(message.IssuerPublicKey())
+			a.updateMarkerSupporters(structureDetails.PastMarkers, supporter, manaVector)
+			a.updateBranchSupporters(messageMetadata.BranchID(), supporter, manaVector)
+		})
+	})
+}
+
+// Weight returns the share of the active consensus mana that the given supporters represent.
+func (a *ApprovalWeightManager) Weight(supporters Supporters) (weight float64) {
+	return a.consensusManaVector().weight(supporters)
+}
+
+// ReevaluateConfirmations confirms the Markers and Branches that reach the confirmation threshold with the current
+// consensus mana of the active nodes. It needs to be called whenever the mana vector changes, as the supporters of a
+// Marker or Branch can reach the threshold without any new supporter being added.
+func (a *ApprovalWeightManager) ReevaluateConfirmations() {
+	manaVector := a.consensusManaVector()
+
+	confirmedMarkers := make([]*markers.Marker, 0)
+	a.tangle.Storage.ForEachMarkerSupporters(func(markerSupporters *MarkerSupporters) bool {
+		if !markerSupporters.Confirmed() && manaVector.weight(markerSupporters.Supporters()) >= a.confirmationThreshold && markerSupporters.SetConfirmed(true) {
+			confirmedMarkers = append(confirmedMarkers, markerSupporters.Marker())
+		}
+
+		return true
+	})
+	for _, marker := range confirmedMarkers {
+		a.tangle.Storage.MarkerMessageMapping(marker).Consume(func(markerMessageMapping *MarkerMessageMapping) {
+			a.confirmPastCone(markerMessageMapping.MessageID())
+		})
+	}
+
+	confirmedBranches := make([]ledgerstate.BranchID, 0)
+	a.tangle.Storage.ForEachBranchSupporters(func(branchSupporters *BranchSupporters) bool {
+		if !branchSupporters.Confirmed() && manaVector.weight(branchSupporters.Supporters()) >= a.confirmationThreshold && branchSupporters.SetConfirmed(true) {
+			confirmedBranches = append(confirmedBranches, branchSupporters.BranchID())
+		}
+
+		return true
+	})
+	for _, branchID := range confirmedBranches {
+		a.Events.BranchConfirmed.Trigger(branchID)
+	}
+}
+
+// consensusManaVector retrieves the consensus mana of the active nodes that is used for the current evaluation round.
+func (a *ApprovalWeightManager) consensusManaVector() (manaVector *consensusManaVector) {
+	a.manaRetrieverMutex.RLock()
+	defer a.manaRetrieverMutex.RUnlock()
+
+	manaVector = &consensusManaVector{}
+	if a.consensusManaRetriever == nil {
+		return manaVector
+	}
+
+	manaVector.consensusMana = a.consensusManaRetriever()
+	for _, consensusMana := range manaVector.consensusMana {
+		manaVector.totalConsensusMana += consensusMana
+	}
+
+	return manaVector
+}
+
+// MarkerConfirmed returns true if the given Marker is confirmed.
+func (a *ApprovalWeightManager) MarkerConfirmed(marker *markers.Marker) (confirmed bool) {
+	a.tangle.Storage.MarkerSupporters(marker).Consume(func(markerSupporters *MarkerSupporters) {
+		confirmed = markerSupporters.Confirmed()
+	})
+
+	return
+}
+
+// BranchConfirmed returns true if the given Branch is confirmed.
+func (a *ApprovalWeightManager) BranchConfirmed(branchID ledgerstate.BranchID) (confirmed bool) {
+	if branchID == ledgerstate.MasterBranchID {
+		return true
+	}
+
+	a.tangle.Storage.BranchSupporters(branchID).Consume(func(branchSupporters *BranchSupporters) {
+		confirmed = branchSupporters.Confirmed()
+	})
+
+	return
+}
+
+// BranchWeight returns the share of the active consensus mana that supports the given Branch. The weight of an
+// AggregatedBranch is the lowest weight of the ConflictBranches it consists of. The MasterBranch does not conflict with
+// anything and is always confirmed, so it has the full weight of 1.
+func (a *ApprovalWeightManager) BranchWeight(branchID ledgerstate.BranchID) (weight float64) {
+	return a.branchWeight(branchID, a.consensusManaVector())
+}
+
+// BranchWeights returns the BranchWeight of each of the given Branches (using the same consensus mana for all of them).
+func (a *ApprovalWeightManager) BranchWeights(branchIDs ...ledgerstate.BranchID) (weights map[ledgerstate.BranchID]float64) {
+	manaVector := a.consensusManaVector()
+
+	weights = make(map[ledgerstate.BranchID]float64, len(branchIDs))
+	for _, branchID := range branchIDs {
+		weights[branchID] = a.branchWeight(branchID, manaVector)
+	}
+
+	return weights
+}
+
+// branchWeight returns the share of the given consensus mana that supports the given Branch.
+func (a *ApprovalWeightManager) branchWeight(branchID ledgerstate.BranchID, manaVector *consensusManaVector) (weight float64) {
+	if branchID == ledgerstate.MasterBranchID {
+		return 1
+	}
+
+	a.tangle.LedgerState.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		if branch.Type() == ledgerstate.ConflictBranchType {
+			a.tangle.Storage.BranchSupporters(branchID).Consume(func(branchSupporters *BranchSupporters) {
+				weight = manaVector.weight(branchSupporters.Supporters())
+			})
+			return
+		}
+
+		weight = math.MaxFloat64
+		for parentBranchID := range branch.Parents() {
+			weight = math.Min(weight, a.branchWeight(parentBranchID, manaVector))
+		}
+	})
+
+	return
+}
+
+// updateMarkerSupporters adds the supporter to the given Markers, their predecessors in the same Sequence and the
+// Markers that they reference in other Sequences. It stops walking as soon as the supporter was already registered,
+// as the remaining past cone then has been processed before.
+func (a *ApprovalWeightManager) updateMarkerSupporters(pastMarkers *markers.Markers, supporter identity.ID, manaVector *consensusManaVector) {
+	markerWalker := walker.New(false)
+	pastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+		markerWalker.Push(*markers.NewMarker(sequenceID, index))
+
+		return true
+	})
+
+	for markerWalker.HasNext() {
+		currentMarker := markerWalker.Next().(markers.Marker)
+
+		a.tangle.Booker.MarkersManager.Sequence(currentMarker.SequenceID()).Consume(func(sequence *markers.Sequence) {
+			if currentMarker.Index() < sequence.LowestIndex() || !a.addMarkerSupporter(&currentMarker, supporter, manaVector) {
+				return
+			}
+
+			for index := currentMarker.Index() - 1; index >= sequence.LowestIndex(); index-- {
+				if !a.addMarkerSupporter(markers.NewMarker(sequence.ID(), index), supporter, manaVector) {
+					break
+				}
+			}
+
+			sequence.ReferencedMarkers(currentMarker.Index()).ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+				markerWalker.Push(*markers.NewMarker(sequenceID, index))
+
+				return true
+			})
+		})
+	}
+}
+
+// addMarkerSupporter adds the supporter to the given Marker and confirms the Marker if it reaches the threshold.
+func (a *ApprovalWeightManager) addMarkerSupporter(marker *markers.Marker, supporter identity.ID, manaVector *consensusManaVector) (added bool) {
+	confirmed := false
+	a.tangle.Storage.MarkerSupporters(marker, NewMarkerSupporters).Consume(func(markerSupporters *MarkerSupporters) {
+		if added = markerSupporters.AddSupporter(supporter); !added || markerSupporters.Confirmed() {
+			return
+		}
+
+		if manaVector.weight(markerSupporters.Supporters()) >= a.confirmationThreshold {
+			confirmed = markerSupporters.SetConfirmed(true)
+		}
+	})
+
+	if confirmed {
+		a.tangle.Storage.MarkerMessageMapping(marker).Consume(func(markerMessageMapping *MarkerMessageMapping) {
+			a.confirmPastCone(markerMessageMapping.MessageID())
+		})
+	}
+
+	return
+}
+
+// confirmPastCone marks the given Message and its past cone as confirmed.
+func (a *ApprovalWeightManager) confirmPastCone(messageID MessageID) {
+	a.tangle.Utils.WalkMessageAndMetadata(func(message *Message, messageMetadata *MessageMetadata, walker *walker.Walker) {
+		if !messageMetadata.SetConfirmed(true) {
+			return
+		}
+		a.Events.MessageConfirmed.Trigger(message.ID())
+
+		message.ForEachParent(func(parent Parent) {
+			if parent.ID != EmptyMessageID {
+				walker.Push(parent.ID)
+			}
+		})
+	}, MessageIDs{messageID})
+}
+
+// updateBranchSupporters adds the supporter to the ConflictBranches that the given Branch represents and to all of
+// their ancestors.
+func (a *ApprovalWeightManager) updateBranchSupporters(branchID ledgerstate.BranchID, supporter identity.ID, manaVector *consensusManaVector) {
+	branchWalker := walker.New(false)
+	branchWalker.Push(branchID)
+
+	for branchWalker.HasNext() {
+		currentBranchID := branchWalker.Next().(ledgerstate.BranchID)
+		if currentBranchID == ledgerstate.MasterBranchID {
+			continue
+		}
+
+		a.tangle.LedgerState.BranchDAG.Branch(currentBranchID).Consume(func(branch ledgerstate.Branch) {
+			if branch.Type() == ledgerstate.ConflictBranchType && !a.addBranchSupporter(currentBranchID, supporter, manaVector) {
+				return
+			}
+
+			for parentBranchID := range branch.Parents() {
+				branchWalker.Push(parentBranchID)
+			}
+		})
+	}
+}
+
+// addBranchSupporter adds the supporter to the given Branch and confirms the Branch if it reaches the threshold.
+func (a *ApprovalWeightManager) addBranchSupporter(branchID ledgerstate.BranchID, supporter identity.ID, manaVector *consensusManaVector) (added bool) {
+	confirmed := false
+	a.tangle.Storage.BranchSupporters(branchID, NewBranchSupporters).Consume(func(branchSupporters *BranchSupporters) {
+		if added = branchSupporters.AddSupporter(supporter); !added || branchSupporters.Confirmed() {
+			return
+		}
+
+		if manaVector.weight(branchSupporters.Supporters()) >= a.confirmationThreshold {
+			confirmed = branchSupporters.SetConfirmed(true)
+		}
+	})
+
+	if confirmed {
+		a.Events.BranchConfirmed.Trigger(branchID)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region consensusManaVector //////////////////////////////////////////////////////////////////////////////////////////
+
+// consensusManaVector holds the consensus mana of the active nodes that is used to evaluate the approval weight.
+type consensusManaVector struct {
+	consensusMana      map[identity.ID]float64
+	totalConsensusMana float64
+}
+
+// weight returns the share of the consensus mana that the given supporters represent.
+func (c *consensusManaVector) weight(supporters Supporters) (weight float64) {
+	if c.totalConsensusMana <= 0 {
+		return 0
+	}
+
+	supportersConsensusMana := 0.0
+	for supporter := range supporters {
+		supportersConsensusMana += c.consensusMana[supporter]
+	}
+
+	return supportersConsensusMana / c.totalConsensusMana
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ApprovalWeightParams /////////////////////////////////////////////////////////////////////////////////////////
+
+// ConsensusManaRetrieveFunc is a function type to retrieve the consensus mana of the active nodes.
+type ConsensusManaRetrieveFunc func() map[identity.ID]float64
+
+// ApprovalWeightParams defines the parameters of the ApprovalWeightManager.
+type ApprovalWeightParams struct {
+	// ConfirmationThreshold defines the share of the active consensus mana that needs to support a Marker or Branch.
+	ConfirmationThreshold float64
+
+	// ConsensusManaRetrieveFunc is used to retrieve the consensus mana of the active nodes.
+	ConsensusManaRetrieveFunc ConsensusManaRetrieveFunc
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ApprovalWeightManagerEvents //////////////////////////////////////////////////////////////////////////////////
+
+// ApprovalWeightManagerEvents represents events happening in the ApprovalWeightManager.
+type ApprovalWeightManagerEvents struct {
+	// MessageConfirmed is triggered when a Message is confirmed by the approval weight of its future cone.
+	MessageConfirmed *events.Event
+
+	// BranchConfirmed is triggered when a Branch is confirmed by the approval weight of its supporters.
+	BranchConfirmed *events.Event
+}
+
+func branchIDEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(ledgerstate.BranchID))(params[0].(ledgerstate.BranchID))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Supporters ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Supporters is a set of node identities that support a Marker or a Branch.
+type Supporters map[identity.ID]types.Empty
+
+// SupportersFromMarshalUtil unmarshals a collection of Supporters using a MarshalUtil (for easier unmarshaling).
+func SupportersFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (supporters Supporters, err error) {
+	supportersCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse supporters count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	supporters = make(Supporters, supportersCount)
+	for i := uint32(0); i < supportersCount; i++ {
+		supporter, supporterErr := identity.IDFromMarshalUtil(marshalUtil)
+		if supporterErr != nil {
+			err = xerrors.Errorf("failed to parse supporter (%v): %w", supporterErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+		supporters[supporter] = types.Void
+	}
+
+	return
+}
+
+// Clone returns a copy of the Supporters.
+func (s Supporters) Clone() (clonedSupporters Supporters) {
+	clonedSupporters = make(Supporters, len(s))
+	for supporter := range s {
+		clonedSupporters[supporter] = types.Void
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the Supporters.
+func (s Supporters) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint32(uint32(len(s)))
+	for supporter := range s {
+		marshalUtil.WriteBytes(supporter.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the Supporters.
+func (s Supporters) String() string {
+	structBuilder := stringify.StructBuilder("Supporters")
+	for supporter := range s {
+		structBuilder.AddField(stringify.StructField(supporter.String(), "true"))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MarkerSupporters /////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkerSupporters is a data structure that keeps track of the Supporters of a Marker.
+type MarkerSupporters struct {
+	marker     *markers.Marker
+	supporters Supporters
+	confirmed  bool
+	mutex      sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewMarkerSupporters is the constructor for the MarkerSupporters.
+func NewMarkerSupporters(marker *markers.Marker) *MarkerSupporters {
+	return &MarkerSupporters{
+		marker:     marker,
+		supporters: make(Supporters),
+	}
+}
+
+// MarkerSupportersFromBytes unmarshals a MarkerSupporters object from a sequence of bytes.
+func MarkerSupportersFromBytes(bytes []byte) (markerSupporters *MarkerSupporters, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if markerSupporters, err = MarkerSupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerSupporters from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MarkerSupportersFromMarshalUtil unmarshals a MarkerSupporters object using a MarshalUtil (for easier unmarshaling).
+func MarkerSupportersFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (markerSupporters *MarkerSupporters, err error) {
+	markerSupporters = &MarkerSupporters{}
+	if markerSupporters.marker, err = markers.MarkerFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Marker from MarshalUtil: %w", err)
+		return
+	}
+	if markerSupporters.supporters, err = SupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Supporters from MarshalUtil: %w", err)
+		return
+	}
+	if markerSupporters.confirmed, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse confirmed flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// MarkerSupportersFromObjectStorage restores a MarkerSupporters object that was stored in the object storage.
+func MarkerSupportersFromObjectStorage(key []byte, data []byte) (markerSupporters objectstorage.StorableObject, err error) {
+	if markerSupporters, _, err = MarkerSupportersFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerSupporters from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Marker returns the Marker that this object tracks the Supporters of.
+func (m *MarkerSupporters) Marker() *markers.Marker {
+	return m.marker
+}
+
+// AddSupporter adds a new Supporter to the Marker. It returns false if the Supporter was known already.
+func (m *MarkerSupporters) AddSupporter(supporter identity.ID) (added bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.supporters[supporter]; exists {
+		return false
+	}
+
+	m.supporters[supporter] = types.Void
+	m.SetModified()
+
+	return true
+}
+
+// Supporters returns a copy of the Supporters of the Marker.
+func (m *MarkerSupporters) Supporters() Supporters {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.supporters.Clone()
+}
+
+// Confirmed returns true if the Marker is confirmed.
+func (m *MarkerSupporters) Confirmed() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.confirmed
+}
+
+// SetConfirmed sets the confirmed flag of the Marker. It returns true if the value was modified.
+func (m *MarkerSupporters) SetConfirmed(confirmed bool) (modified bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.confirmed == confirmed {
+		return false
+	}
+
+	m.confirmed = confirmed
+	m.SetModified()
+
+	return true
+}
+
+// Bytes returns a marshaled version of the MarkerSupporters.
+func (m *MarkerSupporters) Bytes() []byte {
+	return byteutils.ConcatBytes(m.ObjectStorageKey(), m.ObjectStorageValue())
+}
+
+// String returns a human readable version of the MarkerSupporters.
+func (m *MarkerSupporters) String() string {
+	return stringify.Struct("MarkerSupporters",
+		stringify.StructField("marker", m.marker),
+		stringify.StructField("supporters", m.Supporters()),
+		stringify.StructField("confirmed", m.Confirmed()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (m *MarkerSupporters) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (m *MarkerSupporters) ObjectStorageKey() []byte {
+	return m.marker.Bytes()
+}
+
+// ObjectStorageValue marshals the MarkerSupporters into a sequence of bytes that are used as the value part in the
+// object storage.
+func (m *MarkerSupporters) ObjectStorageValue() []byte {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return marshalutil.New().
+		Write(m.supporters).
+		WriteBool(m.confirmed).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &MarkerSupporters{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedMarkerSupporters ///////////////////////////////////////////////////////////////////////////////////////
+
+// CachedMarkerSupporters is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedMarkerSupporters struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedMarkerSupporters) Retain() *CachedMarkerSupporters {
+	return &CachedMarkerSupporters{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedMarkerSupporters) Unwrap() *MarkerSupporters {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*MarkerSupporters)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedMarkerSupporters) Consume(consumer func(markerSupporters *MarkerSupporters), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*MarkerSupporters))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedMarkerSupporters.
+func (c *CachedMarkerSupporters) String() string {
+	return stringify.Struct("CachedMarkerSupporters",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BranchSupporters /////////////////////////////////////////////////////////////////////////////////////////////
+
+// BranchSupporters is a data structure that keeps track of the Supporters of a Branch.
+type BranchSupporters struct {
+	branchID   ledgerstate.BranchID
+	supporters Supporters
+	confirmed  bool
+	mutex      sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewBranchSupporters is the constructor for the BranchSupporters.
+func NewBranchSupporters(branchID ledgerstate.BranchID) *BranchSupporters {
+	return &BranchSupporters{
+		branchID:   branchID,
+		supporters: make(Supporters),
+	}
+}
+
+// BranchSupportersFromBytes unmarshals a BranchSupporters object from a sequence of bytes.
+func BranchSupportersFromBytes(bytes []byte) (branchSupporters *BranchSupporters, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if branchSupporters, err = BranchSupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse BranchSupporters from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// BranchSupportersFromMarshalUtil unmarshals a BranchSupporters object using a MarshalUtil (for easier unmarshaling).
+func BranchSupportersFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (branchSupporters *BranchSupporters, err error) {
+	branchSupporters = &BranchSupporters{}
+	if branchSupporters.branchID, err = ledgerstate.BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse BranchID from MarshalUtil: %w", err)
+		return
+	}
+	if branchSupporters.supporters, err = SupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Supporters from MarshalUtil: %w", err)
+		return
+	}
+	if branchSupporters.confirmed, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse confirmed flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// BranchSupportersFromObjectStorage restores a BranchSupporters object that was stored in the object storage.
+func BranchSupportersFromObjectStorage(key []byte, data []byte) (branchSupporters objectstorage.StorableObject, err error) {
+	if branchSupporters, _, err = BranchSupportersFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse BranchSupporters from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// BranchID returns the BranchID of the Branch that this object tracks the Supporters of.
+func (b *BranchSupporters) BranchID() ledgerstate.BranchID {
+	return b.branchID
+}
+
+// AddSupporter adds a new Supporter to the Branch. It returns false if the Supporter was known already.
+func (b *BranchSupporters) AddSupporter(supporter identity.ID) (added bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, exists := b.supporters[supporter]; exists {
+		return false
+	}
+
+	b.supporters[supporter] = types.Void
+	b.SetModified()
+
+	return true
+}
+
+// Supporters returns a copy of the Supporters of the Branch.
+func (b *BranchSupporters) Supporters() Supporters {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.supporters.Clone()
+}
+
+// Confirmed returns true if the Branch is confirmed.
+func (b *BranchSupporters) Confirmed() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.confirmed
+}
+
+// SetConfirmed sets the confirmed flag of the Branch. It returns true if the value was modified.
+func (b *BranchSupporters) SetConfirmed(confirmed bool) (modified bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.confirmed == confirmed {
+		return false
+	}
+
+	b.confirmed = confirmed
+	b.SetModified()
+
+	return true
+}
+
+// Bytes returns a marshaled version of the BranchSupporters.
+func (b *BranchSupporters) Bytes() []byte {
+	return byteutils.ConcatBytes(b.ObjectStorageKey(), b.ObjectStorageValue())
+}
+
+// String returns a human readable version of the BranchSupporters.
+func (b *BranchSupporters) String() string {
+	return stringify.Struct("BranchSupporters",
+		stringify.StructField("branchID", b.branchID),
+		stringify.StructField("supporters", b.Supporters()),
+		stringify.StructField("confirmed", b.Confirmed()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (b *BranchSupporters) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (b *BranchSupporters) ObjectStorageKey() []byte {
+	return b.branchID.Bytes()
+}
+
+// ObjectStorageValue marshals the BranchSupporters into a sequence of bytes that are used as the value part in the
+// object storage.
+func (b *BranchSupporters) ObjectStorageValue() []byte {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return marshalutil.New().
+		Write(b.supporters).
+		WriteBool(b.confirmed).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &BranchSupporters{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedBranchSupporters ///////////////////////////////////////////////////////////////////////////////////////
+
+// CachedBranchSupporters is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedBranchSupporters struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedBranchSupporters) Retain() *CachedBranchSupporters {
+	return &CachedBranchSupporters{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedBranchSupporters) Unwrap() *BranchSupporters {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*BranchSupporters)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedBranchSupporters) Consume(consumer func(branchSupporters *BranchSupporters), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*BranchSupporters))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedBranchSupporters.
+func (c *CachedBranchSupporters) String() string {
+	return stringify.Struct("CachedBranchSupporters",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MarkerMessageMapping /////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkerMessageMapping is a data structure that maps a Marker to the Message that it represents.
+type MarkerMessageMapping struct {
+	marker    *markers.Marker
+	messageID MessageID
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewMarkerMessageMapping is the constructor for the MarkerMessageMapping.
+func NewMarkerMessageMapping(marker *markers.Marker, messageID MessageID) *MarkerMessageMapping {
+	return &MarkerMessageMapping{
+		marker:    marker,
+		messageID: messageID,
+	}
+}
+
+// MarkerMessageMappingFromBytes unmarshals a MarkerMessageMapping from a sequence of bytes.
+func MarkerMessageMappingFromBytes(bytes []byte) (markerMessageMapping *MarkerMessageMapping, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if markerMessageMapping, err = MarkerMessageMappingFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerMessageMapping from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MarkerMessageMappingFromMarshalUtil unmarshals a MarkerMessageMapping using a MarshalUtil (for easier unmarshaling).
+func MarkerMessageMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (markerMessageMapping *MarkerMessageMapping, err error) {
+	markerMessageMapping = &MarkerMessageMapping{}
+	if markerMessageMapping.marker, err = markers.MarkerFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Marker from MarshalUtil: %w", err)
+		return
+	}
+	if markerMessageMapping.messageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MessageID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// MarkerMessageMappingFromObjectStorage restores a MarkerMessageMapping that was stored in the object storage.
+func MarkerMessageMappingFromObjectStorage(key []byte, data []byte) (markerMessageMapping objectstorage.StorableObject, err error) {
+	if markerMessageMapping, _, err = MarkerMessageMappingFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerMessageMapping from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Marker returns the Marker that is mapped to a MessageID.
+func (m *MarkerMessageMapping) Marker() *markers.Marker {
+	return m.marker
+}
+
+// MessageID returns the MessageID of the Marker.
+func (m *MarkerMessageMapping) MessageID() MessageID {
+	return m.messageID
+}
+
+// Bytes returns a marshaled version of the MarkerMessageMapping.
+func (m *MarkerMessageMapping) Bytes() []byte {
+	return byteutils.ConcatBytes(m.ObjectStorageKey(), m.ObjectStorageValue())
+}
+
+// String returns a human readable version of the MarkerMessageMapping.
+func (m *MarkerMessageMapping) String() string {
+	return stringify.Struct("MarkerMessageMapping",
+		stringify.StructField("marker", m.marker),
+		stringify.StructField("messageID", m.messageID),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (m *MarkerMessageMapping) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (m *MarkerMessageMapping) ObjectStorageKey() []byte {
+	return m.marker.Bytes()
+}
+
+// ObjectStorageValue marshals the MarkerMessageMapping into a sequence of bytes that are used as the value part in the
+// object storage.
+func (m *MarkerMessageMapping) ObjectStorageValue() []byte {
+	return m.messageID.Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &MarkerMessageMapping{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedMarkerMessageMapping ///////////////////////////////////////////////////////////////////////////////////
+
+// CachedMarkerMessageMapping is a wrapper for the generic CachedObject returned by the object storage that overrides
+// the accessor methods with a type-casted one.
+type CachedMarkerMessageMapping struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedMarkerMessageMapping) Retain() *CachedMarkerMessageMapping {
+	return &CachedMarkerMessageMapping{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedMarkerMessageMapping) Unwrap() *MarkerMessageMapping {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*MarkerMessageMapping)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedMarkerMessageMapping) Consume(consumer func(markerMessageMapping *MarkerMessageMapping), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*MarkerMessageMapping))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedMarkerMessageMapping.
+func (c *CachedMarkerMessageMapping) String() string {
+	return stringify.Struct("CachedMarkerMessageMapping",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
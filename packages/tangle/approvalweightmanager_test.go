@@ -0,0 +1,136 @@
+package tangle
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestApprovalWeightManager_ProcessMessage(t *testing.T) {
+	issuers := map[string]*identity.LocalIdentity{
+		"A": identity.GenerateLocalIdentity(),
+		"B": identity.GenerateLocalIdentity(),
+		"C": identity.GenerateLocalIdentity(),
+	}
+	consensusMana := map[identity.ID]float64{
+		issuers["A"].ID():                     30,
+		issuers["B"].ID():                     30,
+		issuers["C"].ID():                     30,
+		identity.GenerateLocalIdentity().ID(): 10,
+	}
+
+	tangle := New(ApprovalWeightConfig(ApprovalWeightParams{
+		ConfirmationThreshold: 0.66,
+		ConsensusManaRetrieveFunc: func() map[identity.ID]float64 {
+			return consensusMana
+		},
+	}))
+	defer tangle.Shutdown()
+	tangle.Setup()
+
+	confirmedMessages := make(chan MessageID, 10)
+	tangle.ApprovalWeightManager.Events.MessageConfirmed.Attach(events.NewClosure(func(messageID MessageID) {
+		confirmedMessages <- messageID
+	}))
+
+	messages := make(map[string]*Message)
+	parent := EmptyMessageID
+	for _, alias := range []string{"A", "B", "C"} {
+		messages[alias] = NewMessage([]MessageID{parent}, []MessageID{}, time.Now(), issuers[alias].PublicKey(), nextSequenceNumber(), payload.NewGenericDataPayload([]byte(alias)), 0, ed25519.Signature{})
+		parent = messages[alias].ID()
+	}
+
+	// the first two messages only collect 60% of the consensus mana
+	tangle.Storage.StoreMessage(messages["A"])
+	tangle.Storage.StoreMessage(messages["B"])
+	assert.Eventually(t, func() bool {
+		booked := false
+		tangle.Storage.MessageMetadata(messages["B"].ID()).Consume(func(messageMetadata *MessageMetadata) {
+			booked = messageMetadata.IsBooked()
+		})
+		return booked
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, confirmedMessages)
+
+	// the third message pushes the approval weight of the first message over the threshold
+	tangle.Storage.StoreMessage(messages["C"])
+	select {
+	case messageID := <-confirmedMessages:
+		assert.Equal(t, messages["A"].ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not confirmed")
+	}
+
+	require.True(t, tangle.Storage.MessageMetadata(messages["A"].ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.True(t, messageMetadata.IsConfirmed())
+	}))
+	require.True(t, tangle.Storage.MessageMetadata(messages["B"].ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.False(t, messageMetadata.IsConfirmed())
+	}))
+}
+
+func TestApprovalWeightManager_ReevaluateConfirmations(t *testing.T) {
+	issuers := map[string]*identity.LocalIdentity{
+		"A": identity.GenerateLocalIdentity(),
+		"B": identity.GenerateLocalIdentity(),
+	}
+	inactiveNode := identity.GenerateLocalIdentity()
+
+	var consensusManaMutex sync.Mutex
+	consensusMana := map[identity.ID]float64{
+		issuers["A"].ID(): 30,
+		issuers["B"].ID(): 30,
+		inactiveNode.ID(): 40,
+	}
+
+	tangle := New(ApprovalWeightConfig(ApprovalWeightParams{
+		ConfirmationThreshold: 0.66,
+		ConsensusManaRetrieveFunc: func() map[identity.ID]float64 {
+			consensusManaMutex.Lock()
+			defer consensusManaMutex.Unlock()
+
+			activeConsensusMana := make(map[identity.ID]float64)
+			for nodeID, nodeMana := range consensusMana {
+				activeConsensusMana[nodeID] = nodeMana
+			}
+			return activeConsensusMana
+		},
+	}))
+	defer tangle.Shutdown()
+	tangle.Setup()
+
+	messageA := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, time.Now(), issuers["A"].PublicKey(), nextSequenceNumber(), payload.NewGenericDataPayload([]byte("A")), 0, ed25519.Signature{})
+	messageB := NewMessage([]MessageID{messageA.ID()}, []MessageID{}, time.Now(), issuers["B"].PublicKey(), nextSequenceNumber(), payload.NewGenericDataPayload([]byte("B")), 0, ed25519.Signature{})
+	tangle.Storage.StoreMessage(messageA)
+	tangle.Storage.StoreMessage(messageB)
+	assert.Eventually(t, func() bool {
+		booked := false
+		tangle.Storage.MessageMetadata(messageB.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			booked = messageMetadata.IsBooked()
+		})
+		return booked
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the supporters only hold 60% of the active consensus mana
+	tangle.ApprovalWeightManager.ReevaluateConfirmations()
+	assert.False(t, tangle.ApprovalWeightManager.MarkerConfirmed(markers.NewMarker(1, 1)))
+
+	// the threshold is reached once the inactive node drops out of the active consensus mana
+	consensusManaMutex.Lock()
+	delete(consensusMana, inactiveNode.ID())
+	consensusManaMutex.Unlock()
+	tangle.ApprovalWeightManager.ReevaluateConfirmations()
+	assert.True(t, tangle.ApprovalWeightManager.MarkerConfirmed(markers.NewMarker(1, 1)))
+	require.True(t, tangle.Storage.MessageMetadata(messageA.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.True(t, messageMetadata.IsConfirmed())
+	}))
+}
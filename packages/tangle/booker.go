@@ -116,6 +116,18 @@ func (b *Booker) Book(messageID MessageID) (err error) {
 
 				for _, output := range transaction.Essence().Outputs() {
 					b.tangle.LedgerState.utxoDAG.StoreAddressOutputMapping(output.Address(), output.ID())
+
+					// outputs that return to a fallback address need to be discoverable by it as well (clients check the
+					// conditions of the output to determine which of the addresses can currently unlock it)
+					if extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput); isExtendedLockedOutput && extendedLockedOutput.FallbackAddress() != nil {
+						b.tangle.LedgerState.utxoDAG.StoreAddressOutputMapping(extendedLockedOutput.FallbackAddress(), output.ID())
+					}
+				}
+				if b.tangle.Options.AddressHistoryEnabled {
+					if storeErr := b.tangle.LedgerState.utxoDAG.StoreAddressTransactionMappings(transaction); storeErr != nil {
+						err = xerrors.Errorf("failed to store the address history of Transaction with %s: %w", transaction.ID(), storeErr)
+						return
+					}
 				}
 
 				attachment, stored := b.tangle.Storage.StoreAttachment(transaction.ID(), messageID)
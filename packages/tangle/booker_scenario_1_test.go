@@ -41,7 +41,7 @@ func TestScenario_1(t *testing.T) {
 		},
 	}
 
-	tangle.LedgerState.LoadSnapshot(snapshot)
+	tangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 
 	messages := make(map[string]*Message)
 	transactions := make(map[string]*ledgerstate.Transaction)
@@ -43,7 +43,7 @@ func TestScenario_2(t *testing.T) {
 		},
 	}
 
-	tangle.LedgerState.LoadSnapshot(snapshot)
+	tangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 
 	messages := make(map[string]*Message)
 	transactions := make(map[string]*ledgerstate.Transaction)
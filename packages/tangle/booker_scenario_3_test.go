@@ -42,7 +42,7 @@ func TestScenario_3(t *testing.T) {
 		},
 	}
 
-	tangle.LedgerState.LoadSnapshot(snapshot)
+	tangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 
 	messages := make(map[string]*Message)
 	transactions := make(map[string]*ledgerstate.Transaction)
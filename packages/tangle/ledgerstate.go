@@ -1,6 +1,13 @@
 package tangle
 
 import (
+	"bufio"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/timedexecutor"
 	"github.com/iotaledger/hive.go/types"
 	"golang.org/x/xerrors"
 
@@ -12,27 +19,84 @@ import (
 // LedgerState is a Tangle component that wraps the components of the ledgerstate package and makes them available at a
 // "single point of contact".
 type LedgerState struct {
-	tangle    *Tangle
-	BranchDAG *ledgerstate.BranchDAG
-	utxoDAG   *ledgerstate.UTXODAG
+	tangle       *Tangle
+	BranchDAG    *ledgerstate.BranchDAG
+	utxoDAG      *ledgerstate.UTXODAG
+	branchPruner *timedexecutor.TimedExecutor
 }
 
 // NewLedgerState is the constructor of the LedgerState component.
 func NewLedgerState(tangle *Tangle) (ledgerState *LedgerState) {
 	branchDAG := ledgerstate.NewBranchDAG(tangle.Options.Store)
 	return &LedgerState{
-		tangle:    tangle,
-		BranchDAG: branchDAG,
-		utxoDAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, branchDAG),
+		tangle:       tangle,
+		BranchDAG:    branchDAG,
+		utxoDAG:      ledgerstate.NewUTXODAG(tangle.Options.Store, branchDAG, ledgerstate.DustProtection(tangle.Options.DustParams)),
+		branchPruner: timedexecutor.New(1),
+	}
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (l *LedgerState) Setup() {
+	l.utxoDAG.Events.Error.Attach(events.NewClosure(func(err error) {
+		l.tangle.Events.Error.Trigger(xerrors.Errorf("error in UTXODAG: %w", err))
+	}))
+
+	// keep the StateCommitment in sync with the confirmed Transactions
+	l.tangle.ConsensusManager.Events.TransactionConfirmed.Attach(events.NewClosure(func(messageID MessageID) {
+		l.tangle.Utils.ComputeIfTransaction(messageID, func(transactionID ledgerstate.TransactionID) {
+			if err := l.utxoDAG.CommitConfirmedTransaction(transactionID); err != nil {
+				l.tangle.Events.Error.Trigger(xerrors.Errorf("failed to commit confirmed Transaction with %s: %w", transactionID, err))
+			}
+		})
+	}))
+
+	// remove Rejected Branches from the ledger state once they had enough time to settle
+	if l.tangle.Options.BranchPruningDelay > 0 {
+		l.BranchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+			defer branchDAGEvent.Release()
+
+			l.schedulePruning(branchDAGEvent.Branch.ID())
+		}))
+
+		// the scheduled prunes do not survive a restart, so the Branches that were rejected before are scheduled again
+		l.BranchDAG.ForEachBranch(func(branch ledgerstate.Branch) {
+			if branch.InclusionState() == ledgerstate.Rejected {
+				l.schedulePruning(branch.ID())
+			}
+		})
 	}
 }
 
 // Shutdown shuts down the LedgerState and persists its state.
 func (l *LedgerState) Shutdown() {
+	l.branchPruner.Shutdown(timedexecutor.CancelPendingTasks)
 	l.utxoDAG.Shutdown()
 	l.BranchDAG.Shutdown()
 }
 
+// schedulePruning schedules the removal of the given Rejected Branch after the configured pruning delay.
+func (l *LedgerState) schedulePruning(branchID ledgerstate.BranchID) {
+	l.branchPruner.ExecuteAfter(func() {
+		l.pruneRejectedBranch(branchID)
+	}, l.tangle.Options.BranchPruningDelay)
+}
+
+// pruneRejectedBranch removes the given Branch and its Transactions from the ledger state if it is still Rejected.
+func (l *LedgerState) pruneRejectedBranch(branchID ledgerstate.BranchID) {
+	stillRejected := false
+	l.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		stillRejected = branch.InclusionState() == ledgerstate.Rejected
+	})
+	if !stillRejected {
+		return
+	}
+
+	if _, err := l.utxoDAG.PruneRejectedBranch(branchID); err != nil {
+		l.tangle.Events.Error.Trigger(xerrors.Errorf("failed to prune rejected Branch with %s: %w", branchID, err))
+	}
+}
+
 // InheritBranch implements the inheritance rules for Branches in the Tangle. It returns a single inherited Branch
 // and automatically creates an AggregatedBranch if necessary.
 func (l *LedgerState) InheritBranch(referencedBranchIDs ledgerstate.BranchIDs) (inheritedBranch ledgerstate.BranchID, err error) {
@@ -43,6 +107,10 @@ func (l *LedgerState) InheritBranch(referencedBranchIDs ledgerstate.BranchIDs) (
 
 	branchIDsContainRejectedBranch, inheritedBranch := l.BranchDAG.BranchIDsContainRejectedBranch(referencedBranchIDs)
 	if branchIDsContainRejectedBranch {
+		// pruned Branches can not be referenced anymore
+		if l.BranchDAG.BranchPruned(inheritedBranch) {
+			inheritedBranch = ledgerstate.InvalidBranchID
+		}
 		return
 	}
 
@@ -89,6 +157,11 @@ func (l *LedgerState) Transaction(transactionID ledgerstate.TransactionID) *ledg
 	return l.utxoDAG.Transaction(transactionID)
 }
 
+// ForEachTransaction iterates over all of the Transactions in the ledger state.
+func (l *LedgerState) ForEachTransaction(consumer func(transaction *ledgerstate.Transaction)) {
+	l.utxoDAG.ForEachTransaction(consumer)
+}
+
 // BookTransaction books the given Transaction into the underlying LedgerState and returns the target Branch and an
 // eventual error.
 func (l *LedgerState) BookTransaction(transaction *ledgerstate.Transaction, messageID MessageID) (targetBranch ledgerstate.BranchID, err error) {
@@ -134,11 +207,13 @@ func (l *LedgerState) TransactionInclusionState(transactionID ledgerstate.Transa
 }
 
 // BranchInclusionState returns the InclusionState of the Branch with the given BranchID which can either be
-// Pending, Confirmed or Rejected.
+// Pending, Confirmed or Rejected. Branches that have been pruned are reported as Rejected.
 func (l *LedgerState) BranchInclusionState(branchID ledgerstate.BranchID) (inclusionState ledgerstate.InclusionState) {
-	l.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+	if !l.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
 		inclusionState = branch.InclusionState()
-	})
+	}) && l.BranchDAG.BranchPruned(branchID) {
+		inclusionState = ledgerstate.Rejected
+	}
 	return
 }
 
@@ -151,12 +226,87 @@ func (l *LedgerState) BranchID(transactionID ledgerstate.TransactionID) (branchI
 }
 
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
-func (l *LedgerState) LoadSnapshot(snapshot map[ledgerstate.TransactionID]map[ledgerstate.Address]*ledgerstate.ColoredBalances) {
+func (l *LedgerState) LoadSnapshot(snapshot *ledgerstate.Snapshot) {
 	l.utxoDAG.LoadSnapshot(snapshot)
 	attachment, _ := l.tangle.Storage.StoreAttachment(ledgerstate.GenesisTransactionID, EmptyMessageID)
 	if attachment != nil {
 		attachment.Release()
 	}
+
+	// the transactions of the unspent outputs are treated as if they were attached to the genesis
+	for _, output := range snapshot.Outputs {
+		attachment, _ := l.tangle.Storage.StoreAttachment(output.ID().TransactionID(), EmptyMessageID)
+		if attachment != nil {
+			attachment.Release()
+		}
+	}
+}
+
+// LoadSnapshotStream loads the outputs of the given snapshot one by one into the UTXO-DAG, so that snapshots with a
+// large amount of outputs can be loaded without holding them in memory. The snapshot is read twice from the current
+// position: the outputs are only stored after the first pass verified that they match the LedgerRoot of the header.
+// It returns the header of the snapshot and the number of loaded outputs.
+func (l *LedgerState) LoadSnapshotStream(snapshot io.ReadSeeker) (header ledgerstate.SnapshotHeader, outputCount int, err error) {
+	start, err := snapshot.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return header, 0, xerrors.Errorf("failed to determine start of snapshot: %w", err)
+	}
+	if header, err = ledgerstate.VerifySnapshot(bufio.NewReader(snapshot)); err != nil {
+		return header, 0, xerrors.Errorf("failed to verify snapshot: %w", err)
+	}
+	if _, err = snapshot.Seek(start, io.SeekStart); err != nil {
+		return header, 0, xerrors.Errorf("failed to rewind snapshot: %w", err)
+	}
+	reader, err := ledgerstate.NewSnapshotReader(bufio.NewReader(snapshot))
+	if err != nil {
+		return header, 0, xerrors.Errorf("failed to read snapshot header: %w", err)
+	}
+
+	attachment, _ := l.tangle.Storage.StoreAttachment(ledgerstate.GenesisTransactionID, EmptyMessageID)
+	if attachment != nil {
+		attachment.Release()
+	}
+
+	for {
+		output, outputMetadata, outputPledge, readErr := reader.Next()
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				return header, outputCount, nil
+			}
+			return header, outputCount, xerrors.Errorf("failed to read output from snapshot: %w", readErr)
+		}
+
+		l.utxoDAG.LoadSnapshotOutput(output, outputMetadata, outputPledge)
+		outputCount++
+
+		// the transactions of the outputs are treated as if they were attached to the genesis
+		attachment, _ := l.tangle.Storage.StoreAttachment(output.ID().TransactionID(), EmptyMessageID)
+		if attachment != nil {
+			attachment.Release()
+		}
+	}
+}
+
+// Snapshot returns a Snapshot of the unspent outputs of the transactions that are accepted by the given filter.
+func (l *LedgerState) Snapshot(filter func(transactionID ledgerstate.TransactionID) bool) *ledgerstate.Snapshot {
+	return l.utxoDAG.Snapshot(filter)
+}
+
+// StateCommitment returns the commitment to the confirmed unspent outputs of the ledger state.
+func (l *LedgerState) StateCommitment() *ledgerstate.StateCommitment {
+	return l.utxoDAG.StateCommitment()
+}
+
+// CommitEpoch applies the confirmed transactions with a timestamp before the end of the given epoch to the
+// StateCommitment and persists the resulting state root as the commitment of the epoch.
+func (l *LedgerState) CommitEpoch(epochIndex ledgerstate.EpochIndex, epochDuration time.Duration) (*ledgerstate.EpochCommitment, error) {
+	return l.utxoDAG.CommitEpoch(epochIndex, epochDuration)
+}
+
+// WriteSnapshot streams the unspent outputs of the transactions that are accepted by the given filter to the given
+// SnapshotWriter.
+func (l *LedgerState) WriteSnapshot(writer *ledgerstate.SnapshotWriter, filter func(transactionID ledgerstate.TransactionID) bool) error {
+	return l.utxoDAG.WriteSnapshot(writer, filter)
 }
 
 // Output returns the Output with the given ID.
@@ -164,6 +314,11 @@ func (l *LedgerState) Output(outputID ledgerstate.OutputID) *ledgerstate.CachedO
 	return l.utxoDAG.Output(outputID)
 }
 
+// OutputPledge returns the OutputPledge of the transaction that created the Output with the given ID.
+func (l *LedgerState) OutputPledge(outputID ledgerstate.OutputID) *ledgerstate.OutputPledge {
+	return l.utxoDAG.OutputPledge(outputID)
+}
+
 // OutputMetadata returns the OutputMetadata with the given ID.
 func (l *LedgerState) OutputMetadata(outputID ledgerstate.OutputID) *ledgerstate.CachedOutputMetadata {
 	return l.utxoDAG.OutputMetadata(outputID)
@@ -177,9 +332,47 @@ func (l *LedgerState) OutputsOnAddress(address ledgerstate.Address) (cachedOutpu
 	return
 }
 
-// CheckTransaction contains fast checks that have to be performed before booking a Transaction.
+// AddressHistoryEnabled returns true if the Transactions that credit or debit an Address are indexed.
+func (l *LedgerState) AddressHistoryEnabled() bool {
+	return l.tangle.Options.AddressHistoryEnabled
+}
+
+// AddressTransactionHistory returns a page of at most limit entries of the transaction history of the given Address
+// (ordered from the newest to the oldest Transaction) that starts after the given cursor. The returned cursor can be
+// used to retrieve the next page and is nil if there are no further entries.
+func (l *LedgerState) AddressTransactionHistory(address ledgerstate.Address, cursor *ledgerstate.AddressHistoryCursor, limit int) ([]*ledgerstate.AddressTransactionMapping, *ledgerstate.AddressHistoryCursor, error) {
+	return l.utxoDAG.AddressTransactionHistory(address, cursor, limit)
+}
+
+// ColorSupply returns the total, burned and circulating supply of the given Color.
+func (l *LedgerState) ColorSupply(color ledgerstate.Color) (*ledgerstate.ColorSupply, error) {
+	return l.utxoDAG.ColorSupply(color)
+}
+
+// ColorMetadata retrieves the AssetMetadata that was attached to the Transaction that minted the given Color.
+func (l *LedgerState) ColorMetadata(color ledgerstate.Color) *ledgerstate.CachedColorMetadata {
+	return l.utxoDAG.ColorMetadata(color)
+}
+
+// CheckTransaction contains fast checks that have to be performed before booking a Transaction. In addition to the
+// checks of the UTXODAG, it verifies that the Transaction complies with the dust protection rules, so that the node
+// does not issue Transactions that end up in the InvalidBranch or exceed the dust allowance of the confirmed ledger
+// state.
 func (l *LedgerState) CheckTransaction(transaction *ledgerstate.Transaction) (valid bool, err error) {
-	return l.utxoDAG.CheckTransaction(transaction)
+	if valid, err = l.utxoDAG.CheckTransaction(transaction); !valid {
+		return
+	}
+
+	if err = l.utxoDAG.CheckDustProtection(transaction); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ValidateTransaction runs the given Transaction through all checks of the ledger state without booking it.
+func (l *LedgerState) ValidateTransaction(transaction *ledgerstate.Transaction) (*ledgerstate.TransactionValidation, error) {
+	return l.utxoDAG.ValidateTransaction(transaction)
 }
 
 // ConsumedOutputs returns the consumed (cached)Outputs of the given Transaction.
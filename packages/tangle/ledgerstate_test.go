@@ -1,8 +1,12 @@
 package tangle
 
 import (
+	"io"
+	"io/ioutil"
 	"testing"
+	"time"
 
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/require"
 
@@ -26,8 +30,83 @@ func TestLoadSnapshot(t *testing.T) {
 		},
 	}
 
-	ledgerState.LoadSnapshot(snapshot)
+	ledgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 	inclusionState, err := ledgerState.TransactionInclusionState(ledgerstate.GenesisTransactionID)
 	require.NoError(t, err)
 	assert.Equal(t, ledgerstate.Confirmed, inclusionState)
 }
+
+func TestLoadSnapshotStream(t *testing.T) {
+	wallets := createWallets(1)
+	output := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	output.SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{1}, 0))
+
+	file, err := ioutil.TempFile(t.TempDir(), "snapshot")
+	require.NoError(t, err)
+	defer file.Close()
+	writer, err := ledgerstate.NewSnapshotWriter(file, 22, time.Now())
+	require.NoError(t, err)
+	outputMetadata := ledgerstate.NewOutputMetadata(output.ID())
+	outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+	require.NoError(t, writer.WriteOutput(output, outputMetadata, nil))
+	_, err = writer.Close()
+	require.NoError(t, err)
+
+	// tamper with the balance of the output, which is followed by its output pledge at the end of the snapshot
+	fileInfo, err := file.Stat()
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte{1}, fileInfo.Size()-ledgerstate.OutputPledgeLength-ledgerstate.AddressLength-8)
+	require.NoError(t, err)
+
+	// the outputs of a snapshot that does not match its ledger root are not stored
+	tangle := New()
+	defer tangle.Shutdown()
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	_, _, err = tangle.LedgerState.LoadSnapshotStream(file)
+	require.ErrorIs(t, err, ledgerstate.ErrSnapshotLedgerRootMismatch)
+	require.False(t, tangle.LedgerState.Output(output.ID()).Consume(func(ledgerstate.Output) {}))
+
+	// restore the balance of the output
+	_, err = file.WriteAt([]byte{100}, fileInfo.Size()-ledgerstate.OutputPledgeLength-ledgerstate.AddressLength-8)
+	require.NoError(t, err)
+	_, err = file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	header, outputCount, err := tangle.LedgerState.LoadSnapshotStream(file)
+	require.NoError(t, err)
+	assert.Equal(t, header.OutputCount, uint64(1))
+	assert.Equal(t, outputCount, 1)
+	require.True(t, tangle.LedgerState.Output(output.ID()).Consume(func(ledgerstate.Output) {}))
+}
+
+func TestLedgerState_PruneRejectedBranchesAfterRestart(t *testing.T) {
+	store := mapdb.NewMapDB()
+
+	// reject a Branch (by finalizing it without liking it) while the pruning is disabled
+	tangle := New(Store(store))
+	conflictIDs := ledgerstate.NewConflictIDs(ledgerstate.NewConflictID(ledgerstate.NewOutputID(ledgerstate.TransactionID{1}, 0)))
+	branchIDs := []ledgerstate.BranchID{{2}, {3}}
+	for _, branchID := range branchIDs {
+		cachedBranch, _, err := tangle.LedgerState.BranchDAG.CreateConflictBranch(branchID, ledgerstate.NewBranchIDs(ledgerstate.MasterBranchID), conflictIDs)
+		require.NoError(t, err)
+		cachedBranch.Release()
+	}
+	_, err := tangle.LedgerState.BranchDAG.SetBranchFinalized(branchIDs[1], true)
+	require.NoError(t, err)
+	require.True(t, tangle.LedgerState.BranchDAG.Branch(branchIDs[1]).Consume(func(branch ledgerstate.Branch) {
+		require.Equal(t, ledgerstate.Rejected, branch.InclusionState())
+	}))
+	tangle.Shutdown()
+
+	// the Branches that were rejected before the restart are pruned as well
+	tangle = New(Store(store), BranchPruning(time.Millisecond))
+	defer tangle.Shutdown()
+	tangle.Setup()
+
+	require.Eventually(t, func() bool {
+		return tangle.LedgerState.BranchDAG.BranchPruned(branchIDs[1])
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, tangle.LedgerState.BranchDAG.BranchPruned(branchIDs[0]), false)
+	assert.Equal(t, tangle.LedgerState.BranchInclusionState(branchIDs[1]), ledgerstate.Rejected)
+}
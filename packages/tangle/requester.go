@@ -11,18 +11,28 @@ const (
 	// DefaultRetryInterval defines the Default Retry Interval of the message requester.
 	DefaultRetryInterval = 10 * time.Second
 
+	// DefaultRequestBatchInterval defines the default time that requests are collected before they are sent in a batch.
+	DefaultRequestBatchInterval = 100 * time.Millisecond
+
+	// DefaultMaxRequestBatchSize defines the default maximum amount of message ids that are sent in a single batch.
+	DefaultMaxRequestBatchSize = 1024
+
 	// the maximum amount of requests before we abort
 	maxRequestThreshold = 500
 )
 
 // RequesterOptions holds options for a message requester.
 type RequesterOptions struct {
-	retryInterval time.Duration
+	retryInterval        time.Duration
+	requestBatchInterval time.Duration
+	maxRequestBatchSize  int
 }
 
 func newRequesterOptions(optionalOptions []RequesterOption) *RequesterOptions {
 	result := &RequesterOptions{
-		retryInterval: 10 * time.Second,
+		retryInterval:        10 * time.Second,
+		requestBatchInterval: DefaultRequestBatchInterval,
+		maxRequestBatchSize:  DefaultMaxRequestBatchSize,
 	}
 
 	for _, optionalOption := range optionalOptions {
@@ -42,6 +52,21 @@ func RetryInterval(interval time.Duration) RequesterOption {
 	}
 }
 
+// RequestBatchInterval creates an option which sets the time that requests are collected before they are sent in a
+// batch.
+func RequestBatchInterval(interval time.Duration) RequesterOption {
+	return func(args *RequesterOptions) {
+		args.requestBatchInterval = interval
+	}
+}
+
+// MaxRequestBatchSize creates an option which sets the maximum amount of message ids that are sent in a single batch.
+func MaxRequestBatchSize(size int) RequesterOption {
+	return func(args *RequesterOptions) {
+		args.maxRequestBatchSize = size
+	}
+}
+
 // region Requester /////////////////////////////////////////////////////////////////////////////////////////////
 
 // Requester takes care of requesting messages.
@@ -52,6 +77,10 @@ type Requester struct {
 	Events            *MessageRequesterEvents
 
 	scheduledRequestsMutex sync.RWMutex
+
+	pendingRequests      MessageIDs
+	pendingRequestsTimer *time.Timer
+	pendingRequestsMutex sync.Mutex
 }
 
 // MessageExistsFunc is a function that tells if a message exists.
@@ -98,7 +127,7 @@ func (r *Requester) StartRequest(id MessageID) {
 	// schedule the next request and trigger the event
 	r.scheduledRequests[id] = time.AfterFunc(r.options.retryInterval, r.createReRequest(id, 0))
 	r.scheduledRequestsMutex.Unlock()
-	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
+	r.queueRequest(id)
 }
 
 // StopRequest stops requests for the given message to further happen.
@@ -113,7 +142,7 @@ func (r *Requester) StopRequest(id MessageID) {
 }
 
 func (r *Requester) reRequest(id MessageID, count int) {
-	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
+	r.queueRequest(id)
 
 	// as we schedule a request at most once per id we do not need to make the trigger and the re-schedule atomic
 	r.scheduledRequestsMutex.Lock()
@@ -143,6 +172,46 @@ func (r *Requester) RequestQueueSize() int {
 	return len(r.scheduledRequests)
 }
 
+// queueRequest adds the given message to the pending requests that are sent in the next batch.
+func (r *Requester) queueRequest(id MessageID) {
+	r.pendingRequestsMutex.Lock()
+	defer r.pendingRequestsMutex.Unlock()
+
+	r.pendingRequests = append(r.pendingRequests, id)
+
+	if len(r.pendingRequests) >= r.options.maxRequestBatchSize {
+		r.flushPendingRequests()
+		return
+	}
+
+	if r.pendingRequestsTimer == nil {
+		r.pendingRequestsTimer = time.AfterFunc(r.options.requestBatchInterval, func() {
+			r.pendingRequestsMutex.Lock()
+			defer r.pendingRequestsMutex.Unlock()
+
+			r.flushPendingRequests()
+		})
+	}
+}
+
+// flushPendingRequests triggers the SendRequest event for all pending requests (the pendingRequestsMutex needs to be
+// locked).
+func (r *Requester) flushPendingRequests() {
+	if r.pendingRequestsTimer != nil {
+		r.pendingRequestsTimer.Stop()
+		r.pendingRequestsTimer = nil
+	}
+
+	if len(r.pendingRequests) == 0 {
+		return
+	}
+
+	pendingRequests := r.pendingRequests
+	r.pendingRequests = nil
+
+	r.Events.SendRequest.Trigger(&SendRequestEvent{IDs: pendingRequests})
+}
+
 func (r *Requester) createReRequest(msgID MessageID, count int) func() {
 	return func() { r.reRequest(msgID, count) }
 }
@@ -153,7 +222,7 @@ func (r *Requester) createReRequest(msgID MessageID, count int) func() {
 
 // MessageRequesterEvents represents events happening on a message requester.
 type MessageRequesterEvents struct {
-	// Fired when a request for a given message should be sent.
+	// Fired when a batch of requests for the given messages should be sent.
 	SendRequest *events.Event
 }
 
@@ -163,7 +232,7 @@ type MessageRequesterEvents struct {
 
 // SendRequestEvent represents the parameters of sendRequestEventHandler
 type SendRequestEvent struct {
-	ID MessageID
+	IDs MessageIDs
 }
 
 func sendRequestEventHandler(handler interface{}, params ...interface{}) {
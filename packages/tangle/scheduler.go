@@ -1,42 +1,79 @@
 package tangle
 
 import (
+	"math"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/datastructure/set"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/types"
 )
 
 const (
-	inboxCapacity = 64
+	// MinMana is the minimum amount of access mana that the Scheduler assumes for an issuer. It makes sure that issuers
+	// without any access mana still get a (small) share of the outbound rate.
+	MinMana = 1.0
+
+	// DefaultMaxQueueSize is the default maximum number of messages that are buffered per issuer.
+	DefaultMaxQueueSize = 1000
+
+	// DefaultMaxBufferTime is the default maximum time that a message waits in the buffer for its parents to be booked.
+	DefaultMaxBufferTime = 5 * time.Minute
 )
 
 // region Scheduler ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Scheduler is a Tangle component that takes care of scheduling the messages that shall be booked.
+// Scheduler is a Tangle component that takes care of scheduling the messages that shall be booked. It keeps a separate
+// queue for every issuer and selects the next message using a deficit round-robin that is weighted by the access mana
+// of the issuers, so that a single issuer can not starve the others. Messages only become ready to be scheduled once
+// all of their parents are booked and are discarded if one of their parents turns out to be invalid or if they are not
+// ready within the maximum buffer time.
 type Scheduler struct {
 	Events *SchedulerEvents
 
-	tangle                 *Tangle
-	inbox                  chan MessageID
-	scheduledMessages      set.Set
-	allMessagesScheduledWG sync.WaitGroup
-	shutdownSignal         chan struct{}
-	shutdown               sync.WaitGroup
-	shutdownOnce           sync.Once
+	tangle                   *Tangle
+	rate                     time.Duration
+	maxQueueSize             int
+	maxBufferTime            time.Duration
+	accessManaRetriever      AccessManaRetrieveFunc
+	accessManaRetrieverMutex sync.RWMutex
+	queues                   *issuerQueues
+	queuesMutex              sync.Mutex
+	messageSubmitted         chan struct{}
+	scheduledMessages        set.Set
+	allMessagesScheduledWG   sync.WaitGroup
+	shutdownSignal           chan struct{}
+	shutdown                 sync.WaitGroup
+	shutdownOnce             sync.Once
 }
 
 // NewScheduler returns a new scheduler.
 func NewScheduler(tangle *Tangle) (scheduler *Scheduler) {
+	params := tangle.Options.SchedulerParams
+	if params.MaxQueueSize <= 0 {
+		params.MaxQueueSize = DefaultMaxQueueSize
+	}
+	if params.MaxBufferTime <= 0 {
+		params.MaxBufferTime = DefaultMaxBufferTime
+	}
+
 	scheduler = &Scheduler{
 		Events: &SchedulerEvents{
 			MessageScheduled: events.NewEvent(MessageIDCaller),
+			MessageDiscarded: events.NewEvent(MessageIDCaller),
 		},
 
-		tangle:            tangle,
-		inbox:             make(chan MessageID, inboxCapacity),
-		shutdownSignal:    make(chan struct{}),
-		scheduledMessages: set.New(true),
+		tangle:              tangle,
+		rate:                params.Rate,
+		maxQueueSize:        params.MaxQueueSize,
+		maxBufferTime:       params.MaxBufferTime,
+		accessManaRetriever: params.AccessManaRetrieveFunc,
+		queues:              newIssuerQueues(),
+		messageSubmitted:    make(chan struct{}, 1),
+		shutdownSignal:      make(chan struct{}),
+		scheduledMessages:   set.New(true),
 	}
 	scheduler.run()
 
@@ -57,12 +94,67 @@ func (s *Scheduler) Setup() {
 		if s.scheduledMessages.Delete(messageID) {
 			s.allMessagesScheduledWG.Done()
 		}
+
+		// buffered children of an invalid message can never be scheduled
+		s.queuesMutex.Lock()
+		invalidMessages := s.queues.ParentInvalid(messageID)
+		s.queues.Cleanup()
+		s.queuesMutex.Unlock()
+
+		s.discard(invalidMessages)
+	}))
+
+	s.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID MessageID) {
+		s.queuesMutex.Lock()
+		ready := s.queues.ParentBooked(messageID)
+		s.queuesMutex.Unlock()
+
+		if ready {
+			s.wakeUp()
+		}
 	}))
 }
 
-// Schedule schedules the given messageID.
+// SetAccessManaRetriever sets the function that is used to retrieve the access mana of the issuers. It allows to
+// connect the Scheduler to a mana provider that is only available after the Tangle has been created.
+func (s *Scheduler) SetAccessManaRetriever(accessManaRetriever AccessManaRetrieveFunc) {
+	s.accessManaRetrieverMutex.Lock()
+	defer s.accessManaRetrieverMutex.Unlock()
+
+	s.accessManaRetriever = accessManaRetriever
+}
+
+// Schedule adds the given messageID to the queue of its issuer. If the queue of the issuer is full or if one of the
+// parents of the message is invalid, the message is discarded and a MessageDiscarded event is triggered.
 func (s *Scheduler) Schedule(messageID MessageID) {
-	s.inbox <- messageID
+	s.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		// the parents are checked while holding the lock, so that the events of the parents can not be missed
+		s.queuesMutex.Lock()
+		missingParents, invalid := s.missingParents(message)
+		submitted := !invalid && s.queues.Submit(identity.NewID(message.IssuerPublicKey()), &queuedMessage{
+			id:        messageID,
+			size:      len(message.Bytes()),
+			submitted: time.Now(),
+		}, missingParents, s.maxQueueSize)
+		s.queuesMutex.Unlock()
+
+		if !submitted {
+			s.Events.MessageDiscarded.Trigger(messageID)
+			return
+		}
+
+		if len(missingParents) == 0 {
+			s.wakeUp()
+		}
+	})
+}
+
+// BufferSize returns the number of messages that are currently waiting to be scheduled.
+func (s *Scheduler) BufferSize() int {
+	s.queuesMutex.Lock()
+	defer s.queuesMutex.Unlock()
+
+	return s.queues.Size()
 }
 
 // Shutdown shuts down the Scheduler and persists its state.
@@ -75,29 +167,184 @@ func (s *Scheduler) Shutdown() {
 	s.allMessagesScheduledWG.Wait()
 }
 
+// wakeUp signals the Scheduler that the buffered messages need to be checked again. Without a configured rate, the
+// Scheduler only checks them when it is woken up.
+func (s *Scheduler) wakeUp() {
+	select {
+	case s.messageSubmitted <- struct{}{}:
+	default:
+	}
+}
+
 func (s *Scheduler) run() {
 	s.shutdown.Add(1)
 	go func() {
 		defer s.shutdown.Done()
 
+		// without a configured rate, messages are scheduled as soon as they are ready
+		if s.rate <= 0 {
+			expiryTicker := time.NewTicker(s.maxBufferTime)
+			defer expiryTicker.Stop()
+
+			for {
+				select {
+				case <-s.messageSubmitted:
+					for s.scheduleNext() {
+					}
+				case <-expiryTicker.C:
+					for s.scheduleNext() {
+					}
+				case <-s.shutdownSignal:
+					for s.scheduleNext() {
+					}
+					return
+				}
+			}
+		}
+
+		ticker := time.NewTicker(s.rate)
+		defer ticker.Stop()
+
 		for {
 			select {
-			case messageID := <-s.inbox:
-				s.scheduleMessage(messageID)
+			case <-ticker.C:
+				s.scheduleNext()
 			case <-s.shutdownSignal:
-				if len(s.inbox) == 0 {
-					return
+				for s.scheduleNext() {
 				}
+				return
 			}
 		}
 	}()
 }
 
-func (s *Scheduler) scheduleMessage(messageID MessageID) {
-	if !s.parentsBooked(messageID) {
+// scheduleNext selects the next message and schedules it. It returns false if there was no message ready to be
+// scheduled.
+func (s *Scheduler) scheduleNext() (scheduled bool) {
+	messageID, selected := s.selectMessage()
+	if !selected {
+		return false
+	}
+
+	s.scheduleMessage(messageID)
+
+	return true
+}
+
+// selectMessage uses a deficit round-robin, weighted by the access mana of the issuers, to select the next message.
+// Instead of iterating round by round, it computes how many rounds each issuer needs until its first ready message
+// fits into its deficit and selects the issuer that reaches this point first.
+func (s *Scheduler) selectMessage() (messageID MessageID, selected bool) {
+	var expiredMessages []*queuedMessage
+	defer func() {
+		s.discard(expiredMessages)
+	}()
+
+	s.queuesMutex.Lock()
+	defer s.queuesMutex.Unlock()
+
+	expiredMessages = s.queues.Expire(time.Now().Add(-s.maxBufferTime))
+
+	type candidate struct {
+		queue    *issuerQueue
+		message  *queuedMessage
+		quantum  float64
+		rounds   float64
+		position int
+	}
+
+	candidates := make([]*candidate, 0)
+	maxMana := 0.0
+	maxMessageSize := 0
+	s.queues.ForEach(func(queue *issuerQueue, offset int) {
+		readyMessage := queue.FirstReady()
+		if readyMessage == nil {
+			return
+		}
+
+		mana := math.Max(s.accessMana(queue.issuerID), MinMana)
+		maxMana = math.Max(maxMana, mana)
+		if readyMessage.size > maxMessageSize {
+			maxMessageSize = readyMessage.size
+		}
+		candidates = append(candidates, &candidate{queue: queue, message: readyMessage, quantum: mana, position: offset})
+	})
+	s.queues.Cleanup()
+
+	if len(candidates) == 0 {
 		return
 	}
 
+	var winner *candidate
+	for _, c := range candidates {
+		// the issuer with the highest mana can schedule at least one message per round
+		c.quantum = float64(maxMessageSize) * c.quantum / maxMana
+		if missingDeficit := float64(c.message.size) - c.queue.deficit; missingDeficit > 0 {
+			c.rounds = math.Ceil(missingDeficit / c.quantum)
+
+			// the current issuer has already received its quantum in this round
+			if c.position == 0 {
+				c.position = s.queues.Len()
+			}
+		}
+
+		if winner == nil || c.rounds < winner.rounds || (c.rounds == winner.rounds && c.position < winner.position) {
+			winner = c
+		}
+	}
+
+	for _, c := range candidates {
+		c.queue.deficit += winner.rounds * c.quantum
+	}
+	winner.queue.deficit -= float64(winner.message.size)
+	s.queues.Remove(winner.message)
+	s.queues.SetCurrent(winner.queue.issuerID)
+	s.queues.Cleanup()
+
+	return winner.message.id, true
+}
+
+// missingParents returns the parents of the given Message that are not booked, yet. It returns invalid = true if one
+// of the parents is invalid, as the message can then never be scheduled.
+func (s *Scheduler) missingParents(message *Message) (missingParents map[MessageID]types.Empty, invalid bool) {
+	missingParents = make(map[MessageID]types.Empty)
+	message.ForEachParent(func(parent Parent) {
+		if invalid || parent.ID == EmptyMessageID {
+			return
+		}
+
+		if !s.tangle.Storage.MessageMetadata(parent.ID).Consume(func(messageMetadata *MessageMetadata) {
+			invalid = messageMetadata.IsInvalid()
+			if !messageMetadata.IsBooked() {
+				missingParents[parent.ID] = types.Void
+			}
+		}) {
+			missingParents[parent.ID] = types.Void
+		}
+	})
+
+	return missingParents, invalid
+}
+
+// discard triggers the MessageDiscarded event for the given messages that were removed from the buffer.
+func (s *Scheduler) discard(discardedMessages []*queuedMessage) {
+	for _, discardedMessage := range discardedMessages {
+		s.Events.MessageDiscarded.Trigger(discardedMessage.id)
+	}
+}
+
+func (s *Scheduler) accessMana(issuerID identity.ID) float64 {
+	s.accessManaRetrieverMutex.RLock()
+	defer s.accessManaRetrieverMutex.RUnlock()
+
+	if s.accessManaRetriever == nil {
+		return MinMana
+	}
+
+	return s.accessManaRetriever(issuerID)
+}
+
+func (s *Scheduler) scheduleMessage(messageID MessageID) {
 	s.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
 		if messageMetadata.SetScheduled(true) {
 			if s.scheduledMessages.Add(messageID) {
@@ -108,23 +355,27 @@ func (s *Scheduler) scheduleMessage(messageID MessageID) {
 	})
 }
 
-func (s *Scheduler) parentsBooked(messageID MessageID) (parentsBooked bool) {
-	s.tangle.Storage.Message(messageID).Consume(func(message *Message) {
-		parentsBooked = true
-		message.ForEachParent(func(parent Parent) {
-			if !parentsBooked || parent.ID == EmptyMessageID {
-				return
-			}
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-			if !s.tangle.Storage.MessageMetadata(parent.ID).Consume(func(messageMetadata *MessageMetadata) {
-				parentsBooked = messageMetadata.IsBooked()
-			}) {
-				parentsBooked = false
-			}
-		})
-	})
+// region SchedulerParams //////////////////////////////////////////////////////////////////////////////////////////////
 
-	return
+// AccessManaRetrieveFunc is a function type to retrieve the access mana of a node.
+type AccessManaRetrieveFunc func(nodeID identity.ID) float64
+
+// SchedulerParams defines the parameters of the Scheduler.
+type SchedulerParams struct {
+	// Rate defines the minimum time between two scheduled messages (0 means that messages are scheduled as soon as
+	// they are ready).
+	Rate time.Duration
+
+	// MaxQueueSize defines the maximum number of messages that are buffered per issuer.
+	MaxQueueSize int
+
+	// MaxBufferTime defines the maximum time that a message waits in the buffer for its parents to be booked.
+	MaxBufferTime time.Duration
+
+	// AccessManaRetrieveFunc is used to retrieve the access mana of the issuers.
+	AccessManaRetrieveFunc AccessManaRetrieveFunc
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -135,6 +386,9 @@ func (s *Scheduler) parentsBooked(messageID MessageID) (parentsBooked bool) {
 type SchedulerEvents struct {
 	// MessageScheduled is triggered when a message is ready to be scheduled.
 	MessageScheduled *events.Event
+
+	// MessageDiscarded is triggered when a message is dropped by the Scheduler.
+	MessageDiscarded *events.Event
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
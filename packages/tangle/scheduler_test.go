@@ -4,8 +4,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 )
 
 func TestScheduler(t *testing.T) {
@@ -34,6 +39,7 @@ func TestScheduler(t *testing.T) {
 			messageMetadata.SetBooked(true)
 			tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
 		})
+		tangle.Booker.Events.MessageBooked.Trigger(messageID)
 	}))
 
 	// store messages bypassing the messageStored event
@@ -56,3 +62,229 @@ func TestScheduler(t *testing.T) {
 		return allMessagedScheduled
 	}, 10*time.Second, 100*time.Millisecond)
 }
+
+func TestScheduler_ParentBooked(t *testing.T) {
+	tangle := New()
+	defer tangle.Shutdown()
+
+	tangle.Scheduler.Setup()
+
+	parent := newTestDataMessage("parent")
+	child := newTestParentsDataMessage("child", []MessageID{parent.ID()}, []MessageID{})
+	tangle.Storage.StoreMessage(parent)
+	tangle.Storage.StoreMessage(child)
+
+	scheduled := make(chan MessageID, 1)
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
+		scheduled <- messageID
+	}))
+
+	// the child is buffered until its parent is booked
+	tangle.Scheduler.Schedule(child.ID())
+	select {
+	case <-scheduled:
+		t.Fatal("message was scheduled before its parent was booked")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	tangle.Storage.MessageMetadata(parent.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		messageMetadata.SetBooked(true)
+	})
+	tangle.Booker.Events.MessageBooked.Trigger(parent.ID())
+
+	select {
+	case messageID := <-scheduled:
+		assert.Equal(t, child.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not scheduled after its parent was booked")
+	}
+}
+
+func TestScheduler_Discarded(t *testing.T) {
+	tangle := New(SchedulerConfig(SchedulerParams{
+		Rate:         time.Hour,
+		MaxQueueSize: 2,
+	}))
+	defer tangle.Shutdown()
+
+	tangle.Storage.Setup()
+	tangle.Solidifier.Setup()
+	tangle.Scheduler.Setup()
+
+	discarded := make(chan MessageID, 10)
+	tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
+		discarded <- messageID
+	}))
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
+	}))
+
+	messages := []*Message{newTestDataMessage("A"), newTestDataMessage("B"), newTestDataMessage("C")}
+	for _, message := range messages {
+		tangle.Storage.StoreMessage(message)
+	}
+
+	select {
+	case messageID := <-discarded:
+		assert.Equal(t, messages[2].ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not discarded")
+	}
+	assert.Equal(t, 2, tangle.Scheduler.BufferSize())
+}
+
+func TestScheduler_InvalidParent(t *testing.T) {
+	tangle := New()
+	defer tangle.Shutdown()
+
+	tangle.Scheduler.Setup()
+
+	discarded := make(chan MessageID, 10)
+	tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
+		discarded <- messageID
+	}))
+
+	// a single invalid parent invalidates the message, no matter in which order the parents are checked
+	invalidParent := newTestDataMessage("invalid")
+	validParent := newTestDataMessage("valid")
+	for _, parent := range []*Message{invalidParent, validParent} {
+		tangle.Storage.StoreMessage(parent)
+		tangle.Storage.MessageMetadata(parent.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetBooked(true)
+		})
+	}
+	tangle.Storage.MessageMetadata(invalidParent.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		messageMetadata.SetInvalid(true)
+	})
+	for _, parents := range [][]MessageID{{invalidParent.ID(), validParent.ID()}, {validParent.ID(), invalidParent.ID()}} {
+		child := newTestParentsDataMessage("child", parents, []MessageID{})
+		tangle.Storage.StoreMessage(child)
+		tangle.Scheduler.Schedule(child.ID())
+
+		select {
+		case messageID := <-discarded:
+			assert.Equal(t, child.ID(), messageID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("message with an invalid parent was not discarded")
+		}
+	}
+
+	// buffered messages are discarded as soon as one of their parents becomes invalid
+	pendingParent := newTestDataMessage("pending")
+	child := newTestParentsDataMessage("child", []MessageID{pendingParent.ID()}, []MessageID{})
+	tangle.Storage.StoreMessage(pendingParent)
+	tangle.Storage.StoreMessage(child)
+	tangle.Scheduler.Schedule(child.ID())
+	assert.Equal(t, 1, tangle.Scheduler.BufferSize())
+
+	tangle.Events.MessageInvalid.Trigger(pendingParent.ID())
+	select {
+	case messageID := <-discarded:
+		assert.Equal(t, child.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("buffered message with an invalid parent was not discarded")
+	}
+	assert.Equal(t, 0, tangle.Scheduler.BufferSize())
+}
+
+func TestScheduler_Expired(t *testing.T) {
+	tangle := New(SchedulerConfig(SchedulerParams{
+		MaxBufferTime: 100 * time.Millisecond,
+	}))
+	defer tangle.Shutdown()
+
+	tangle.Scheduler.Setup()
+
+	discarded := make(chan MessageID, 10)
+	tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
+		discarded <- messageID
+	}))
+
+	// the parent is never booked, so the child never becomes ready
+	parent := newTestDataMessage("parent")
+	child := newTestParentsDataMessage("child", []MessageID{parent.ID()}, []MessageID{})
+	tangle.Storage.StoreMessage(parent)
+	tangle.Storage.StoreMessage(child)
+	tangle.Scheduler.Schedule(child.ID())
+
+	select {
+	case messageID := <-discarded:
+		assert.Equal(t, child.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stale message was not discarded")
+	}
+	assert.Equal(t, 0, tangle.Scheduler.BufferSize())
+}
+
+func TestScheduler_ShutdownDrainsQueues(t *testing.T) {
+	tangle := New(SchedulerConfig(SchedulerParams{
+		Rate: time.Hour,
+	}))
+	defer tangle.Shutdown()
+
+	tangle.Scheduler.Setup()
+
+	scheduled := make(chan MessageID, 10)
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
+		scheduled <- messageID
+	}))
+
+	messages := []*Message{newTestDataMessage("A"), newTestDataMessage("B"), newTestDataMessage("C")}
+	for _, message := range messages {
+		tangle.Storage.StoreMessage(message)
+		tangle.Scheduler.Schedule(message.ID())
+	}
+	assert.Equal(t, len(messages), tangle.Scheduler.BufferSize())
+
+	// the ready messages are scheduled on shutdown without waiting for the rate
+	tangle.Scheduler.Shutdown()
+	assert.Len(t, scheduled, len(messages))
+	assert.Equal(t, 0, tangle.Scheduler.BufferSize())
+}
+
+func TestScheduler_Fairness(t *testing.T) {
+	issuerA := identity.GenerateLocalIdentity()
+	issuerB := identity.GenerateLocalIdentity()
+	accessMana := map[identity.ID]float64{
+		issuerA.ID(): 300,
+		issuerB.ID(): 100,
+	}
+
+	tangle := New(SchedulerConfig(SchedulerParams{
+		Rate: time.Hour,
+		AccessManaRetrieveFunc: func(nodeID identity.ID) float64 {
+			return accessMana[nodeID]
+		},
+	}))
+	defer tangle.Shutdown()
+
+	tangle.Scheduler.Setup()
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID MessageID) {
+		tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
+	}))
+
+	const messagesPerIssuer = 100
+	for i := 0; i < messagesPerIssuer; i++ {
+		for _, issuer := range []*identity.LocalIdentity{issuerA, issuerB} {
+			message := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, time.Now(), issuer.PublicKey(), nextSequenceNumber(), payload.NewGenericDataPayload([]byte("test")), 0, ed25519.Signature{})
+			tangle.Storage.StoreMessage(message)
+			tangle.Scheduler.Schedule(message.ID())
+		}
+	}
+
+	scheduled := make(map[identity.ID]int)
+	for i := 0; i < messagesPerIssuer; i++ {
+		messageID, selected := tangle.Scheduler.selectMessage()
+		require.True(t, selected)
+
+		tangle.Storage.Message(messageID).Consume(func(message *Message) {
+			scheduled[identity.NewID(message.IssuerPublicKey())]++
+		})
+	}
+
+	// the issuers get a share of the scheduled messages that is proportional to their access mana
+	assert.InDelta(t, 75, scheduled[issuerA.ID()], 2)
+	assert.InDelta(t, 25, scheduled[issuerB.ID()], 2)
+}
@@ -0,0 +1,273 @@
+package tangle
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/types"
+)
+
+// region issuerQueues /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// issuerQueues is the buffer of the Scheduler. It holds one queue per issuer and keeps the queues in a ring that is
+// used for the round-robin. It tracks the parents that the buffered messages are still waiting for, so that the
+// readiness of the messages is updated by the events of their parents instead of being polled.
+type issuerQueues struct {
+	queues   map[identity.ID]*issuerQueue
+	ring     []identity.ID
+	current  int
+	size     int
+	messages map[MessageID]*queuedMessage
+	children map[MessageID]map[MessageID]*queuedMessage
+}
+
+// newIssuerQueues returns a new empty buffer.
+func newIssuerQueues() *issuerQueues {
+	return &issuerQueues{
+		queues:   make(map[identity.ID]*issuerQueue),
+		ring:     make([]identity.ID, 0),
+		messages: make(map[MessageID]*queuedMessage),
+		children: make(map[MessageID]map[MessageID]*queuedMessage),
+	}
+}
+
+// Submit appends the given message to the queue of its issuer. The message only becomes ready once all of the given
+// missing parents are booked. It returns false if the queue of the issuer is full or if the message is already buffered.
+func (i *issuerQueues) Submit(issuerID identity.ID, message *queuedMessage, missingParents map[MessageID]types.Empty, maxQueueSize int) (submitted bool) {
+	if _, exists := i.messages[message.id]; exists {
+		return false
+	}
+
+	queue, exists := i.queues[issuerID]
+	if !exists {
+		queue = newIssuerQueue(issuerID)
+		i.queues[issuerID] = queue
+		i.ring = append(i.ring, issuerID)
+	}
+
+	if queue.Len() >= maxQueueSize {
+		return false
+	}
+
+	message.queue = queue
+	message.missingParents = missingParents
+	for parentID := range missingParents {
+		if _, exists := i.children[parentID]; !exists {
+			i.children[parentID] = make(map[MessageID]*queuedMessage)
+		}
+		i.children[parentID][message.id] = message
+	}
+	i.messages[message.id] = message
+	queue.Append(message)
+	i.size++
+
+	return true
+}
+
+// ParentBooked marks the given parent as booked in its buffered children. It returns true if one of the children
+// became ready.
+func (i *issuerQueues) ParentBooked(parentID MessageID) (ready bool) {
+	for _, child := range i.children[parentID] {
+		delete(child.missingParents, parentID)
+		if len(child.missingParents) == 0 {
+			child.queue.SetReady(child)
+			ready = true
+		}
+	}
+	delete(i.children, parentID)
+
+	return ready
+}
+
+// ParentInvalid removes the buffered children of the given invalid parent (and the invalid message itself), as they
+// can never be scheduled, and returns them.
+func (i *issuerQueues) ParentInvalid(parentID MessageID) (invalidMessages []*queuedMessage) {
+	if message, exists := i.messages[parentID]; exists {
+		i.Remove(message)
+		invalidMessages = append(invalidMessages, message)
+	}
+	for _, child := range i.children[parentID] {
+		i.Remove(child)
+		invalidMessages = append(invalidMessages, child)
+	}
+
+	return invalidMessages
+}
+
+// Expire removes the messages that were submitted before the given time and are still not ready, yet, and returns
+// them.
+func (i *issuerQueues) Expire(submittedBefore time.Time) (expiredMessages []*queuedMessage) {
+	for _, queue := range i.queues {
+		for _, message := range queue.Expired(submittedBefore) {
+			i.Remove(message)
+			expiredMessages = append(expiredMessages, message)
+		}
+	}
+
+	return expiredMessages
+}
+
+// Remove removes the given message from the buffer.
+func (i *issuerQueues) Remove(message *queuedMessage) {
+	for parentID := range message.missingParents {
+		delete(i.children[parentID], message.id)
+		if len(i.children[parentID]) == 0 {
+			delete(i.children, parentID)
+		}
+	}
+	delete(i.messages, message.id)
+	message.queue.Remove(message)
+}
+
+// ForEach iterates over all queues in round-robin order, starting with the current one. The offset passed to the
+// consumer is the distance of the queue to the current queue in the ring.
+func (i *issuerQueues) ForEach(consumer func(queue *issuerQueue, offset int)) {
+	for offset := 0; offset < len(i.ring); offset++ {
+		consumer(i.queues[i.ring[(i.current+offset)%len(i.ring)]], offset)
+	}
+}
+
+// SetCurrent moves the round-robin pointer to the queue of the given issuer.
+func (i *issuerQueues) SetCurrent(issuerID identity.ID) {
+	for index, ringIssuerID := range i.ring {
+		if ringIssuerID == issuerID {
+			i.current = index
+			return
+		}
+	}
+}
+
+// Cleanup removes all empty queues from the ring (which resets their deficit) and updates the total size. If the
+// current queue is removed, the pointer moves on to the next non-empty queue.
+func (i *issuerQueues) Cleanup() {
+	var currentIssuerID *identity.ID
+	i.ForEach(func(queue *issuerQueue, _ int) {
+		if currentIssuerID == nil && queue.Len() > 0 {
+			currentIssuerID = &queue.issuerID
+		}
+	})
+
+	i.size = 0
+	i.current = 0
+	ring := make([]identity.ID, 0, len(i.ring))
+	for _, issuerID := range i.ring {
+		queue := i.queues[issuerID]
+		if queue.Len() == 0 {
+			delete(i.queues, issuerID)
+			continue
+		}
+
+		if issuerID == *currentIssuerID {
+			i.current = len(ring)
+		}
+		i.size += queue.Len()
+		ring = append(ring, issuerID)
+	}
+	i.ring = ring
+}
+
+// Len returns the number of issuers that have messages in the buffer.
+func (i *issuerQueues) Len() int {
+	return len(i.ring)
+}
+
+// Size returns the total number of messages in the buffer.
+func (i *issuerQueues) Size() int {
+	return i.size
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region issuerQueue //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// issuerQueue is the queue of the messages of a single issuer together with its deficit. The messages that still wait
+// for their parents and the ones that are ready to be scheduled are kept in separate FIFO queues.
+type issuerQueue struct {
+	issuerID        identity.ID
+	waitingMessages *list.List
+	readyMessages   *list.List
+	deficit         float64
+}
+
+// newIssuerQueue returns a new empty queue for the given issuer.
+func newIssuerQueue(issuerID identity.ID) *issuerQueue {
+	return &issuerQueue{
+		issuerID:        issuerID,
+		waitingMessages: list.New(),
+		readyMessages:   list.New(),
+	}
+}
+
+// Append adds the message to the end of the queue.
+func (q *issuerQueue) Append(message *queuedMessage) {
+	if len(message.missingParents) == 0 {
+		message.element = q.readyMessages.PushBack(message)
+		message.ready = true
+		return
+	}
+
+	message.element = q.waitingMessages.PushBack(message)
+}
+
+// SetReady moves the message to the end of the ready messages.
+func (q *issuerQueue) SetReady(message *queuedMessage) {
+	if message.ready {
+		return
+	}
+
+	q.waitingMessages.Remove(message.element)
+	message.element = q.readyMessages.PushBack(message)
+	message.ready = true
+}
+
+// Remove removes the message from the queue.
+func (q *issuerQueue) Remove(message *queuedMessage) {
+	if message.ready {
+		q.readyMessages.Remove(message.element)
+		return
+	}
+
+	q.waitingMessages.Remove(message.element)
+}
+
+// FirstReady returns the oldest message of the queue that is ready to be scheduled (nil if there is none).
+func (q *issuerQueue) FirstReady() (readyMessage *queuedMessage) {
+	if element := q.readyMessages.Front(); element != nil {
+		return element.Value.(*queuedMessage)
+	}
+
+	return nil
+}
+
+// Expired returns the messages that were submitted before the given time and are not ready, yet.
+func (q *issuerQueue) Expired(submittedBefore time.Time) (expiredMessages []*queuedMessage) {
+	for element := q.waitingMessages.Front(); element != nil; element = element.Next() {
+		message := element.Value.(*queuedMessage)
+		if !message.submitted.Before(submittedBefore) {
+			break
+		}
+
+		expiredMessages = append(expiredMessages, message)
+	}
+
+	return expiredMessages
+}
+
+// Len returns the number of messages in the queue.
+func (q *issuerQueue) Len() int {
+	return q.waitingMessages.Len() + q.readyMessages.Len()
+}
+
+// queuedMessage is an element of the issuerQueue.
+type queuedMessage struct {
+	id             MessageID
+	size           int
+	submitted      time.Time
+	missingParents map[MessageID]types.Empty
+	queue          *issuerQueue
+	element        *list.Element
+	ready          bool
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,353 @@
+package tangle
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/types"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+)
+
+// region LocalSnapshot ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// maxLocalSnapshotElementSize is the maximum size of a serialized element of a LocalSnapshot. The Markers of a
+// Sequence can outgrow a single Message, so the bound is more generous than the one of the Outputs of the ledger state,
+// but it still prevents corrupted length prefixes from allocating arbitrary amounts of memory.
+const maxLocalSnapshotElementSize = 16 * 1024 * 1024
+
+// LocalSnapshot contains everything that is needed to boot a node from a pruned Tangle: the ledger state at the pruning
+// boundary, the solid entry points (the pruned Messages that are still referenced by the remaining Messages) and the
+// state of the Markers.
+type LocalSnapshot struct {
+	LedgerState                 *ledgerstate.Snapshot
+	SolidEntryPoints            []*SolidEntryPoint
+	Sequences                   []*markers.Sequence
+	SequenceAliasMappings       []*markers.SequenceAliasMapping
+	MarkerIndexBranchIDMappings []*MarkerIndexBranchIDMapping
+
+	// messagesToPrune contains the Messages that are covered by the snapshot (it is not serialized).
+	messagesToPrune MessageIDs
+}
+
+// NewLocalSnapshot returns a new empty LocalSnapshot.
+func NewLocalSnapshot() *LocalSnapshot {
+	return &LocalSnapshot{
+		LedgerState:                 ledgerstate.NewSnapshot(),
+		SolidEntryPoints:            make([]*SolidEntryPoint, 0),
+		Sequences:                   make([]*markers.Sequence, 0),
+		SequenceAliasMappings:       make([]*markers.SequenceAliasMapping, 0),
+		MarkerIndexBranchIDMappings: make([]*MarkerIndexBranchIDMapping, 0),
+	}
+}
+
+// WriteTo writes the LocalSnapshot to the given writer in the following format:
+//	ledger_state(see ledgerstate.Snapshot)
+//	solid_entry_point_count(int64)
+//	-> solid_entry_point_count * message_length(int64)+message+metadata_length(int64)+metadata
+//	sequence_count(int64)
+//	-> sequence_count * sequence_length(int64)+sequence
+//	sequence_alias_mapping_count(int64)
+//	-> sequence_alias_mapping_count * mapping_length(int64)+mapping
+//	marker_index_branch_id_mapping_count(int64)
+//	-> marker_index_branch_id_mapping_count * mapping_length(int64)+mapping
+func (l *LocalSnapshot) WriteTo(writer io.Writer) (int64, error) {
+	bytesWritten, err := l.LedgerState.WriteTo(writer)
+	if err != nil {
+		return bytesWritten, fmt.Errorf("unable to write ledger state: %w", err)
+	}
+
+	solidEntryPointsBytes := make([][]byte, 0, 2*len(l.SolidEntryPoints))
+	for _, solidEntryPoint := range l.SolidEntryPoints {
+		solidEntryPointsBytes = append(solidEntryPointsBytes, solidEntryPoint.Message.Bytes(), solidEntryPoint.MessageMetadata.Bytes())
+	}
+	sequencesBytes := make([][]byte, 0, len(l.Sequences))
+	for _, sequence := range l.Sequences {
+		sequencesBytes = append(sequencesBytes, sequence.Bytes())
+	}
+	sequenceAliasMappingsBytes := make([][]byte, 0, len(l.SequenceAliasMappings))
+	for _, sequenceAliasMapping := range l.SequenceAliasMappings {
+		sequenceAliasMappingsBytes = append(sequenceAliasMappingsBytes, sequenceAliasMapping.Bytes())
+	}
+	markerIndexBranchIDMappingsBytes := make([][]byte, 0, len(l.MarkerIndexBranchIDMappings))
+	for _, markerIndexBranchIDMapping := range l.MarkerIndexBranchIDMappings {
+		markerIndexBranchIDMappingsBytes = append(markerIndexBranchIDMappingsBytes, markerIndexBranchIDMapping.Bytes())
+	}
+
+	for _, section := range []struct {
+		name     string
+		count    int
+		elements [][]byte
+	}{
+		{"solid entry points", len(l.SolidEntryPoints), solidEntryPointsBytes},
+		{"sequences", len(l.Sequences), sequencesBytes},
+		{"sequence alias mappings", len(l.SequenceAliasMappings), sequenceAliasMappingsBytes},
+		{"marker index branch id mappings", len(l.MarkerIndexBranchIDMappings), markerIndexBranchIDMappingsBytes},
+	} {
+		if err := binary.Write(writer, binary.LittleEndian, int64(section.count)); err != nil {
+			return bytesWritten, fmt.Errorf("unable to write %s count: %w", section.name, err)
+		}
+		bytesWritten += 8
+
+		for _, element := range section.elements {
+			written, err := writeLengthPrefixedBytes(writer, element)
+			bytesWritten += written
+			if err != nil {
+				return bytesWritten, fmt.Errorf("unable to write %s: %w", section.name, err)
+			}
+		}
+	}
+
+	return bytesWritten, nil
+}
+
+// ReadFrom reads the LocalSnapshot from the given reader. This function overrides existing content of the
+// LocalSnapshot.
+func (l *LocalSnapshot) ReadFrom(reader io.Reader) (int64, error) {
+	*l = *NewLocalSnapshot()
+
+	bytesRead, err := l.LedgerState.ReadFrom(reader)
+	if err != nil {
+		return bytesRead, fmt.Errorf("unable to read ledger state: %w", err)
+	}
+
+	for _, section := range []struct {
+		name     string
+		elements int
+		parse    func(elementBytes [][]byte) error
+	}{
+		{"solid entry points", 2, func(elementBytes [][]byte) (err error) {
+			solidEntryPoint := &SolidEntryPoint{}
+			if solidEntryPoint.Message, _, err = MessageFromBytes(elementBytes[0]); err != nil {
+				return err
+			}
+			if solidEntryPoint.MessageMetadata, _, err = MessageMetadataFromBytes(elementBytes[1]); err != nil {
+				return err
+			}
+			l.SolidEntryPoints = append(l.SolidEntryPoints, solidEntryPoint)
+			return nil
+		}},
+		{"sequences", 1, func(elementBytes [][]byte) error {
+			sequence, _, err := markers.SequenceFromBytes(elementBytes[0])
+			if err != nil {
+				return err
+			}
+			l.Sequences = append(l.Sequences, sequence)
+			return nil
+		}},
+		{"sequence alias mappings", 1, func(elementBytes [][]byte) error {
+			sequenceAliasMapping, _, err := markers.SequenceAliasMappingFromBytes(elementBytes[0])
+			if err != nil {
+				return err
+			}
+			l.SequenceAliasMappings = append(l.SequenceAliasMappings, sequenceAliasMapping)
+			return nil
+		}},
+		{"marker index branch id mappings", 1, func(elementBytes [][]byte) error {
+			markerIndexBranchIDMapping, _, err := MarkerIndexBranchIDMappingFromBytes(elementBytes[0])
+			if err != nil {
+				return err
+			}
+			l.MarkerIndexBranchIDMappings = append(l.MarkerIndexBranchIDMappings, markerIndexBranchIDMapping)
+			return nil
+		}},
+	} {
+		var count int64
+		if err := binary.Read(reader, binary.LittleEndian, &count); err != nil {
+			return bytesRead, fmt.Errorf("unable to read %s count: %w", section.name, err)
+		}
+		bytesRead += 8
+
+		for i := int64(0); i < count; i++ {
+			elementBytes := make([][]byte, section.elements)
+			for j := range elementBytes {
+				read, err := readLengthPrefixedBytes(reader, &elementBytes[j])
+				bytesRead += read
+				if err != nil {
+					return bytesRead, fmt.Errorf("unable to read %s: %w", section.name, err)
+				}
+			}
+
+			if err := section.parse(elementBytes); err != nil {
+				return bytesRead, fmt.Errorf("unable to unmarshal %s: %w", section.name, err)
+			}
+		}
+	}
+
+	return bytesRead, nil
+}
+
+// writeLengthPrefixedBytes is an internal utility function that writes the length of the given bytes followed by the
+// bytes themselves.
+func writeLengthPrefixedBytes(writer io.Writer, bytes []byte) (int64, error) {
+	if err := binary.Write(writer, binary.LittleEndian, int64(len(bytes))); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(writer, binary.LittleEndian, bytes); err != nil {
+		return 8, err
+	}
+
+	return 8 + int64(len(bytes)), nil
+}
+
+// readLengthPrefixedBytes is an internal utility function that reads bytes that were written by
+// writeLengthPrefixedBytes.
+func readLengthPrefixedBytes(reader io.Reader, bytes *[]byte) (int64, error) {
+	var length int64
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return 0, err
+	}
+	if length < 0 || length > maxLocalSnapshotElementSize {
+		return 8, fmt.Errorf("length %d exceeds the maximum of %d bytes: %w", length, maxLocalSnapshotElementSize, cerrors.ErrParseBytesFailed)
+	}
+	*bytes = make([]byte, length)
+	if err := binary.Read(reader, binary.LittleEndian, *bytes); err != nil {
+		return 8, err
+	}
+
+	return 8 + length, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SolidEntryPoint //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SolidEntryPoint is a pruned Message that is still referenced by the remaining Messages of the Tangle. It is kept
+// (together with its metadata) so that the Messages referencing it can still be solidified and booked.
+type SolidEntryPoint struct {
+	Message         *Message
+	MessageMetadata *MessageMetadata
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Tangle snapshot functions ////////////////////////////////////////////////////////////////////////////////////
+
+// PruneLocalSnapshot deletes all Messages (including their metadata, approvers and attachments) that are covered by
+// the given LocalSnapshot, i.e. the confirmed Messages that were issued before its boundary and that are not needed as
+// solid entry points. It returns the number of pruned Messages.
+func (t *Tangle) PruneLocalSnapshot(snapshot *LocalSnapshot) (prunedMessages int) {
+	for _, messageID := range snapshot.messagesToPrune {
+		t.Storage.PruneMessage(messageID)
+	}
+
+	return len(snapshot.messagesToPrune)
+}
+
+// LoadLocalSnapshot loads the given LocalSnapshot, so that the node can continue to process the Messages that were
+// issued after the boundary of the snapshot.
+func (t *Tangle) LoadLocalSnapshot(snapshot *LocalSnapshot) {
+	t.LedgerState.LoadSnapshot(snapshot.LedgerState)
+
+	for _, sequence := range snapshot.Sequences {
+		t.Booker.MarkersManager.ImportSequence(sequence)
+	}
+	for _, sequenceAliasMapping := range snapshot.SequenceAliasMappings {
+		t.Booker.MarkersManager.ImportSequenceAliasMapping(sequenceAliasMapping)
+	}
+	for _, markerIndexBranchIDMapping := range snapshot.MarkerIndexBranchIDMappings {
+		t.Storage.StoreMarkerIndexBranchIDMapping(markerIndexBranchIDMapping)
+	}
+	for _, solidEntryPoint := range snapshot.SolidEntryPoints {
+		t.Storage.StoreSolidEntryPoint(solidEntryPoint)
+	}
+}
+
+// LocalSnapshot creates a LocalSnapshot of the Tangle at the given boundary. All confirmed Messages that were issued
+// before the boundary are covered by the snapshot and can be removed with PruneLocalSnapshot afterwards.
+func (t *Tangle) LocalSnapshot(boundary time.Time) (snapshot *LocalSnapshot) {
+	snapshot = NewLocalSnapshot()
+
+	prunableMessages := t.prunableMessages(boundary)
+	snapshot.messagesToPrune = make(MessageIDs, 0)
+	for messageID := range prunableMessages {
+		if !t.isSolidEntryPoint(messageID, prunableMessages) {
+			snapshot.messagesToPrune = append(snapshot.messagesToPrune, messageID)
+			continue
+		}
+
+		t.Storage.Message(messageID).Consume(func(message *Message) {
+			t.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+				solidEntryPointMetadata, _, err := MessageMetadataFromBytes(messageMetadata.Bytes())
+				if err != nil {
+					panic(err)
+				}
+				// only the confirmed ledger state is part of the snapshot
+				solidEntryPointMetadata.SetBranchID(ledgerstate.MasterBranchID)
+
+				snapshot.SolidEntryPoints = append(snapshot.SolidEntryPoints, &SolidEntryPoint{
+					Message:         message,
+					MessageMetadata: solidEntryPointMetadata,
+				})
+			})
+		})
+	}
+
+	snapshot.LedgerState = t.LedgerState.Snapshot(func(transactionID ledgerstate.TransactionID) bool {
+		if inclusionState, err := t.LedgerState.TransactionInclusionState(transactionID); err != nil || inclusionState != ledgerstate.Confirmed {
+			return false
+		}
+
+		for _, messageID := range t.Storage.AttachmentMessageIDs(transactionID) {
+			if _, prunable := prunableMessages[messageID]; !prunable && messageID != EmptyMessageID {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	t.Booker.MarkersManager.ForEachSequence(func(sequence *markers.Sequence) bool {
+		snapshot.Sequences = append(snapshot.Sequences, sequence)
+		return true
+	})
+	t.Booker.MarkersManager.ForEachSequenceAliasMapping(func(sequenceAliasMapping *markers.SequenceAliasMapping) bool {
+		snapshot.SequenceAliasMappings = append(snapshot.SequenceAliasMappings, sequenceAliasMapping)
+		return true
+	})
+	t.Storage.ForEachMarkerIndexBranchIDMapping(func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) bool {
+		snapshot.MarkerIndexBranchIDMappings = append(snapshot.MarkerIndexBranchIDMappings, markerIndexBranchIDMapping)
+		return true
+	})
+
+	return
+}
+
+// prunableMessages is an internal utility function that returns all confirmed Messages that were issued before the
+// given boundary.
+func (t *Tangle) prunableMessages(boundary time.Time) (prunableMessages map[MessageID]types.Empty) {
+	prunableMessages = make(map[MessageID]types.Empty)
+	t.Storage.ForEachMessage(func(message *Message) bool {
+		if message.ID() == EmptyMessageID || !message.IssuingTime().Before(boundary) {
+			return true
+		}
+
+		t.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			if messageMetadata.IsConfirmed() {
+				prunableMessages[message.ID()] = types.Void
+			}
+		})
+
+		return true
+	})
+
+	return
+}
+
+// isSolidEntryPoint is an internal utility function that checks if the given Message is approved by a Message that is
+// not pruned.
+func (t *Tangle) isSolidEntryPoint(messageID MessageID, prunableMessages map[MessageID]types.Empty) (isSolidEntryPoint bool) {
+	t.Storage.Approvers(messageID).Consume(func(approver *Approver) {
+		if _, prunable := prunableMessages[approver.ApproverMessageID()]; !prunable {
+			isSolidEntryPoint = true
+		}
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,121 @@
+package tangle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestTangle_LocalSnapshot(t *testing.T) {
+	tangle := New()
+	defer tangle.Shutdown()
+
+	tangle.Setup()
+	tangle.Events.Error.Attach(events.NewClosure(func(err error) {
+		panic(err)
+	}))
+
+	mtf := NewMessageTestFramework(tangle, WithGenesisOutput("Genesis1", 5), WithGenesisOutput("Genesis2", 8))
+
+	mtf.CreateMessage("Message1", WithInputs("Genesis1"), WithOutput("A", 5), WithStrongParents("Genesis"))
+	mtf.CreateMessage("Message2", WithStrongParents("Message1"))
+	mtf.CreateMessage("Message3", WithInputs("A"), WithOutput("B", 5), WithStrongParents("Message2"))
+
+	mtf.IssueMessages("Message1", "Message2", "Message3").WaitMessagesBooked()
+
+	// confirm the first two messages and the transaction of Message1
+	for _, messageAlias := range []string{"Message1", "Message2"} {
+		tangle.Storage.MessageMetadata(mtf.Message(messageAlias).ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetConfirmed(true)
+		})
+	}
+	transactionID := mtf.Message("Message1").Payload().(*ledgerstate.Transaction).ID()
+	tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+
+	snapshot := tangle.LocalSnapshot(time.Now().Add(time.Second))
+	prunedMessages := tangle.PruneLocalSnapshot(snapshot)
+
+	// Message1 is pruned, Message2 is still referenced by Message3 and becomes a solid entry point
+	assert.Equal(t, 1, prunedMessages)
+	assert.False(t, tangle.Storage.Message(mtf.Message("Message1").ID()).Consume(func(*Message) {}))
+	assert.Empty(t, tangle.Storage.AttachmentMessageIDs(transactionID))
+	assert.True(t, tangle.Storage.Message(mtf.Message("Message2").ID()).Consume(func(*Message) {}))
+	require.Len(t, snapshot.SolidEntryPoints, 1)
+	assert.Equal(t, mtf.Message("Message2").ID(), snapshot.SolidEntryPoints[0].Message.ID())
+
+	// the ledger state contains the unspent genesis output and the output created by Message1
+	snapshotOutputIDs := make(map[ledgerstate.OutputID]bool)
+	for _, output := range snapshot.LedgerState.Outputs {
+		snapshotOutputIDs[output.ID()] = true
+	}
+	assert.Equal(t, map[ledgerstate.OutputID]bool{
+		mtf.outputsByAlias["Genesis2"].ID(): true,
+		mtf.outputsByAlias["A"].ID():        true,
+	}, snapshotOutputIDs)
+
+	// a fresh node boots from the serialized snapshot and books the remaining message
+	var buffer bytes.Buffer
+	_, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	loadedSnapshot := NewLocalSnapshot()
+	_, err = loadedSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+
+	freshTangle := New()
+	defer freshTangle.Shutdown()
+
+	freshTangle.Setup()
+	freshTangle.LoadLocalSnapshot(loadedSnapshot)
+
+	booked := make(chan MessageID, 1)
+	freshTangle.Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID MessageID) {
+		booked <- messageID
+	}))
+
+	message3, _, err := MessageFromBytes(mtf.Message("Message3").Bytes())
+	require.NoError(t, err)
+	freshTangle.Storage.StoreMessage(message3)
+
+	select {
+	case messageID := <-booked:
+		assert.Equal(t, message3.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not booked")
+	}
+
+	freshTangle.Storage.MessageMetadata(message3.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.False(t, messageMetadata.IsInvalid())
+		assert.Equal(t, ledgerstate.MasterBranchID, messageMetadata.BranchID())
+	})
+}
+
+func TestReadLengthPrefixedBytes(t *testing.T) {
+	var buffer bytes.Buffer
+	_, err := writeLengthPrefixedBytes(&buffer, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	var readBytes []byte
+	read, err := readLengthPrefixedBytes(&buffer, &readBytes)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), read)
+	assert.Equal(t, []byte{1, 2, 3}, readBytes)
+
+	// corrupted length prefixes do not allocate the announced amount of memory
+	for _, length := range []int64{-1, maxLocalSnapshotElementSize + 1} {
+		buffer.Reset()
+		require.NoError(t, binary.Write(&buffer, binary.LittleEndian, length))
+		_, err = readLengthPrefixedBytes(&buffer, &readBytes)
+		assert.True(t, xerrors.Is(err, cerrors.ErrParseBytesFailed))
+	}
+}
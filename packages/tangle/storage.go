@@ -38,6 +38,15 @@ const (
 	// PrefixMarkerBranchIDMapping defines the storage prefix for the PrefixMarkerBranchIDMapping.
 	PrefixMarkerBranchIDMapping
 
+	// PrefixMarkerMessageMapping defines the storage prefix for the MarkerMessageMapping.
+	PrefixMarkerMessageMapping
+
+	// PrefixMarkerSupporters defines the storage prefix for the MarkerSupporters.
+	PrefixMarkerSupporters
+
+	// PrefixBranchSupporters defines the storage prefix for the BranchSupporters.
+	PrefixBranchSupporters
+
 	cacheTime = 2 * time.Second
 
 	// DBSequenceNumber defines the db sequence number.
@@ -55,6 +64,9 @@ type Storage struct {
 	missingMessageStorage             *objectstorage.ObjectStorage
 	attachmentStorage                 *objectstorage.ObjectStorage
 	markerIndexBranchIDMappingStorage *objectstorage.ObjectStorage
+	markerMessageMappingStorage       *objectstorage.ObjectStorage
+	markerSupportersStorage           *objectstorage.ObjectStorage
+	branchSupportersStorage           *objectstorage.ObjectStorage
 
 	Events   *StorageEvents
 	shutdown chan struct{}
@@ -73,6 +85,9 @@ func NewStorage(tangle *Tangle) (storage *Storage) {
 		missingMessageStorage:             osFactory.New(PrefixMissingMessage, MissingMessageFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 		attachmentStorage:                 osFactory.New(PrefixAttachments, AttachmentFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.PartitionKey(ledgerstate.TransactionIDLength, MessageIDLength), objectstorage.LeakDetectionEnabled(false)),
 		markerIndexBranchIDMappingStorage: osFactory.New(PrefixMarkerBranchIDMapping, MarkerIndexBranchIDMappingFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		markerMessageMappingStorage:       osFactory.New(PrefixMarkerMessageMapping, MarkerMessageMappingFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		markerSupportersStorage:           osFactory.New(PrefixMarkerSupporters, MarkerSupportersFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		branchSupportersStorage:           osFactory.New(PrefixBranchSupporters, BranchSupportersFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 
 		Events: &StorageEvents{
 			MessageStored:        events.NewEvent(MessageIDCaller),
@@ -235,6 +250,41 @@ func (s *Storage) DeleteMessage(messageID MessageID) {
 	})
 }
 
+// PruneMessage deletes the Message together with its metadata, its approvers and the attachment of its payload.
+func (s *Storage) PruneMessage(messageID MessageID) {
+	s.Message(messageID).Consume(func(message *Message) {
+		if message.Payload().Type() == ledgerstate.TransactionType {
+			s.attachmentStorage.Delete(NewAttachment(message.Payload().(*ledgerstate.Transaction).ID(), messageID).ObjectStorageKey())
+		}
+	})
+
+	s.DeleteMessage(messageID)
+}
+
+// ForEachMessage iterates through all stored Messages.
+func (s *Storage) ForEachMessage(consumer func(message *Message) bool) {
+	s.messageStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedMessage{CachedObject: cachedObject}).Consume(func(message *Message) {
+			continueIteration = consumer(message)
+		})
+
+		return continueIteration
+	})
+}
+
+// StoreSolidEntryPoint stores the Message and the MessageMetadata of a SolidEntryPoint (i.e. loaded from a local
+// snapshot) without triggering any events, as the Message has already been processed by the node that created the
+// snapshot.
+func (s *Storage) StoreSolidEntryPoint(solidEntryPoint *SolidEntryPoint) {
+	if cachedMessage, stored := s.messageStorage.StoreIfAbsent(solidEntryPoint.Message); stored {
+		cachedMessage.Release()
+	}
+	if cachedMessageMetadata, stored := s.messageMetadataStorage.StoreIfAbsent(solidEntryPoint.MessageMetadata); stored {
+		cachedMessageMetadata.Release()
+	}
+}
+
 // DeleteMissingMessage deletes a message from the missingMessageStorage.
 func (s *Storage) DeleteMissingMessage(messageID MessageID) {
 	s.missingMessageStorage.Delete(messageID[:])
@@ -253,6 +303,84 @@ func (s *Storage) MarkerIndexBranchIDMapping(sequenceID markers.SequenceID, comp
 	return &CachedMarkerIndexBranchIDMapping{CachedObject: s.markerIndexBranchIDMappingStorage.Load(sequenceID.Bytes())}
 }
 
+// StoreMarkerIndexBranchIDMapping stores a MarkerIndexBranchIDMapping (i.e. loaded from a local snapshot) in the
+// underlying object storage if it does not exist, yet.
+func (s *Storage) StoreMarkerIndexBranchIDMapping(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) {
+	if cachedMarkerIndexBranchIDMapping, stored := s.markerIndexBranchIDMappingStorage.StoreIfAbsent(markerIndexBranchIDMapping); stored {
+		cachedMarkerIndexBranchIDMapping.Release()
+	}
+}
+
+// ForEachMarkerIndexBranchIDMapping iterates through all stored MarkerIndexBranchIDMappings.
+func (s *Storage) ForEachMarkerIndexBranchIDMapping(consumer func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) bool) {
+	s.markerIndexBranchIDMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedMarkerIndexBranchIDMapping{CachedObject: cachedObject}).Consume(func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) {
+			continueIteration = consumer(markerIndexBranchIDMapping)
+		})
+
+		return continueIteration
+	})
+}
+
+// StoreMarkerMessageMapping stores a MarkerMessageMapping in the underlying object storage.
+func (s *Storage) StoreMarkerMessageMapping(markerMessageMapping *MarkerMessageMapping) {
+	s.markerMessageMappingStorage.Store(markerMessageMapping).Release()
+}
+
+// MarkerMessageMapping retrieves the MarkerMessageMapping of the given Marker.
+func (s *Storage) MarkerMessageMapping(marker *markers.Marker) *CachedMarkerMessageMapping {
+	return &CachedMarkerMessageMapping{CachedObject: s.markerMessageMappingStorage.Load(marker.Bytes())}
+}
+
+// MarkerSupporters retrieves the MarkerSupporters of the given Marker. It accepts an optional computeIfAbsent callback
+// that can be used to dynamically create the MarkerSupporters if they don't exist, yet.
+func (s *Storage) MarkerSupporters(marker *markers.Marker, computeIfAbsentCallback ...func(marker *markers.Marker) *MarkerSupporters) *CachedMarkerSupporters {
+	if len(computeIfAbsentCallback) >= 1 {
+		return &CachedMarkerSupporters{s.markerSupportersStorage.ComputeIfAbsent(marker.Bytes(), func(key []byte) objectstorage.StorableObject {
+			return computeIfAbsentCallback[0](marker)
+		})}
+	}
+
+	return &CachedMarkerSupporters{CachedObject: s.markerSupportersStorage.Load(marker.Bytes())}
+}
+
+// BranchSupporters retrieves the BranchSupporters of the given Branch. It accepts an optional computeIfAbsent callback
+// that can be used to dynamically create the BranchSupporters if they don't exist, yet.
+func (s *Storage) BranchSupporters(branchID ledgerstate.BranchID, computeIfAbsentCallback ...func(branchID ledgerstate.BranchID) *BranchSupporters) *CachedBranchSupporters {
+	if len(computeIfAbsentCallback) >= 1 {
+		return &CachedBranchSupporters{s.branchSupportersStorage.ComputeIfAbsent(branchID.Bytes(), func(key []byte) objectstorage.StorableObject {
+			return computeIfAbsentCallback[0](branchID)
+		})}
+	}
+
+	return &CachedBranchSupporters{CachedObject: s.branchSupportersStorage.Load(branchID.Bytes())}
+}
+
+// ForEachMarkerSupporters iterates through all stored MarkerSupporters.
+func (s *Storage) ForEachMarkerSupporters(consumer func(markerSupporters *MarkerSupporters) bool) {
+	s.markerSupportersStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedMarkerSupporters{CachedObject: cachedObject}).Consume(func(markerSupporters *MarkerSupporters) {
+			continueIteration = consumer(markerSupporters)
+		})
+
+		return continueIteration
+	})
+}
+
+// ForEachBranchSupporters iterates through all stored BranchSupporters.
+func (s *Storage) ForEachBranchSupporters(consumer func(branchSupporters *BranchSupporters) bool) {
+	s.branchSupportersStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedBranchSupporters{CachedObject: cachedObject}).Consume(func(branchSupporters *BranchSupporters) {
+			continueIteration = consumer(branchSupporters)
+		})
+
+		return continueIteration
+	})
+}
+
 func (s *Storage) storeGenesis() {
 	s.MessageMetadata(EmptyMessageID, func() *MessageMetadata {
 		genesisMetadata := &MessageMetadata{
@@ -269,6 +397,7 @@ func (s *Storage) storeGenesis() {
 			scheduled: true,
 			booked:    true,
 			eligible:  true,
+			confirmed: true,
 		}
 
 		genesisMetadata.Persist()
@@ -296,6 +425,9 @@ func (s *Storage) Shutdown() {
 	s.missingMessageStorage.Shutdown()
 	s.attachmentStorage.Shutdown()
 	s.markerIndexBranchIDMappingStorage.Shutdown()
+	s.markerMessageMappingStorage.Shutdown()
+	s.markerSupportersStorage.Shutdown()
+	s.branchSupportersStorage.Shutdown()
 
 	close(s.shutdown)
 }
@@ -309,6 +441,9 @@ func (s *Storage) Prune() error {
 		s.missingMessageStorage,
 		s.attachmentStorage,
 		s.markerIndexBranchIDMappingStorage,
+		s.markerMessageMappingStorage,
+		s.markerSupportersStorage,
+		s.branchSupportersStorage,
 	} {
 		if err := storage.Prune(); err != nil {
 			err = fmt.Errorf("failed to prune storage: %w", err)
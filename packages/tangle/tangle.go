@@ -3,6 +3,7 @@ package tangle
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -22,19 +23,20 @@ import (
 
 // Tangle is the central data structure of the IOTA protocol.
 type Tangle struct {
-	Options          *Options
-	Parser           *Parser
-	Storage          *Storage
-	Solidifier       *Solidifier
-	Scheduler        *Scheduler
-	Booker           *Booker
-	ConsensusManager *ConsensusManager
-	TipManager       *TipManager
-	Requester        *Requester
-	MessageFactory   *MessageFactory
-	LedgerState      *LedgerState
-	Utils            *Utils
-	Events           *Events
+	Options               *Options
+	Parser                *Parser
+	Storage               *Storage
+	Solidifier            *Solidifier
+	Scheduler             *Scheduler
+	Booker                *Booker
+	ConsensusManager      *ConsensusManager
+	ApprovalWeightManager *ApprovalWeightManager
+	TipManager            *TipManager
+	Requester             *Requester
+	MessageFactory        *MessageFactory
+	LedgerState           *LedgerState
+	Utils                 *Utils
+	Events                *Events
 
 	setupParserOnce sync.Once
 	syncedMutex     sync.RWMutex
@@ -60,6 +62,7 @@ func New(options ...Option) (tangle *Tangle) {
 	tangle.LedgerState = NewLedgerState(tangle)
 	tangle.Booker = NewBooker(tangle)
 	tangle.ConsensusManager = NewConsensusManager(tangle)
+	tangle.ApprovalWeightManager = NewApprovalWeightManager(tangle)
 	tangle.Requester = NewRequester(tangle)
 	tangle.TipManager = NewTipManager(tangle)
 	tangle.MessageFactory = NewMessageFactory(tangle, tangle.TipManager)
@@ -95,7 +98,9 @@ func (t *Tangle) Setup() {
 	t.Scheduler.Setup()
 	t.Booker.Setup()
 	t.ConsensusManager.Setup()
+	t.ApprovalWeightManager.Setup()
 	t.TipManager.Setup()
+	t.LedgerState.Setup()
 
 	t.MessageFactory.Events.Error.Attach(events.NewClosure(func(err error) {
 		t.Events.Error.Trigger(xerrors.Errorf("error in MessageFactory: %w", err))
@@ -216,6 +221,12 @@ type Options struct {
 	TangleWidth                  int
 	ConsensusMechanism           ConsensusMechanism
 	GenesisNode                  *ed25519.PublicKey
+	SchedulerParams              SchedulerParams
+	ApprovalWeightParams         ApprovalWeightParams
+	TipSelectionStrategy         TipSelectionStrategy
+	AddressHistoryEnabled        bool
+	DustParams                   ledgerstate.DustParams
+	BranchPruningDelay           time.Duration
 }
 
 // Store is an Option for the Tangle that allows to specify which storage layer is supposed to be used to persist data.
@@ -254,6 +265,51 @@ func Width(width int) Option {
 	}
 }
 
+// SchedulerConfig is an Option for the Tangle that allows to set the scheduler parameters.
+func SchedulerConfig(config SchedulerParams) Option {
+	return func(options *Options) {
+		options.SchedulerParams = config
+	}
+}
+
+// ApprovalWeightConfig is an Option for the Tangle that allows to set the parameters of the ApprovalWeightManager.
+func ApprovalWeightConfig(config ApprovalWeightParams) Option {
+	return func(options *Options) {
+		options.ApprovalWeightParams = config
+	}
+}
+
+// TipSelection is an Option for the Tangle that allows to define the TipSelectionStrategy that is used by the
+// TipManager to select the strong parents of new Messages.
+func TipSelection(tipSelectionStrategy TipSelectionStrategy) Option {
+	return func(options *Options) {
+		options.TipSelectionStrategy = tipSelectionStrategy
+	}
+}
+
+// AddressHistory is an Option for the Tangle that allows to enable the index of the Transactions that credit or debit
+// an Address.
+func AddressHistory(enabled bool) Option {
+	return func(options *Options) {
+		options.AddressHistoryEnabled = enabled
+	}
+}
+
+// DustProtectionConfig is an Option for the Tangle that allows to set the dust protection rules of the ledger state.
+func DustProtectionConfig(config ledgerstate.DustParams) Option {
+	return func(options *Options) {
+		options.DustParams = config
+	}
+}
+
+// BranchPruning is an Option for the Tangle that allows to set the time after which Rejected Branches and the
+// Transactions booked into them are removed from the ledger state (0 disables the pruning).
+func BranchPruning(delay time.Duration) Option {
+	return func(options *Options) {
+		options.BranchPruningDelay = delay
+	}
+}
+
 // GenesisNode is an Option for the Tangle that allows to set the GenesisNode, i.e., the node that is allowed to attach
 // to the Genesis Message.
 func GenesisNode(genesisNodeBase58 string) Option {
@@ -113,8 +113,10 @@ func (m *MessageTestFramework) createGenesisOutputs() {
 		genesisOutputs[addressWallet.address] = ledgerstate.NewColoredBalances(coloredBalances)
 	}
 
-	m.tangle.LedgerState.LoadSnapshot(map[ledgerstate.TransactionID]map[ledgerstate.Address]*ledgerstate.ColoredBalances{
-		ledgerstate.GenesisTransactionID: genesisOutputs,
+	m.tangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{
+		Transactions: map[ledgerstate.TransactionID]map[ledgerstate.Address]*ledgerstate.ColoredBalances{
+			ledgerstate.GenesisTransactionID: genesisOutputs,
+		},
 	})
 
 	for alias := range m.options.genesisOutputs {
@@ -130,20 +130,28 @@ const tipLifeGracePeriod = maxParentsTimeDifference - 1*time.Minute
 
 // TipManager manages a map of tips and emits events for their removal and addition.
 type TipManager struct {
-	tangle      *Tangle
-	strongTips  *randommap.RandomMap
-	weakTips    *randommap.RandomMap
-	tipsCleaner *TimedTaskExecutor
-	Events      *TipManagerEvents
+	tangle               *Tangle
+	strongTips           *randommap.RandomMap
+	weakTips             *randommap.RandomMap
+	tipsCleaner          *TimedTaskExecutor
+	tipSelectionStrategy TipSelectionStrategy
+	Events               *TipManagerEvents
 }
 
 // NewTipManager creates a new tip-selector.
 func NewTipManager(tangle *Tangle, tips ...MessageID) *TipManager {
+	tipSelectionStrategy := tangle.Options.TipSelectionStrategy
+	if tipSelectionStrategy == nil {
+		tipSelectionStrategy = NewUniformRandomTipSelection()
+	}
+	tipSelectionStrategy.Init(tangle)
+
 	tipSelector := &TipManager{
-		tangle:      tangle,
-		strongTips:  randommap.New(),
-		weakTips:    randommap.New(),
-		tipsCleaner: NewTimedTaskExecutor(1),
+		tangle:               tangle,
+		strongTips:           randommap.New(),
+		weakTips:             randommap.New(),
+		tipsCleaner:          NewTimedTaskExecutor(1),
+		tipSelectionStrategy: tipSelectionStrategy,
 		Events: &TipManagerEvents{
 			TipAdded:   events.NewEvent(tipEventHandler),
 			TipRemoved: events.NewEvent(tipEventHandler),
@@ -294,7 +302,7 @@ func (t *TipManager) Tips(p payload.Payload, countStrongParents, countWeakParent
 }
 
 // selectStrongTips returns a list of strong parents. In case of a transaction, it references young enough attachments
-// of consumed transactions directly. Otherwise/additionally count tips are randomly selected.
+// of consumed transactions directly. Otherwise/additionally count tips are selected by the TipSelectionStrategy.
 func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents MessageIDs) {
 	parents = make([]MessageID, 0, MaxParentsCount)
 	parentsMap := make(map[MessageID]types.Empty)
@@ -344,7 +352,10 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		count = MaxParentsCount - len(parents)
 	}
 
-	tips := t.strongTips.RandomUniqueEntries(count)
+	var tips MessageIDs
+	if count > 0 {
+		tips = t.tipSelectionStrategy.SelectTips(t, count)
+	}
 	// count is invalid or there are no tips
 	if len(tips) == 0 {
 		// only add genesis if no tip was found and not previously referenced (in case of a transaction)
@@ -354,9 +365,7 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		return
 	}
 	// at least one tip is returned
-	for _, tip := range tips {
-		messageID := tip.(MessageID)
-
+	for _, messageID := range tips {
 		if _, ok := parentsMap[messageID]; !ok {
 			parentsMap[messageID] = types.Void
 			parents = append(parents, messageID)
@@ -360,7 +360,7 @@ func TestTipManager_TransactionTips(t *testing.T) {
 		},
 	}
 
-	tangle.LedgerState.LoadSnapshot(snapshot)
+	tangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{Transactions: snapshot})
 	// determine genesis index so that correct output can be referenced
 	var g1, g2 uint16
 	tangle.LedgerState.utxoDAG.Output(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0)).Consume(func(output ledgerstate.Output) {
@@ -0,0 +1,199 @@
+package tangle
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region TipSelectionStrategy /////////////////////////////////////////////////////////////////////////////////////////
+
+// TipSelectionStrategy is the interface for the algorithms that are used by the TipManager to select the strong tips
+// that are referenced by new Messages.
+type TipSelectionStrategy interface {
+	// Init initializes the TipSelectionStrategy by making it aware of the Tangle it is used in.
+	Init(tangle *Tangle)
+
+	// SelectTips returns up to count distinct strong tips of the given TipManager.
+	SelectTips(tipManager *TipManager, count int) (tips MessageIDs)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UniformRandomTipSelection ////////////////////////////////////////////////////////////////////////////////////
+
+// UniformRandomTipSelection is a TipSelectionStrategy that selects the tips uniformly at random.
+type UniformRandomTipSelection struct{}
+
+// NewUniformRandomTipSelection is the constructor of the UniformRandomTipSelection.
+func NewUniformRandomTipSelection() *UniformRandomTipSelection {
+	return &UniformRandomTipSelection{}
+}
+
+// Init initializes the TipSelectionStrategy by making it aware of the Tangle it is used in.
+func (u *UniformRandomTipSelection) Init(*Tangle) {}
+
+// SelectTips returns up to count distinct strong tips of the given TipManager.
+func (u *UniformRandomTipSelection) SelectTips(tipManager *TipManager, count int) (tips MessageIDs) {
+	randomTips := tipManager.strongTips.RandomUniqueEntries(count)
+	tips = make(MessageIDs, 0, len(randomTips))
+	for _, tip := range randomTips {
+		tips = append(tips, tip.(MessageID))
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &UniformRandomTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region YoungTipSelection ////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultMaxTipAge is the default maximum age of the tips that are selected by the YoungTipSelection.
+const DefaultMaxTipAge = tipLifeGracePeriod / 2
+
+// YoungTipSelection is a TipSelectionStrategy that only selects tips that are younger than a maximum age and that
+// prefers younger tips over older ones (the probability of a tip to be selected decreases linearly with its age). If
+// there are no young tips at all, it selects the tips uniformly at random, so that the Tangle can continue to grow.
+type YoungTipSelection struct {
+	tangle    *Tangle
+	maxTipAge time.Duration
+}
+
+// NewYoungTipSelection is the constructor of the YoungTipSelection. The maximum tip age is required to be below the
+// tip life grace period of the TipManager, otherwise the DefaultMaxTipAge is used.
+func NewYoungTipSelection(maxTipAge time.Duration) *YoungTipSelection {
+	if maxTipAge <= 0 || maxTipAge >= tipLifeGracePeriod {
+		maxTipAge = DefaultMaxTipAge
+	}
+
+	return &YoungTipSelection{
+		maxTipAge: maxTipAge,
+	}
+}
+
+// Init initializes the TipSelectionStrategy by making it aware of the Tangle it is used in.
+func (y *YoungTipSelection) Init(tangle *Tangle) {
+	y.tangle = tangle
+}
+
+// SelectTips returns up to count distinct strong tips of the given TipManager.
+func (y *YoungTipSelection) SelectTips(tipManager *TipManager, count int) (tips MessageIDs) {
+	candidates := make(MessageIDs, 0)
+	weights := make([]float64, 0)
+	for _, tip := range tipManager.AllStrongTips() {
+		y.tangle.Storage.Message(tip).Consume(func(message *Message) {
+			if age := clock.Since(message.IssuingTime()); age < y.maxTipAge {
+				candidates = append(candidates, tip)
+				weights = append(weights, 1-float64(age)/float64(y.maxTipAge))
+			}
+		})
+	}
+
+	if len(candidates) == 0 {
+		return NewUniformRandomTipSelection().SelectTips(tipManager, count)
+	}
+
+	return weightedRandomTips(candidates, weights, count)
+}
+
+// MaxTipAge returns the maximum age of the tips that are selected.
+func (y *YoungTipSelection) MaxTipAge() time.Duration {
+	return y.maxTipAge
+}
+
+// weightedRandomTips is an internal utility function that selects up to count distinct tips from the candidates where
+// the probability of a candidate to be selected is proportional to its weight.
+func weightedRandomTips(candidates MessageIDs, weights []float64, count int) (tips MessageIDs) {
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	tips = make(MessageIDs, 0, count)
+	for len(tips) < count && len(candidates) > 0 && totalWeight > 0 {
+		selectedIndex := len(candidates) - 1
+		for index, threshold := 0, rand.Float64()*totalWeight; index < len(candidates); index++ {
+			if threshold -= weights[index]; threshold < 0 {
+				selectedIndex = index
+				break
+			}
+		}
+
+		tips = append(tips, candidates[selectedIndex])
+		totalWeight -= weights[selectedIndex]
+		candidates = append(candidates[:selectedIndex], candidates[selectedIndex+1:]...)
+		weights = append(weights[:selectedIndex], weights[selectedIndex+1:]...)
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &YoungTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HeaviestBranchTipSelection ///////////////////////////////////////////////////////////////////////////////////
+
+// HeaviestBranchTipSelection is a TipSelectionStrategy that selects the tips from the Branch with the highest approval
+// weight. If this Branch does not contain enough tips, the remaining tips are taken from the next heaviest Branches.
+type HeaviestBranchTipSelection struct {
+	tangle *Tangle
+}
+
+// NewHeaviestBranchTipSelection is the constructor of the HeaviestBranchTipSelection.
+func NewHeaviestBranchTipSelection() *HeaviestBranchTipSelection {
+	return &HeaviestBranchTipSelection{}
+}
+
+// Init initializes the TipSelectionStrategy by making it aware of the Tangle it is used in.
+func (h *HeaviestBranchTipSelection) Init(tangle *Tangle) {
+	h.tangle = tangle
+}
+
+// SelectTips returns up to count distinct strong tips of the given TipManager.
+func (h *HeaviestBranchTipSelection) SelectTips(tipManager *TipManager, count int) (tips MessageIDs) {
+	tipsByBranch := make(map[ledgerstate.BranchID]MessageIDs)
+	for _, tip := range tipManager.AllStrongTips() {
+		h.tangle.Storage.MessageMetadata(tip).Consume(func(messageMetadata *MessageMetadata) {
+			tipsByBranch[messageMetadata.BranchID()] = append(tipsByBranch[messageMetadata.BranchID()], tip)
+		})
+	}
+
+	branchIDs := make([]ledgerstate.BranchID, 0, len(tipsByBranch))
+	for branchID := range tipsByBranch {
+		branchIDs = append(branchIDs, branchID)
+	}
+	branchWeights := h.tangle.ApprovalWeightManager.BranchWeights(branchIDs...)
+	sort.Slice(branchIDs, func(i, j int) bool {
+		return branchWeights[branchIDs[i]] > branchWeights[branchIDs[j]]
+	})
+
+	tips = make(MessageIDs, 0, count)
+	for _, branchID := range branchIDs {
+		branchTips := tipsByBranch[branchID]
+		rand.Shuffle(len(branchTips), func(i, j int) {
+			branchTips[i], branchTips[j] = branchTips[j], branchTips[i]
+		})
+
+		for _, tip := range branchTips {
+			if len(tips) == count {
+				return
+			}
+			tips = append(tips, tip)
+		}
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &HeaviestBranchTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,93 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestYoungTipSelection(t *testing.T) {
+	tangle := New(TipSelection(NewYoungTipSelection(time.Minute)))
+	defer tangle.Shutdown()
+
+	youngMessage := newTestParentsDataWithTimestamp("young", []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-10*time.Second))
+	oldMessage := newTestParentsDataWithTimestamp("old", []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-2*time.Minute))
+	tangle.Storage.StoreMessage(youngMessage)
+	tangle.Storage.StoreMessage(oldMessage)
+	tangle.TipManager.Set(youngMessage.ID(), oldMessage.ID())
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, MessageIDs{youngMessage.ID()}, tangle.TipManager.tipSelectionStrategy.SelectTips(tangle.TipManager, 2))
+	}
+
+	// without any young tips, the tips are selected uniformly at random
+	tangle.TipManager.strongTips.Delete(youngMessage.ID())
+	assert.Equal(t, MessageIDs{oldMessage.ID()}, tangle.TipManager.tipSelectionStrategy.SelectTips(tangle.TipManager, 2))
+
+	// the maximum tip age is always below the tip life grace period
+	assert.Equal(t, DefaultMaxTipAge, NewYoungTipSelection(tipLifeGracePeriod).MaxTipAge())
+}
+
+func TestWeightedRandomTips(t *testing.T) {
+	tipA := randomMessageID()
+	tipB := randomMessageID()
+
+	selected := make(map[MessageID]int)
+	for i := 0; i < 10000; i++ {
+		tips := weightedRandomTips(MessageIDs{tipA, tipB}, []float64{3, 1}, 1)
+		require.Len(t, tips, 1)
+		selected[tips[0]]++
+	}
+	assert.InDelta(t, 7500, selected[tipA], 300)
+
+	assert.ElementsMatch(t, MessageIDs{tipA, tipB}, weightedRandomTips(MessageIDs{tipA, tipB}, []float64{3, 1}, 3))
+}
+
+func TestHeaviestBranchTipSelection(t *testing.T) {
+	supporter := identity.GenerateLocalIdentity()
+	tangle := New(TipSelection(NewHeaviestBranchTipSelection()), ApprovalWeightConfig(ApprovalWeightParams{
+		ConsensusManaRetrieveFunc: func() map[identity.ID]float64 {
+			return map[identity.ID]float64{
+				supporter.ID():                        60,
+				identity.GenerateLocalIdentity().ID(): 40,
+			}
+		},
+	}))
+	defer tangle.Shutdown()
+
+	// create two conflicting branches of which only the first one is supported
+	conflictID := ledgerstate.NewConflictID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
+	heavyBranchID := ledgerstate.NewBranchID(ledgerstate.TransactionID{10})
+	lightBranchID := ledgerstate.NewBranchID(ledgerstate.TransactionID{11})
+	for _, branchID := range []ledgerstate.BranchID{heavyBranchID, lightBranchID} {
+		cachedBranch, _, err := tangle.LedgerState.BranchDAG.CreateConflictBranch(branchID, ledgerstate.NewBranchIDs(ledgerstate.MasterBranchID), ledgerstate.NewConflictIDs(conflictID))
+		require.NoError(t, err)
+		cachedBranch.Release()
+	}
+	tangle.Storage.BranchSupporters(heavyBranchID, NewBranchSupporters).Consume(func(branchSupporters *BranchSupporters) {
+		branchSupporters.AddSupporter(supporter.ID())
+	})
+	assert.Equal(t, 0.6, tangle.ApprovalWeightManager.BranchWeight(heavyBranchID))
+	assert.Equal(t, 0.0, tangle.ApprovalWeightManager.BranchWeight(lightBranchID))
+	assert.Equal(t, 1.0, tangle.ApprovalWeightManager.BranchWeight(ledgerstate.MasterBranchID))
+
+	tipsByBranch := make(map[ledgerstate.BranchID]MessageIDs)
+	for i, branchID := range []ledgerstate.BranchID{heavyBranchID, heavyBranchID, lightBranchID, ledgerstate.MasterBranchID} {
+		message := newTestParentsDataMessage(string(rune('A'+i)), []MessageID{EmptyMessageID}, []MessageID{})
+		tangle.Storage.StoreMessage(message)
+		tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetBranchID(branchID)
+		})
+		tangle.TipManager.Set(message.ID())
+		tipsByBranch[branchID] = append(tipsByBranch[branchID], message.ID())
+	}
+
+	// the tips of the MasterBranch are preferred, as it does not conflict with anything
+	assert.ElementsMatch(t, append(tipsByBranch[ledgerstate.MasterBranchID], tipsByBranch[heavyBranchID]...), tangle.TipManager.tipSelectionStrategy.SelectTips(tangle.TipManager, 3))
+	assert.Len(t, tangle.TipManager.tipSelectionStrategy.SelectTips(tangle.TipManager, 8), 4)
+}
@@ -1,7 +1,10 @@
 package tangle
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/iotaledger/hive.go/datastructure/walker"
 	"github.com/iotaledger/hive.go/types"
@@ -88,6 +91,100 @@ func (u *Utils) WalkMessageAndMetadata(callback func(message *Message, messageMe
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region past cone ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PastCone returns the ids of the Messages in the past cone of the given target (including the target itself) that are
+// not in the past cone of the given known Messages in topological order (parents before their children). The walk stops
+// at the known Messages, at Messages that were issued before the earliest known Message and after maxCount Messages (the
+// Messages closest to the target are kept).
+func (u *Utils) PastCone(target MessageID, knownMessageIDs MessageIDs, maxCount int) (pastCone MessageIDs) {
+	knownMessages := make(map[MessageID]types.Empty, len(knownMessageIDs))
+	var lowerBound time.Time
+	for _, knownMessageID := range knownMessageIDs {
+		knownMessages[knownMessageID] = types.Void
+		u.tangle.Storage.Message(knownMessageID).Consume(func(message *Message) {
+			if lowerBound.IsZero() || message.IssuingTime().Before(lowerBound) {
+				lowerBound = message.IssuingTime()
+			}
+		})
+	}
+	if _, known := knownMessages[target]; known {
+		return nil
+	}
+
+	// collect the past cone and the number of parents of every Message that are part of the past cone
+	parentsInPastCone := make(map[MessageID]int)
+	children := make(map[MessageID]MessageIDs)
+	u.WalkMessage(func(message *Message, walker *walker.Walker) {
+		if message.IssuingTime().Before(lowerBound) {
+			return
+		}
+
+		parentsInPastCone[message.ID()] = 0
+		if len(parentsInPastCone) >= maxCount {
+			walker.StopWalk()
+			return
+		}
+
+		message.ForEachParent(func(parent Parent) {
+			if _, known := knownMessages[parent.ID]; known || parent.ID == EmptyMessageID {
+				return
+			}
+
+			walker.Push(parent.ID)
+		})
+	}, MessageIDs{target})
+
+	for messageID := range parentsInPastCone {
+		u.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+			message.ForEachParent(func(parent Parent) {
+				if _, exists := parentsInPastCone[parent.ID]; !exists {
+					return
+				}
+
+				// a parent can be referenced as a strong and as a weak parent at the same time
+				for _, child := range children[parent.ID] {
+					if child == messageID {
+						return
+					}
+				}
+
+				parentsInPastCone[messageID]++
+				children[parent.ID] = append(children[parent.ID], messageID)
+			})
+		})
+	}
+
+	// sort the past cone topologically by starting at the Messages without parents in the past cone (the ties are ordered
+	// by their ids, so that the same past cone always results in the same order and can be requested in pages)
+	pastCone = make(MessageIDs, 0, len(parentsInPastCone))
+	for messageID, parentCount := range parentsInPastCone {
+		if parentCount == 0 {
+			pastCone = append(pastCone, messageID)
+		}
+	}
+	sortMessageIDs(pastCone)
+	for i := 0; i < len(pastCone); i++ {
+		sortMessageIDs(children[pastCone[i]])
+		for _, child := range children[pastCone[i]] {
+			if parentsInPastCone[child]--; parentsInPastCone[child] == 0 {
+				pastCone = append(pastCone, child)
+			}
+		}
+	}
+
+	return pastCone
+}
+
+// sortMessageIDs sorts the given MessageIDs by their bytes.
+func sortMessageIDs(messageIDs MessageIDs) {
+	sort.Slice(messageIDs, func(i, j int) bool {
+		return bytes.Compare(messageIDs[i][:], messageIDs[j][:]) < 0
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region structural checks ////////////////////////////////////////////////////////////////////////////////////////////
 
 // AllTransactionsApprovedByMessages checks if all Transactions were attached by at least one Message that was directly
@@ -45,3 +45,52 @@ func TestUtils_AllTransactionsApprovedByMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestUtils_PastCone(t *testing.T) {
+	tangle := New()
+	defer tangle.Shutdown()
+
+	tangle.Setup()
+	tangle.Events.Error.Attach(events.NewClosure(func(err error) {
+		panic(err)
+	}))
+
+	mtf := NewMessageTestFramework(tangle)
+
+	mtf.CreateMessage("Message1", WithStrongParents("Genesis"))
+	mtf.CreateMessage("Message2", WithStrongParents("Message1"))
+	mtf.CreateMessage("Message3", WithStrongParents("Message1"))
+	mtf.CreateMessage("Message4", WithStrongParents("Message2", "Message3"))
+	mtf.CreateMessage("Message5", WithStrongParents("Message4"))
+	mtf.CreateMessage("Message6", WithStrongParents("Genesis"))
+
+	mtf.IssueMessages("Message1", "Message2", "Message3", "Message4", "Message5", "Message6").WaitMessagesBooked()
+
+	assertPastCone := func(pastCone MessageIDs, expectedAliases ...string) {
+		expectedMessageIDs := make(MessageIDs, len(expectedAliases))
+		for i, alias := range expectedAliases {
+			expectedMessageIDs[i] = mtf.Message(alias).ID()
+		}
+		assert.ElementsMatch(t, expectedMessageIDs, pastCone)
+
+		// every parent needs to be in front of its children
+		positions := make(map[MessageID]int)
+		for i, messageID := range pastCone {
+			positions[messageID] = i
+		}
+		for _, messageID := range pastCone {
+			tangle.Storage.Message(messageID).Consume(func(message *Message) {
+				message.ForEachParent(func(parent Parent) {
+					if position, exists := positions[parent.ID]; exists {
+						assert.Less(t, position, positions[messageID])
+					}
+				})
+			})
+		}
+	}
+
+	assertPastCone(tangle.Utils.PastCone(mtf.Message("Message5").ID(), nil, 100), "Message1", "Message2", "Message3", "Message4", "Message5")
+	assertPastCone(tangle.Utils.PastCone(mtf.Message("Message5").ID(), MessageIDs{mtf.Message("Message2").ID()}, 100), "Message3", "Message4", "Message5")
+	assertPastCone(tangle.Utils.PastCone(mtf.Message("Message5").ID(), nil, 2), "Message4", "Message5")
+	assertPastCone(tangle.Utils.PastCone(mtf.Message("Message5").ID(), MessageIDs{mtf.Message("Message5").ID()}, 100))
+}
@@ -102,7 +102,7 @@ func (f *FPC) Events() vote.Events {
 
 // Round enqueues new items, sets opinions on active vote contexts, finalizes them and then
 // queries for opinions.
-func (f *FPC) Round(rand float64) error {
+func (f *FPC) Round(rand float64, source vote.RandomnessSource) error {
 	start := time.Now()
 	// enqueue new voting contexts
 	f.enqueue()
@@ -122,6 +122,7 @@ func (f *FPC) Round(rand float64) error {
 		roundStats := &vote.RoundStats{
 			Duration:           time.Since(start),
 			RandUsed:           rand,
+			RandSource:         source,
 			ActiveVoteContexts: f.ctxs,
 			QueriedOpinions:    queriedOpinions,
 		}
@@ -209,12 +210,13 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 		return nil, ErrNoOpinionGiversAvailable
 	}
 
-	// select a random subset of opinion givers to query.
+	// select a random subset of opinion givers to query (weighted by their mana).
 	// if the same opinion giver is selected multiple times, we query it only once
 	// but use its opinion N selected times.
+	sampler := newOpinionGiverSampler(opinionGivers, f.paras.MaxOpinionGiverWeight)
 	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
 	for i := 0; i < f.paras.QuerySampleSize; i++ {
-		selected := opinionGivers[f.opinionGiverRng.Intn(len(opinionGivers))]
+		selected := sampler.sample(f.opinionGiverRng)
 		opinionGiversToQuery[selected]++
 	}
 
@@ -284,7 +286,8 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 
 	f.ctxsMu.RLock()
 	defer f.ctxsMu.RUnlock()
-	// compute liked percentage
+	// compute liked percentage (every opinion is counted as often as its opinion giver was selected which makes the
+	// liked percentage an estimate of the share of mana that likes the given item)
 	for id, votes := range voteMap {
 		var likedSum float64
 		votedCount := float64(len(votes))
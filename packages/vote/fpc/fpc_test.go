@@ -65,12 +65,17 @@ func TestFPCPreventSameIDMultipleTimes(t *testing.T) {
 type opiniongivermock struct {
 	roundsReplies []opinion.Opinions
 	roundIndex    int
+	mana          float64
 }
 
 func (ogm *opiniongivermock) ID() identity.ID {
 	return identity.GenerateIdentity().ID()
 }
 
+func (ogm *opiniongivermock) Mana() float64 {
+	return ogm.mana
+}
+
 func (ogm *opiniongivermock) Query(_ context.Context, _ []string, _ []string) (opinion.Opinions, error) {
 	if ogm.roundIndex >= len(ogm.roundsReplies) {
 		return ogm.roundsReplies[len(ogm.roundsReplies)-1], nil
@@ -106,7 +111,7 @@ func TestFPCFinalizedEvent(t *testing.T) {
 
 	// do 5 rounds of FPC -> 5 because the last one finalizes the vote
 	for i := 0; i < 5; i++ {
-		assert.NoError(t, voter.Round(0.5))
+		assert.NoError(t, voter.Round(0.5, vote.UnixTimestampRandomness))
 	}
 
 	require.NotNil(t, finalizedOpinion, "finalized event should have been fired")
@@ -138,7 +143,7 @@ func TestFPCFailedEvent(t *testing.T) {
 	assert.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Like))
 
 	for i := 0; i < 4; i++ {
-		assert.NoError(t, voter.Round(0.5))
+		assert.NoError(t, voter.Round(0.5, vote.UnixTimestampRandomness))
 	}
 
 	require.NotNil(t, failedOpinion, "failed event should have been fired")
@@ -181,7 +186,7 @@ func TestFPCVotingMultipleOpinionGivers(t *testing.T) {
 
 		var roundsDone int
 		for finalOpinion == nil {
-			assert.NoError(t, voter.Round(0.7))
+			assert.NoError(t, voter.Round(0.7, vote.UnixTimestampRandomness))
 			roundsDone++
 		}
 
@@ -190,3 +195,62 @@ func TestFPCVotingMultipleOpinionGivers(t *testing.T) {
 		assert.Equal(t, test.expectedOpinion, *finalOpinion)
 	}
 }
+
+func TestFPCRoundStatsRandomnessSource(t *testing.T) {
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{&opiniongivermock{roundsReplies: []opinion.Opinions{{opinion.Like}}}}, nil
+	}
+	voter := fpc.New(opinionGiverFunc)
+
+	var roundStats *vote.RoundStats
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(executedRoundStats *vote.RoundStats) {
+		roundStats = executedRoundStats
+	}))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.3, vote.DRNGRandomness))
+	require.NotNil(t, roundStats)
+	assert.Equal(t, 0.3, roundStats.RandUsed)
+	assert.Equal(t, vote.DRNGRandomness, roundStats.RandSource)
+
+	assert.NoError(t, voter.Round(0.6, vote.UnixTimestampRandomness))
+	assert.Equal(t, vote.UnixTimestampRandomness, roundStats.RandSource)
+}
+
+func TestFPCManaWeightedSampling(t *testing.T) {
+	type testInput struct {
+		maxOpinionGiverWeight float64
+		expectedLiked         float64
+		delta                 float64
+	}
+	tests := []testInput{
+		// the opinion givers are sampled proportional to their mana (1000 / (1000 + 500))
+		{0, 2.0 / 3, 0.1},
+		// the weight of the heavy node is capped at half of the capped total mana (500 / (500 + 500))
+		{0.5, 0.5, 0.05},
+	}
+
+	for _, test := range tests {
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			opinionGivers := []opinion.OpinionGiver{&opiniongivermock{roundsReplies: []opinion.Opinions{{opinion.Like}}, mana: 1000}}
+			for i := 0; i < 1000; i++ {
+				opinionGivers = append(opinionGivers, &opiniongivermock{roundsReplies: []opinion.Opinions{{opinion.Dislike}}, mana: float64(i % 2)})
+			}
+			return opinionGivers, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1000
+		paras.MaxOpinionGiverWeight = test.maxOpinionGiverWeight
+		voter := fpc.New(opinionGiverFunc, paras)
+
+		var liked float64
+		voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+			liked = roundStats.ActiveVoteContexts["a"].Liked
+		}))
+
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		assert.NoError(t, voter.Round(0.5, vote.UnixTimestampRandomness))
+		assert.InDelta(t, test.expectedLiked, liked, test.delta)
+	}
+}
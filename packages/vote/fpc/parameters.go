@@ -22,6 +22,8 @@ type Parameters struct {
 	MaxRoundsPerVoteContext int
 	// The max amount of time a query is allowed to take.
 	QueryTimeout time.Duration
+	// The max share of the total mana that is taken into account for a single opinion giver (0 disables the cap).
+	MaxOpinionGiverWeight float64
 }
 
 // DefaultParameters returns the default parameters used in FPC.
@@ -36,6 +38,7 @@ func DefaultParameters() *Parameters {
 		CoolingOffPeriod:                    0,
 		MaxRoundsPerVoteContext:             100,
 		QueryTimeout:                        6500 * time.Millisecond,
+		MaxOpinionGiverWeight:               0,
 	}
 }
 
@@ -0,0 +1,126 @@
+package fpc
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// opinionGiverSampler draws OpinionGivers (with replacement) with a probability that is proportional to their mana. It
+// uses the alias method, so that every draw only takes constant time.
+type opinionGiverSampler struct {
+	opinionGivers []opinion.OpinionGiver
+	probabilities []float64
+	aliases       []int
+}
+
+// newOpinionGiverSampler creates an opinionGiverSampler for the given OpinionGivers. If maxWeight is in the interval
+// (0, 1), the mana of the OpinionGivers is capped so that none of them holds more than the given share of the capped
+// total mana. If none of the OpinionGivers has any mana, all of them are drawn with the same probability.
+func newOpinionGiverSampler(opinionGivers []opinion.OpinionGiver, maxWeight float64) (sampler *opinionGiverSampler) {
+	weights := make([]float64, len(opinionGivers))
+	for i, opinionGiver := range opinionGivers {
+		weights[i] = math.Max(opinionGiver.Mana(), 0)
+	}
+
+	if maxWeight > 0 && maxWeight < 1 {
+		maxOpinionGiverWeight := weightCap(weights, maxWeight)
+		for i := range weights {
+			weights[i] = math.Min(weights[i], maxOpinionGiverWeight)
+		}
+	}
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		totalWeight = float64(len(weights))
+	}
+
+	sampler = &opinionGiverSampler{
+		opinionGivers: opinionGivers,
+		probabilities: make([]float64, len(opinionGivers)),
+		aliases:       make([]int, len(opinionGivers)),
+	}
+
+	small := make([]int, 0, len(weights))
+	large := make([]int, 0, len(weights))
+	for i := range weights {
+		if weights[i] = weights[i] * float64(len(weights)) / totalWeight; weights[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		smallIndex, largeIndex := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+
+		sampler.probabilities[smallIndex] = weights[smallIndex]
+		sampler.aliases[smallIndex] = largeIndex
+
+		if weights[largeIndex] -= 1 - weights[smallIndex]; weights[largeIndex] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, largeIndex)
+		}
+	}
+
+	// the remaining entries only differ from 1 because of floating point inaccuracies
+	for _, index := range append(small, large...) {
+		sampler.probabilities[index] = 1
+	}
+
+	return
+}
+
+// weightCap returns the largest limit for the given weights, so that no capped weight exceeds the given share of the
+// sum of the capped weights. Capping a weight lowers the sum as well, so the limit is found by capping the largest
+// weights one after the other until the remaining ones fit below it. If no such limit exists, because there are fewer
+// than 1/maxShare weights, it returns the smallest weight, which makes all weights equal.
+func weightCap(weights []float64, maxShare float64) (limit float64) {
+	if len(weights) == 0 {
+		return 0
+	}
+
+	sortedWeights := make([]float64, len(weights))
+	copy(sortedWeights, weights)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sortedWeights)))
+
+	uncappedWeight := 0.0
+	for _, weight := range sortedWeights {
+		uncappedWeight += weight
+	}
+
+	for cappedCount, weight := range sortedWeights {
+		// the capped weights can not exceed their share anymore
+		if float64(cappedCount)*maxShare >= 1 {
+			return sortedWeights[cappedCount-1]
+		}
+
+		// solve limit = maxShare * (cappedCount * limit + uncappedWeight)
+		if limit = maxShare * uncappedWeight / (1 - float64(cappedCount)*maxShare); weight <= limit {
+			return limit
+		}
+		uncappedWeight -= weight
+	}
+
+	return sortedWeights[len(sortedWeights)-1]
+}
+
+// sample draws a random OpinionGiver.
+func (o *opinionGiverSampler) sample(rng *rand.Rand) opinion.OpinionGiver {
+	index := rng.Intn(len(o.opinionGivers))
+	if rng.Float64() < o.probabilities[index] {
+		return o.opinionGivers[index]
+	}
+
+	return o.opinionGivers[o.aliases[index]]
+}
@@ -0,0 +1,297 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultIdleTimeout defines the default time after which unused connections are closed.
+	DefaultIdleTimeout = 1 * time.Minute
+
+	// DefaultMinReconnectBackoff defines the default time a peer is not queried after its first failed query.
+	DefaultMinReconnectBackoff = 1 * time.Second
+
+	// DefaultMaxReconnectBackoff defines the default maximum time a peer is not queried after repeated failed queries.
+	DefaultMaxReconnectBackoff = 1 * time.Minute
+)
+
+// ErrReconnectBackoff is returned if a peer is queried while its connection is in the reconnect backoff.
+var ErrReconnectBackoff = errors.New("connection is in reconnect backoff")
+
+// region ConnectionPool ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConnectionPool manages persistent gRPC connections to the FPC services of other peers, so that the connections do not
+// have to be established again for every query. Connections that failed are closed and only reestablished after an
+// exponentially increasing backoff and connections that were not used for a while are closed.
+type ConnectionPool struct {
+	options          *ConnectionPoolOptions
+	connections      map[identity.ID]*pooledConnection
+	connectionsMutex sync.Mutex
+	shutdownSignal   chan struct{}
+	shutdownOnce     sync.Once
+}
+
+// NewConnectionPool is the constructor of the ConnectionPool.
+func NewConnectionPool(options ...ConnectionPoolOption) (connectionPool *ConnectionPool) {
+	connectionPool = &ConnectionPool{
+		options:        DefaultConnectionPoolOptions(),
+		connections:    make(map[identity.ID]*pooledConnection),
+		shutdownSignal: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(connectionPool.options)
+	}
+
+	go connectionPool.closeIdleConnectionsLoop()
+
+	return
+}
+
+// Query sends the given QueryRequest to the FPC service of the given peer by using its pooled connection (the
+// connection is established if it does not exist, yet).
+func (c *ConnectionPool) Query(ctx context.Context, peerID identity.ID, address string, query *QueryRequest) (reply *QueryReply, err error) {
+	connection, err := c.connection(peerID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply, err = NewVoterQueryClient(connection).Opinion(ctx, query); err != nil {
+		c.reportFailure(peerID, connection)
+
+		return nil, err
+	}
+	c.reportSuccess(peerID)
+
+	return reply, nil
+}
+
+// Evict closes and removes the connection of the given peer (i.e. if it is no longer known).
+func (c *ConnectionPool) Evict(peerID identity.ID) {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	if pooledConnection, exists := c.connections[peerID]; exists {
+		pooledConnection.close()
+		delete(c.connections, peerID)
+	}
+}
+
+// CloseIdleConnections closes the connections that were not used for longer than the idle timeout.
+func (c *ConnectionPool) CloseIdleConnections() {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	for peerID, pooledConnection := range c.connections {
+		if time.Since(pooledConnection.lastUsed) < c.options.IdleTimeout {
+			continue
+		}
+
+		pooledConnection.close()
+		// keep the backoff of failed peers
+		if pooledConnection.failures == 0 {
+			delete(c.connections, peerID)
+		}
+	}
+}
+
+// Size returns the number of open connections.
+func (c *ConnectionPool) Size() (size int) {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	for _, pooledConnection := range c.connections {
+		if pooledConnection.conn != nil {
+			size++
+		}
+	}
+
+	return
+}
+
+// Shutdown closes all connections and stops the ConnectionPool.
+func (c *ConnectionPool) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		close(c.shutdownSignal)
+
+		c.connectionsMutex.Lock()
+		defer c.connectionsMutex.Unlock()
+
+		for peerID, pooledConnection := range c.connections {
+			pooledConnection.close()
+			delete(c.connections, peerID)
+		}
+	})
+}
+
+// connection returns the connection to the given peer (and dials it if necessary).
+func (c *ConnectionPool) connection(peerID identity.ID, address string) (connection *grpc.ClientConn, err error) {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	pooledConn, exists := c.connections[peerID]
+	if !exists {
+		pooledConn = &pooledConnection{}
+		c.connections[peerID] = pooledConn
+	}
+	pooledConn.lastUsed = time.Now()
+
+	// the peer changed its address
+	if pooledConn.address != address {
+		pooledConn.close()
+		pooledConn.address = address
+	}
+
+	if pooledConn.conn != nil {
+		return pooledConn.conn, nil
+	}
+
+	if time.Now().Before(pooledConn.nextDial) {
+		return nil, fmt.Errorf("%w: %s", ErrReconnectBackoff, peerID)
+	}
+
+	if pooledConn.conn, err = grpc.Dial(address, c.options.DialOptions...); err != nil {
+		return nil, fmt.Errorf("unable to connect to FPC service: %w", err)
+	}
+
+	return pooledConn.conn, nil
+}
+
+// reportFailure closes the given connection of the peer and increases its reconnect backoff.
+func (c *ConnectionPool) reportFailure(peerID identity.ID, connection *grpc.ClientConn) {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	// ignore failures of connections that were replaced in the meantime
+	pooledConnection, exists := c.connections[peerID]
+	if !exists || pooledConnection.conn != connection {
+		return
+	}
+
+	pooledConnection.close()
+	pooledConnection.failures++
+	pooledConnection.nextDial = time.Now().Add(c.backoff(pooledConnection.failures))
+}
+
+// reportSuccess resets the reconnect backoff of the given peer.
+func (c *ConnectionPool) reportSuccess(peerID identity.ID) {
+	c.connectionsMutex.Lock()
+	defer c.connectionsMutex.Unlock()
+
+	if pooledConnection, exists := c.connections[peerID]; exists {
+		pooledConnection.failures = 0
+		pooledConnection.nextDial = time.Time{}
+	}
+}
+
+// backoff returns the reconnect backoff after the given number of consecutive failures.
+func (c *ConnectionPool) backoff(failures int) (backoff time.Duration) {
+	backoff = c.options.MinReconnectBackoff
+	for i := 1; i < failures && backoff < c.options.MaxReconnectBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.options.MaxReconnectBackoff {
+		backoff = c.options.MaxReconnectBackoff
+	}
+
+	return
+}
+
+// closeIdleConnectionsLoop periodically closes the idle connections until the ConnectionPool is shut down (a
+// non-positive idle timeout keeps the connections open).
+func (c *ConnectionPool) closeIdleConnectionsLoop() {
+	if c.options.IdleTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.options.IdleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.CloseIdleConnections()
+		case <-c.shutdownSignal:
+			return
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConnectionPoolOptions ////////////////////////////////////////////////////////////////////////////////////////
+
+// ConnectionPoolOptions is a container for the options of the ConnectionPool.
+type ConnectionPoolOptions struct {
+	IdleTimeout         time.Duration
+	MinReconnectBackoff time.Duration
+	MaxReconnectBackoff time.Duration
+	DialOptions         []grpc.DialOption
+}
+
+// DefaultConnectionPoolOptions returns the default options of the ConnectionPool.
+func DefaultConnectionPoolOptions() *ConnectionPoolOptions {
+	return &ConnectionPoolOptions{
+		IdleTimeout:         DefaultIdleTimeout,
+		MinReconnectBackoff: DefaultMinReconnectBackoff,
+		MaxReconnectBackoff: DefaultMaxReconnectBackoff,
+		DialOptions:         []grpc.DialOption{grpc.WithInsecure()},
+	}
+}
+
+// ConnectionPoolOption represents the return type of the optional config parameters of the ConnectionPool.
+type ConnectionPoolOption func(*ConnectionPoolOptions)
+
+// IdleTimeout is an Option for the ConnectionPool that defines the time after which unused connections are closed.
+func IdleTimeout(idleTimeout time.Duration) ConnectionPoolOption {
+	return func(options *ConnectionPoolOptions) {
+		options.IdleTimeout = idleTimeout
+	}
+}
+
+// ReconnectBackoff is an Option for the ConnectionPool that defines the bounds of the exponential backoff after which
+// the connections of failed peers are reestablished.
+func ReconnectBackoff(minBackoff, maxBackoff time.Duration) ConnectionPoolOption {
+	return func(options *ConnectionPoolOptions) {
+		options.MinReconnectBackoff = minBackoff
+		options.MaxReconnectBackoff = maxBackoff
+	}
+}
+
+// DialOptions is an Option for the ConnectionPool that defines the options that are used to establish connections.
+func DialOptions(dialOptions ...grpc.DialOption) ConnectionPoolOption {
+	return func(options *ConnectionPoolOptions) {
+		options.DialOptions = dialOptions
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region pooledConnection /////////////////////////////////////////////////////////////////////////////////////////////
+
+// pooledConnection is the entry of a single peer in the ConnectionPool.
+type pooledConnection struct {
+	address  string
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+	failures int
+	nextDial time.Time
+}
+
+// close closes the underlying connection (if it is open).
+func (p *pooledConnection) close() {
+	if p.conn == nil {
+		return
+	}
+
+	_ = p.conn.Close()
+	p.conn = nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,109 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestConnectionPool(t *testing.T) {
+	address := freeAddress(t)
+	voterServer := New(fpc.New(nil), func(string, vote.ObjectType) opinion.Opinion {
+		return opinion.Like
+	}, address, nil, nil, nil)
+	go func() {
+		_ = voterServer.Run()
+	}()
+	defer voterServer.Shutdown()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	connectionPool := NewConnectionPool(ReconnectBackoff(time.Minute, time.Hour))
+	defer connectionPool.Shutdown()
+
+	peerID := identity.GenerateIdentity().ID()
+	query := &QueryRequest{ConflictIDs: []string{"a"}, TimestampIDs: []string{"b"}}
+
+	// the connection is established once and reused by subsequent queries
+	var firstConnection interface{}
+	for i := 0; i < 3; i++ {
+		reply := queryWithTimeout(t, connectionPool, peerID, address, query)
+		assert.Equal(t, []int32{int32(opinion.Like), int32(opinion.Like)}, reply.Opinion)
+		assert.Equal(t, 1, connectionPool.Size())
+
+		if i == 0 {
+			firstConnection = connectionPool.connections[peerID].conn
+		}
+		assert.Equal(t, firstConnection, connectionPool.connections[peerID].conn)
+	}
+
+	// evicted peers are reconnected on demand
+	connectionPool.Evict(peerID)
+	assert.Equal(t, 0, connectionPool.Size())
+	queryWithTimeout(t, connectionPool, peerID, address, query)
+	assert.Equal(t, 1, connectionPool.Size())
+
+	// idle connections are closed
+	connectionPool.connections[peerID].lastUsed = time.Now().Add(-DefaultIdleTimeout)
+	connectionPool.CloseIdleConnections()
+	assert.Equal(t, 0, connectionPool.Size())
+}
+
+func TestConnectionPool_ReconnectBackoff(t *testing.T) {
+	connectionPool := NewConnectionPool(ReconnectBackoff(time.Minute, time.Hour))
+	defer connectionPool.Shutdown()
+
+	peerID := identity.GenerateIdentity().ID()
+	query := &QueryRequest{ConflictIDs: []string{"a"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the first query fails because nobody is listening and the peer is not dialed again during the backoff
+	_, err := connectionPool.Query(ctx, peerID, freeAddress(t), query)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrReconnectBackoff))
+
+	_, err = connectionPool.Query(ctx, peerID, freeAddress(t), query)
+	assert.True(t, errors.Is(err, ErrReconnectBackoff))
+	assert.Equal(t, 0, connectionPool.Size())
+
+	assert.Equal(t, time.Minute, connectionPool.backoff(1))
+	assert.Equal(t, 4*time.Minute, connectionPool.backoff(3))
+	assert.Equal(t, time.Hour, connectionPool.backoff(10))
+}
+
+func queryWithTimeout(t *testing.T, connectionPool *ConnectionPool, peerID identity.ID, address string, query *QueryRequest) *QueryReply {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reply, err := connectionPool.Query(ctx, peerID, address, query)
+	require.NoError(t, err)
+
+	return reply
+}
+
+// freeAddress returns a local address that is currently not in use.
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
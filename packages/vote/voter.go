@@ -2,6 +2,7 @@ package vote
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/iotaledger/hive.go/events"
@@ -26,8 +27,30 @@ type Voter interface {
 // were generated in a decentralized fashion.
 type DRNGRoundBasedVoter interface {
 	Voter
-	// Round starts a new round.
-	Round(rand float64) error
+	// Round starts a new round using the given random number that was taken from the given source.
+	Round(rand float64, source RandomnessSource) error
+}
+
+// RandomnessSource defines the source of the random number that is used in a round.
+type RandomnessSource uint8
+
+const (
+	// UnixTimestampRandomness defines random numbers that are derived from the current Unix timestamp.
+	UnixTimestampRandomness RandomnessSource = iota
+	// DRNGRandomness defines random numbers that are taken from the collective beacons of a dRNG committee.
+	DRNGRandomness
+)
+
+// String returns a human readable version of the RandomnessSource.
+func (r RandomnessSource) String() string {
+	switch r {
+	case UnixTimestampRandomness:
+		return "UnixTimestamp"
+	case DRNGRandomness:
+		return "DRNG"
+	default:
+		return fmt.Sprintf("RandomnessSource(%d)", uint8(r))
+	}
 }
 
 // Events defines events which happen on a Voter.
@@ -48,6 +71,8 @@ type RoundStats struct {
 	Duration time.Duration `json:"duration"`
 	// The rand number used during the round.
 	RandUsed float64 `json:"rand_used"`
+	// The source of the rand number used during the round.
+	RandSource RandomnessSource `json:"rand_source"`
 	// The vote contexts on which opinions were formed and queried.
 	// This list does not include the vote contexts which were finalized/aborted
 	// during the execution of the round.
@@ -37,6 +37,7 @@ func onRoundExecuted(roundStats *vote.RoundStats) {
 		rs := vote.RoundStats{
 			Duration:           roundStats.Duration,
 			RandUsed:           roundStats.RandUsed,
+			RandSource:         roundStats.RandSource,
 			ActiveVoteContexts: chunk,
 		}
 
@@ -45,6 +45,9 @@ func configureEvents() {
 		return
 	}
 
+	// use the collective beacons as the source of randomness of the FPC rounds
+	messagelayer.SetDRNG(Instance())
+
 	messagelayer.Tangle().ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
 		messagelayer.Tangle().Storage.Message(messageID).Consume(func(msg *tangle.Message) {
 			if msg.Payload().Type() != drng.PayloadType {
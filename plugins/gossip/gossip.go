@@ -48,7 +48,7 @@ func createManager() {
 	if err := lPeer.UpdateService(service.GossipKey, "tcp", gossipPort); err != nil {
 		log.Fatalf("could not update services: %s", err)
 	}
-	mgr = gossip.NewManager(lPeer, loadMessage, log)
+	mgr = gossip.NewManager(lPeer, loadMessage, log, gossip.LoadPastCone(loadPastCone))
 }
 
 func start(shutdownSignal <-chan struct{}) {
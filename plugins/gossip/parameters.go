@@ -13,10 +13,16 @@ const (
 	CfgGossipAgeThreshold = "gossip.ageThreshold"
 	// CfgGossipTipsBroadcastInterval the interval in which the oldest known tip is re-broadcast.
 	CfgGossipTipsBroadcastInterval = "gossip.tipsBroadcaster.interval"
+	// CfgGossipSyncTimeout defines the time after which a bulk synchronization without progress is retried.
+	CfgGossipSyncTimeout = "gossip.sync.timeout"
+	// CfgGossipSyncMaxMessages defines the maximum size of the past cone that can be synchronized with sync requests.
+	CfgGossipSyncMaxMessages = "gossip.sync.maxMessages"
 )
 
 func init() {
 	flag.Int(CfgGossipPort, 14666, "tcp port for gossip connection")
 	flag.Duration(CfgGossipAgeThreshold, 5*time.Second, "message age threshold for gossip")
 	flag.Duration(CfgGossipTipsBroadcastInterval, 10*time.Second, "the interval in which the oldest known tip is re-broadcast")
+	flag.Duration(CfgGossipSyncTimeout, 30*time.Second, "the time after which a bulk synchronization without progress is retried with another neighbor")
+	flag.Int(CfgGossipSyncMaxMessages, 100000, "the maximum size of the past cone that can be synchronized with sync requests")
 }
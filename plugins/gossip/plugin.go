@@ -31,6 +31,8 @@ var (
 	log                     *logger.Logger
 	ageThreshold            time.Duration
 	tipsBroadcasterInterval time.Duration
+	syncTimeout             time.Duration
+	syncMaxMessages         int
 
 	requestedMsgs *requestedMessages
 )
@@ -47,11 +49,14 @@ func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	ageThreshold = config.Node().Duration(CfgGossipAgeThreshold)
 	tipsBroadcasterInterval = config.Node().Duration(CfgGossipTipsBroadcastInterval)
+	syncTimeout = config.Node().Duration(CfgGossipSyncTimeout)
+	syncMaxMessages = config.Node().Int(CfgGossipSyncMaxMessages)
 	requestedMsgs = newRequestedMessages()
 
 	configureLogging()
 	configureMessageLayer()
 	configureAutopeering()
+	configureSynchronizer()
 }
 
 func run(*node.Plugin) {
@@ -61,6 +66,9 @@ func run(*node.Plugin) {
 	if err := daemon.BackgroundWorker(tipsBroadcasterName, startTipBroadcaster, shutdown.PriorityGossip); err != nil {
 		log.Panicf("Failed to start as daemon: %s", err)
 	}
+	if err := daemon.BackgroundWorker(synchronizerName, startSynchronizer, shutdown.PrioritySynchronization); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
 }
 
 func configureAutopeering() {
@@ -151,7 +159,11 @@ func configureMessageLayer() {
 
 	// request missing messages
 	messagelayer.Tangle().Requester.Events.SendRequest.Attach(events.NewClosure(func(sendRequest *tangle.SendRequestEvent) {
-		mgr.RequestMessage(sendRequest.ID[:])
+		messageIDs := make([][]byte, len(sendRequest.IDs))
+		for i, messageID := range sendRequest.IDs {
+			messageIDs[i] = messageID.Bytes()
+		}
+		mgr.RequestMessages(messageIDs)
 	}))
 
 	messagelayer.Tangle().Storage.Events.MissingMessageStored.Attach(events.NewClosure(requestedMsgs.append))
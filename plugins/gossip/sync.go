@@ -0,0 +1,323 @@
+package gossip
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+const (
+	// the name of the synchronizer worker
+	synchronizerName = PluginName + "[Synchronizer]"
+
+	// the maximum amount of tips that are sent as the known frontier in a sync request
+	maxKnownFrontierSize = 64
+)
+
+var synchronizer = &pastConeSynchronizer{}
+
+// SyncStatus contains the progress of the bulk synchronization of the past cone of a sync beacon.
+type SyncStatus struct {
+	// Active is true while the past cone is synchronized.
+	Active bool
+	// Target is the sync beacon message whose past cone is synchronized.
+	Target tangle.MessageID
+	// Peer is the neighbor that was asked for the past cone.
+	Peer identity.ID
+	// Received is the number of messages that were received so far.
+	Received int
+	// Total is the number of messages that the neighbor announced (0 until the first response arrived).
+	Total int
+	// StartTime is the time the synchronization was started.
+	StartTime time.Time
+}
+
+// Sync returns the status of the current (or last) bulk synchronization.
+func Sync() SyncStatus {
+	return synchronizer.Status()
+}
+
+// pastConeSynchronizer requests the past cone of sync beacons in bulk from a single neighbor if the node is not synced.
+type pastConeSynchronizer struct {
+	status          SyncStatus
+	knownMessageIDs [][]byte
+	lastRequest     time.Time
+	lastActivity    time.Time
+	mutex           sync.RWMutex
+}
+
+// Status returns a copy of the current SyncStatus.
+func (p *pastConeSynchronizer) Status() SyncStatus {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.status
+}
+
+// Start starts the synchronization of the past cone of the given target unless a synchronization is running already.
+func (p *pastConeSynchronizer) Start(target tangle.MessageID) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.status.Active {
+		return
+	}
+
+	p.start(target, identity.ID{})
+}
+
+// HandleSyncResponse updates the progress of the running synchronization.
+func (p *pastConeSynchronizer) HandleSyncResponse(event *gossip.SyncResponseReceivedEvent) {
+	target, _, err := tangle.MessageIDFromBytes(event.Target)
+	if err != nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.status.Active || p.status.Target != target || p.status.Peer != event.Peer.ID() {
+		return
+	}
+
+	p.lastActivity = time.Now()
+	p.status.Total = event.Total
+	p.status.Received += event.Count
+
+	if event.Next != 0 {
+		p.requestPage(target, uint32(event.Next))
+		return
+	}
+
+	if p.status.Received >= p.status.Total {
+		p.status.Active = false
+		log.Infof("Synchronized past cone of %s: %d messages received from %s in %v", target, p.status.Received, event.Peer.ID(), time.Since(p.status.StartTime))
+	}
+}
+
+// requestPage requests the page of the past cone of the target that starts at the given offset from the neighbor of the
+// running synchronization. The requests are spaced by the SyncRequestInterval, so that they are not dropped by the rate
+// limit of the neighbor (the mutex needs to be locked).
+func (p *pastConeSynchronizer) requestPage(target tangle.MessageID, offset uint32) {
+	peerID, knownMessageIDs := p.status.Peer, p.knownMessageIDs
+	request := func() {
+		if err := Manager().RequestSync(target.Bytes(), knownMessageIDs, offset, peerID); err != nil {
+			log.Debugw("error requesting sync", "peer-id", peerID, "err", err)
+		}
+	}
+
+	delay := time.Until(p.lastRequest.Add(gossip.SyncRequestInterval))
+	if delay < 0 {
+		delay = 0
+	}
+	p.lastRequest = time.Now().Add(delay)
+	time.AfterFunc(delay, request)
+}
+
+// CheckTimeout retries a synchronization that did not make any progress within the timeout with another neighbor.
+func (p *pastConeSynchronizer) CheckTimeout() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.status.Active || time.Since(p.lastActivity) < syncTimeout {
+		return
+	}
+
+	log.Warnf("Synchronization of past cone of %s with %s timed out after receiving %d messages", p.status.Target, p.status.Peer, p.status.Received)
+	p.status.Active = false
+
+	if messagelayer.Tangle().Synced() {
+		return
+	}
+
+	p.start(p.status.Target, p.status.Peer)
+}
+
+// start sends a sync request for the given target to a random neighbor that is not the excluded one (the mutex needs to
+// be locked).
+func (p *pastConeSynchronizer) start(target tangle.MessageID, excludedPeer identity.ID) {
+	neighbors := Manager().AllNeighbors()
+	candidates := make([]*gossip.Neighbor, 0, len(neighbors))
+	for _, neighbor := range neighbors {
+		if neighbor.ID() != excludedPeer {
+			candidates = append(candidates, neighbor)
+		}
+	}
+	if len(candidates) == 0 {
+		// fall back to the excluded neighbor if it is the only one
+		if len(neighbors) == 0 {
+			return
+		}
+		candidates = neighbors
+	}
+	neighbor := candidates[rand.Intn(len(candidates))]
+
+	knownFrontier := messagelayer.Tangle().TipManager.AllStrongTips()
+	if len(knownFrontier) > maxKnownFrontierSize {
+		knownFrontier = knownFrontier[:maxKnownFrontierSize]
+	}
+	knownMessageIDs := make([][]byte, len(knownFrontier))
+	for i, messageID := range knownFrontier {
+		knownMessageIDs[i] = messageID.Bytes()
+	}
+
+	if err := Manager().RequestSync(target.Bytes(), knownMessageIDs, 0, neighbor.ID()); err != nil {
+		log.Debugw("error requesting sync", "peer-id", neighbor.ID(), "err", err)
+		return
+	}
+
+	p.status = SyncStatus{
+		Active:    true,
+		Target:    target,
+		Peer:      neighbor.ID(),
+		StartTime: time.Now(),
+	}
+	p.knownMessageIDs = knownMessageIDs
+	p.lastRequest = p.status.StartTime
+	p.lastActivity = p.status.StartTime
+
+	log.Infof("Synchronizing past cone of %s with %s", target, neighbor.ID())
+}
+
+func configureSynchronizer() {
+	Manager().Events().SyncResponseReceived.Attach(events.NewClosure(synchronizer.HandleSyncResponse))
+
+	// synchronize the past cone of new sync beacons in bulk while the node is not synced
+	messagelayer.Tangle().Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		if messagelayer.Tangle().Synced() {
+			return
+		}
+
+		messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+			if !messagelayer.IsFollowedSyncBeacon(message) {
+				return
+			}
+
+			synchronizer.Start(messageID)
+		})
+	}))
+}
+
+func startSynchronizer(shutdownSignal <-chan struct{}) {
+	ticker := time.NewTicker(syncTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			synchronizer.CheckTimeout()
+		case <-shutdownSignal:
+			return
+		}
+	}
+}
+
+// loads a page of the past cone of the given target (without the past cone of the known messages) from the message
+// layer.
+func loadPastCone(target tangle.MessageID, knownMessageIDs tangle.MessageIDs, offset int, limit int) ([][]byte, int, error) {
+	pastCone := pastCones.Load(target, knownMessageIDs, offset)
+	if offset >= len(pastCone) {
+		return nil, len(pastCone), nil
+	}
+	page := pastCone[offset:]
+	if len(page) > limit {
+		page = page[:limit]
+	}
+
+	messages := make([][]byte, 0, len(page))
+	for _, messageID := range page {
+		messageBytes, err := loadMessage(messageID)
+		if err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, messageBytes)
+	}
+
+	return messages, len(pastCone), nil
+}
+
+// the maximum amount of past cones that are cached for the sync sessions of neighbors
+const maxCachedPastCones = 8
+
+var pastCones = &pastConeCache{entries: make(map[string]*pastConeCacheEntry)}
+
+// pastConeCache caches the sorted past cones that are served to neighbors, so that the past cone of a sync session is
+// computed once for its first page instead of once for every page. The entries expire if the session does not request
+// a page within the sync timeout.
+type pastConeCache struct {
+	entries map[string]*pastConeCacheEntry
+	mutex   sync.Mutex
+}
+
+// pastConeCacheEntry is a cached past cone together with the time it was last requested.
+type pastConeCacheEntry struct {
+	pastCone   tangle.MessageIDs
+	lastAccess time.Time
+}
+
+// Load returns the past cone of the given target without the past cone of the known messages. The past cone is
+// computed for the first page of a session (offset 0) and taken from the cache for the following pages.
+func (p *pastConeCache) Load(target tangle.MessageID, knownMessageIDs tangle.MessageIDs, offset int) tangle.MessageIDs {
+	key := pastConeCacheKey(target, knownMessageIDs)
+
+	p.mutex.Lock()
+	p.expire()
+	if entry, exists := p.entries[key]; exists && offset != 0 {
+		entry.lastAccess = time.Now()
+		p.mutex.Unlock()
+		return entry.pastCone
+	}
+	p.mutex.Unlock()
+
+	pastCone := messagelayer.Tangle().Utils.PastCone(target, knownMessageIDs, syncMaxMessages)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.entries[key] = &pastConeCacheEntry{pastCone: pastCone, lastAccess: time.Now()}
+	for len(p.entries) > maxCachedPastCones {
+		p.evictOldest()
+	}
+
+	return pastCone
+}
+
+// expire removes the past cones of the sessions that did not request a page within the sync timeout (the mutex needs
+// to be locked).
+func (p *pastConeCache) expire() {
+	for key, entry := range p.entries {
+		if time.Since(entry.lastAccess) > syncTimeout {
+			delete(p.entries, key)
+		}
+	}
+}
+
+// evictOldest removes the past cone that was requested least recently (the mutex needs to be locked).
+func (p *pastConeCache) evictOldest() {
+	var oldestKey string
+	var oldestAccess time.Time
+	for key, entry := range p.entries {
+		if oldestAccess.IsZero() || entry.lastAccess.Before(oldestAccess) {
+			oldestKey, oldestAccess = key, entry.lastAccess
+		}
+	}
+	delete(p.entries, oldestKey)
+}
+
+// pastConeCacheKey returns the key of the past cone of the given target without the past cone of the known messages.
+func pastConeCacheKey(target tangle.MessageID, knownMessageIDs tangle.MessageIDs) string {
+	key := make([]byte, 0, (len(knownMessageIDs)+1)*tangle.MessageIDLength)
+	key = append(key, target.Bytes()...)
+	for _, knownMessageID := range knownMessageIDs {
+		key = append(key, knownMessageID.Bytes()...)
+	}
+
+	return string(key)
+}
@@ -2,5 +2,9 @@ package mana
 
 import "golang.org/x/xerrors"
 
-// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
-var ErrQueryNotAllowed = xerrors.New("mana query not allowed, node is not synced, debug mode disabled")
+var (
+	// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
+	ErrQueryNotAllowed = xerrors.New("mana query not allowed, node is not synced, debug mode disabled")
+	// ErrTooManyHistorySamples is returned when a mana history with more than maxHistorySamples samples is requested.
+	ErrTooManyHistorySamples = xerrors.New("too many samples in mana history")
+)
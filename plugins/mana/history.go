@@ -0,0 +1,252 @@
+package mana
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/config"
+)
+
+const (
+	// maxHistorySamples is the maximum number of samples per node of a mana history.
+	maxHistorySamples = 10000
+	// maxHistoryValues is the maximum number of samples of all nodes of a mana history.
+	maxHistoryValues = 1000000
+	// maxCachedHistoryValues is the maximum number of samples of all cached mana histories.
+	maxCachedHistoryValues = 2 * maxHistoryValues
+)
+
+var (
+	historyCache       = newHistoryLRUCache(maxCachedHistoryValues)
+	historyComputation singleflight.Group
+)
+
+// GetManaHistory returns the effective mana of the given nodes (or of all nodes if none are given) sampled every
+// interval between start and end. Start and end are truncated to multiples of the interval, so that requests of the
+// current history share their samples (and their cache entry) until the next interval begins. The history is computed
+// from the persisted event logs, so it only reaches back as far as the logs do, and the results are cached for the
+// configured time.
+func GetManaHistory(manaType mana.Type, nodeIDs []identity.ID, start, end time.Time, interval time.Duration) (map[identity.ID][]*mana.HistorySample, error) {
+	if !QueryAllowed() {
+		return nil, ErrQueryNotAllowed
+	}
+	if manaType != mana.AccessMana && manaType != mana.ConsensusMana {
+		return nil, xerrors.Errorf("no history for mana type %s: %w", manaType.String(), mana.ErrUnknownManaType)
+	}
+	if interval <= 0 || end.Before(start) {
+		return nil, xerrors.Errorf("invalid history with interval %s between %s and %s", interval, start, end)
+	}
+	start, end = start.Truncate(interval), end.Truncate(interval)
+	samples := int(end.Sub(start)/interval) + 1
+	nodeCount := len(nodeIDs)
+	if nodeCount == 0 {
+		nodeCount = baseManaVectors[manaType].Size()
+	}
+	if samples > maxHistorySamples || samples*nodeCount > maxHistoryValues {
+		return nil, xerrors.Errorf("history of %d nodes with interval %s between %s and %s: %w", nodeCount, interval, start, end, ErrTooManyHistorySamples)
+	}
+
+	cacheKey := historyCacheKey(manaType, nodeIDs, start, end, interval)
+	if history, exists := historyCache.Get(cacheKey, clock.SyncedTime()); exists {
+		return history, nil
+	}
+
+	// concurrent requests of the same history wait for a single computation
+	result, err, _ := historyComputation.Do(cacheKey, func() (interface{}, error) {
+		baseManaVector, eventLogs, err := historyBase(manaType, end)
+		if err != nil {
+			return nil, err
+		}
+		history, err := mana.BuildHistory(baseManaVector, eventLogs, nodeIDs, start, end, interval)
+		if err != nil {
+			return nil, err
+		}
+		if len(history)*samples > maxHistoryValues {
+			return nil, xerrors.Errorf("history of %d nodes with interval %s between %s and %s: %w", len(history), interval, start, end, ErrTooManyHistorySamples)
+		}
+
+		historyCache.Put(cacheKey, history, clock.SyncedTime().Add(config.Node().Duration(CfgHistoryCacheTime)))
+		return history, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[identity.ID][]*mana.HistorySample), nil
+}
+
+// historyBase returns the base mana vector and the sorted events until the given time that the history of the given
+// type of mana is built from.
+func historyBase(manaType mana.Type, end time.Time) (baseManaVector mana.BaseManaVector, eventLogs mana.EventSlice, err error) {
+	if baseManaVector, err = mana.NewBaseManaVector(manaType); err != nil {
+		return nil, nil, err
+	}
+	if manaType == mana.AccessMana {
+		if eventLogs, err = loggedEventsBetween(accessEventsLogStorage, time.Time{}, end); err != nil {
+			return nil, nil, err
+		}
+		return baseManaVector, eventLogs, nil
+	}
+
+	// the consensus events that have been pruned from the log are contained in the stored past vector
+	cbmvPast := baseManaVector.(*mana.ConsensusBaseManaVector)
+	var prunedUntil time.Time
+	if metadata := GetPastConsensusManaVectorMetadata(); metadata != nil {
+		consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+			cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
+			defer cachedPbm.Release()
+			err = cbmvPast.FromPersistable(cachedPbm.Unwrap())
+			return err == nil
+		})
+		if err != nil {
+			return nil, nil, xerrors.Errorf("error while restoring %s mana vector from storage: %w", mana.ConsensusMana.String(), err)
+		}
+		if cbmvPast.Size() > 0 {
+			prunedUntil = metadata.Timestamp
+		}
+	}
+	if eventLogs, err = loggedEventsBetween(consensusEventsLogStorage, prunedUntil, end); err != nil {
+		return nil, nil, err
+	}
+	return cbmvPast, eventLogs, nil
+}
+
+// loggedEventsBetween returns the sorted events of the given log that happened in the interval [start, end].
+func loggedEventsBetween(eventsLogStorage *objectstorage.ObjectStorage, start, end time.Time) (eventLogs mana.EventSlice, err error) {
+	eventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
+		defer cachedPe.Release()
+		pe := cachedPe.Unwrap()
+		if pe.Time.Before(start) || pe.Time.After(end) {
+			return true
+		}
+
+		var ev mana.Event
+		if ev, err = mana.FromPersistableEvent(pe); err != nil {
+			return false
+		}
+		eventLogs = append(eventLogs, ev)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	eventLogs.Sort()
+	return eventLogs, nil
+}
+
+// historyCacheKey returns the key of the mana history with the given parameters in the cache.
+func historyCacheKey(manaType mana.Type, nodeIDs []identity.ID, start, end time.Time, interval time.Duration) string {
+	nodeIDStrings := make([]string, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		nodeIDStrings[i] = fmt.Sprintf("%x", nodeID.Bytes())
+	}
+	sort.Strings(nodeIDStrings)
+	return fmt.Sprintf("%d:%d:%d:%d:%s", manaType, start.UnixNano(), end.UnixNano(), interval, strings.Join(nodeIDStrings, ","))
+}
+
+// pruneAccessEventLogsStorage removes the access mana events that are older than the configured retention. Access mana
+// decays, so old pledges do not contribute to the history anymore.
+func pruneAccessEventLogsStorage() {
+	retainedFrom := clock.SyncedTime().Add(-config.Node().Duration(CfgAccessEventLogsRetention))
+
+	var entriesToDelete [][]byte
+	accessEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
+		defer cachedPe.Release()
+		if pe := cachedPe.Unwrap(); pe.Time.Before(retainedFrom) {
+			entriesToDelete = append(entriesToDelete, pe.ObjectStorageKey())
+		}
+		return true
+	})
+	accessEventsLogStorage.DeleteEntriesFromStore(entriesToDelete)
+}
+
+// historyLRUCache is a cache of computed mana histories that holds at most a fixed number of samples and evicts the
+// least recently used histories first.
+type historyLRUCache struct {
+	entries    map[string]*list.Element
+	usageOrder *list.List
+	size       int
+	maxSize    int
+	mutex      sync.Mutex
+}
+
+// historyCacheEntry is a computed mana history together with the time it expires from the cache.
+type historyCacheEntry struct {
+	key       string
+	history   map[identity.ID][]*mana.HistorySample
+	size      int
+	expiresAt time.Time
+}
+
+// newHistoryLRUCache creates a historyLRUCache that holds at most maxSize samples.
+func newHistoryLRUCache(maxSize int) *historyLRUCache {
+	return &historyLRUCache{
+		entries:    make(map[string]*list.Element),
+		usageOrder: list.New(),
+		maxSize:    maxSize,
+	}
+}
+
+// Get returns the history with the given key if it is cached and did not expire before the given time.
+func (h *historyLRUCache) Get(key string, now time.Time) (history map[identity.ID][]*mana.HistorySample, exists bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	element, exists := h.entries[key]
+	if !exists {
+		return nil, false
+	}
+	entry := element.Value.(*historyCacheEntry)
+	if !entry.expiresAt.After(now) {
+		h.remove(element)
+		return nil, false
+	}
+
+	h.usageOrder.MoveToFront(element)
+	return entry.history, true
+}
+
+// Put adds the history with the given key to the cache and evicts the least recently used entries until the samples
+// of all cached histories fit into the cache. Histories that are larger than the cache are not cached.
+func (h *historyLRUCache) Put(key string, history map[identity.ID][]*mana.HistorySample, expiresAt time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if element, exists := h.entries[key]; exists {
+		h.remove(element)
+	}
+
+	size := 0
+	for _, samples := range history {
+		size += len(samples)
+	}
+	if size > h.maxSize {
+		return
+	}
+
+	h.entries[key] = h.usageOrder.PushFront(&historyCacheEntry{key: key, history: history, size: size, expiresAt: expiresAt})
+	h.size += size
+
+	for h.size > h.maxSize {
+		h.remove(h.usageOrder.Back())
+	}
+}
+
+// remove is an internal utility function that removes the given entry from the cache.
+func (h *historyLRUCache) remove(element *list.Element) {
+	entry := h.usageOrder.Remove(element).(*historyCacheEntry)
+	delete(h.entries, entry.key)
+	h.size -= entry.size
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
 )
 
 const (
@@ -13,6 +15,11 @@ const (
 	CfgEmaCoefficient2 = "mana.emaCoefficient2"
 	// CfgDecay defines the decay coefficient used for Base Mana 2 calculation.
 	CfgDecay = "mana.decay"
+	// CfgAccessManaFunction defines the name of the function that is used for the access mana calculation.
+	CfgAccessManaFunction = "mana.accessManaFunction"
+	// CfgConsensusManaFunction defines the name of the function that is used for the consensus mana calculation. It has
+	// to be the same on all nodes of the network.
+	CfgConsensusManaFunction = "mana.consensusManaFunction"
 	// CfgAllowedAccessPledge defines the list of nodes that access mana is allowed to be pledged to.
 	CfgAllowedAccessPledge = "mana.allowedAccessPledge"
 	// CfgAllowedAccessFilterEnabled defines if access mana pledge filter is enabled.
@@ -26,8 +33,16 @@ const (
 	CfgManaEnableResearchVectors = "mana.enableResearchVectors"
 	// CfgPruneConsensusEventLogsInterval defines the interval to check and prune consensus event logs storage.
 	CfgPruneConsensusEventLogsInterval = "mana.pruneConsensusEventLogsInterval"
+	// CfgAccessEventLogsRetention defines how long access mana events are kept to build the access mana history.
+	CfgAccessEventLogsRetention = "mana.accessEventLogsRetention"
+	// CfgHistoryCacheTime defines how long computed mana histories are cached.
+	CfgHistoryCacheTime = "mana.historyCacheTime"
 	// CfgVectorsCleanupInterval defines the interval to clean empty mana nodes from the base mana vectors.
 	CfgVectorsCleanupInterval = "mana.vectorsCleanupInterval"
+	// CfgVectorsCheckpointInterval defines the interval in which the mana vectors are persisted.
+	CfgVectorsCheckpointInterval = "mana.vectorsCheckpointInterval"
+	// CfgActivityWindow defines the time window in which a node needs to issue a message to count as active.
+	CfgActivityWindow = "mana.activityWindow"
 	// CfgDebuggingEnabled defines if the mana plugin responds to queries while not being in sync or not.
 	CfgDebuggingEnabled = "mana.debuggingEnabled"
 )
@@ -36,12 +51,18 @@ func init() {
 	flag.Float64(CfgEmaCoefficient1, 0.00003209, "coefficient used for Effective Base Mana 1 (moving average) calculation")   // half-life = 6 hours
 	flag.Float64(CfgEmaCoefficient2, 0.0057762265, "coefficient used for Effective Base Mana 2 (moving average) calculation") // half-life = 2 minutes
 	flag.Float64(CfgDecay, 0.00003209, "decay coefficient used for Base Mana 2 calculation")
+	flag.String(CfgAccessManaFunction, mana.EMAManaFunctionName, "function used for the access mana calculation (ema or instant)")
+	flag.String(CfgConsensusManaFunction, mana.EMAManaFunctionName, "function used for the consensus mana calculation (ema or instant), has to be the same on all nodes of the network")
 	flag.StringSlice(CfgAllowedAccessPledge, nil, "list of nodes that access mana is allowed to be pledged to")
 	flag.StringSlice(CfgAllowedConsensusPledge, nil, "list of nodes that consensus mana is allowed to be pledge to")
 	flag.Bool(CfgAllowedAccessFilterEnabled, false, "if filtering on access mana pledge nodes is enabled")
 	flag.Bool(CfgAllowedConsensusFilterEnabled, false, "if filtering on consensus mana pledge nodes is enabled")
 	flag.Bool(CfgManaEnableResearchVectors, false, "enable mana research vectors")
 	flag.Duration(CfgPruneConsensusEventLogsInterval, 5*time.Minute, "interval to check and prune consensus event storage")
+	flag.Duration(CfgAccessEventLogsRetention, 7*24*time.Hour, "time to keep access mana events to build the access mana history")
+	flag.Duration(CfgHistoryCacheTime, time.Minute, "time to cache computed mana histories")
 	flag.Duration(CfgVectorsCleanupInterval, 30*time.Minute, "interval to cleanup empty mana nodes from the mana vectors")
+	flag.Duration(CfgVectorsCheckpointInterval, time.Minute, "interval to persist the mana vectors together with the last booked transaction")
+	flag.Duration(CfgActivityWindow, 2*time.Minute, "time window in which a node needs to issue a message to count as active")
 	flag.Bool(CfgDebuggingEnabled, false, "if mana plugin responds to queries while not in sync")
 }
@@ -1,7 +1,7 @@
 package mana
 
 import (
-	"math"
+	"bytes"
 	"math/rand"
 	"sort"
 	"sync"
@@ -14,8 +14,10 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/types"
 	"go.uber.org/atomic"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	db_pkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -35,6 +37,11 @@ const (
 
 	maxConsensusEventsInStorage = 108000
 	slidingEventsInterval       = 10800 // 10% of maxConsensusEventsInStorage
+	activityPruningInterval     = 5 * time.Second
+
+	// confirmationReevaluationInterval defines how often the approval weight is reevaluated after the consensus mana
+	// of the active nodes changed.
+	confirmationReevaluationInterval = time.Second
 )
 
 var (
@@ -44,15 +51,23 @@ var (
 	log                                        *logger.Logger
 	baseManaVectors                            map[mana.Type]mana.BaseManaVector
 	osFactory                                  *objectstorage.Factory
-	storages                                   map[mana.Type]*objectstorage.ObjectStorage
+	vectorStorage                              *mana.VectorStorage
+	bookingMutex                               sync.Mutex
+	lastBookedIndex                            uint64
+	lastBookedTransactionID                    ledgerstate.TransactionID
 	allowedPledgeNodes                         map[mana.Type]AllowedPledge
 	consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
 	consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
 	consensusEventsLogStorage                  *objectstorage.ObjectStorage
 	consensusEventsLogsStorageSize             atomic.Uint32
+	accessEventsLogStorage                     *objectstorage.ObjectStorage
+	activityTracker                            *mana.ActivityTracker
 	onTransactionConfirmedClosure              *events.Closure
+	onMessageStoredClosure                     *events.Closure
 	onPledgeEventClosure                       *events.Closure
 	onRevokeEventClosure                       *events.Closure
+	onActiveManaChangedClosure                 *events.Closure
+	activeManaChanged                          atomic.Bool
 	debuggingEnabled                           bool
 )
 
@@ -68,10 +83,13 @@ func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 
 	onTransactionConfirmedClosure = events.NewClosure(onTransactionConfirmed)
+	onMessageStoredClosure = events.NewClosure(onMessageStored)
 	onPledgeEventClosure = events.NewClosure(logPledgeEvent)
 	onRevokeEventClosure = events.NewClosure(logRevokeEvent)
+	onActiveManaChangedClosure = events.NewClosure(onActiveNodesChanged)
 
 	allowedPledgeNodes = make(map[mana.Type]AllowedPledge)
+	activityTracker = mana.NewActivityTracker(config.Node().Duration(CfgActivityWindow))
 	baseManaVectors = make(map[mana.Type]mana.BaseManaVector)
 	baseManaVectors[mana.AccessMana], _ = mana.NewBaseManaVector(mana.AccessMana)
 	baseManaVectors[mana.ConsensusMana], _ = mana.NewBaseManaVector(mana.ConsensusMana)
@@ -80,22 +98,27 @@ func configure(*node.Plugin) {
 		baseManaVectors[mana.ResearchConsensus], _ = mana.NewResearchBaseManaVector(mana.WeightedMana, mana.ConsensusMana, mana.Mixed)
 	}
 
-	// configure storage for each vector type
-	storages = make(map[mana.Type]*objectstorage.ObjectStorage)
+	// mana calculation coefficients can be set from config
+	mana.SetCoefficients(config.Node().Float64(CfgEmaCoefficient1), config.Node().Float64(CfgEmaCoefficient2), config.Node().Float64(CfgDecay))
+	if err := configureManaFunctions(); err != nil {
+		log.Panic(err.Error())
+	}
+
+	// configure storage for the vectors
 	store := database.Store()
-	osFactory = objectstorage.NewFactory(store, db_pkg.PrefixMana)
-	storages[mana.AccessMana] = osFactory.New(mana.PrefixAccess, mana.FromObjectStorage)
-	storages[mana.ConsensusMana] = osFactory.New(mana.PrefixConsensus, mana.FromObjectStorage)
-	if config.Node().Bool(CfgManaEnableResearchVectors) {
-		storages[mana.ResearchAccess] = osFactory.New(mana.PrefixAccessResearch, mana.FromObjectStorage)
-		storages[mana.ResearchConsensus] = osFactory.New(mana.PrefixConsensusResearch, mana.FromObjectStorage)
+	var err error
+	if vectorStorage, err = mana.NewVectorStorage(store.WithRealm([]byte{db_pkg.PrefixMana})); err != nil {
+		log.Panicf("failed to open mana vector storage: %s", err)
 	}
+
+	osFactory = objectstorage.NewFactory(store, db_pkg.PrefixMana)
 	consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
 	consensusEventsLogsStorageSize.Store(getConsensusEventLogsStorageSize())
+	accessEventsLogStorage = osFactory.New(mana.PrefixAccessEventStorage, mana.FromEventObjectStorage)
 	consensusBaseManaPastVectorStorage = osFactory.New(mana.PrefixConsensusPastVector, mana.FromObjectStorage)
 	consensusBaseManaPastVectorMetadataStorage = osFactory.New(mana.PrefixConsensusPastMetadata, mana.FromMetadataObjectStorage)
 
-	err := verifyPledgeNodes()
+	err = verifyPledgeNodes()
 	if err != nil {
 		log.Panic(err.Error())
 	}
@@ -103,29 +126,72 @@ func configure(*node.Plugin) {
 	debuggingEnabled = config.Node().Bool(CfgDebuggingEnabled)
 
 	configureEvents()
+
+	// the vectors need to be restored before any new transaction is booked
+	restoreManaVectors()
+}
+
+// configureManaFunctions sets the mana functions of the access and consensus mana that are defined in the config.
+func configureManaFunctions() error {
+	for manaType, manaFunctionName := range map[mana.Type]string{
+		mana.AccessMana:    config.Node().String(CfgAccessManaFunction),
+		mana.ConsensusMana: config.Node().String(CfgConsensusManaFunction),
+	} {
+		manaFunction, err := mana.NewManaFunction(manaFunctionName, manaType)
+		if err != nil {
+			return err
+		}
+		if err = mana.SetManaFunction(manaType, manaFunction); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func configureEvents() {
 	// until we have the proper event...
 	messagelayer.Tangle().ConsensusManager.Events.TransactionConfirmed.Attach(onTransactionConfirmedClosure)
+	messagelayer.Tangle().Storage.Events.MessageStored.Attach(onMessageStoredClosure)
 	mana.Events().Pledged.Attach(onPledgeEventClosure)
 	mana.Events().Revoked.Attach(onRevokeEventClosure)
+	activityTracker.Events.NodeJoined.Attach(onActiveManaChangedClosure)
+	activityTracker.Events.NodeLeft.Attach(onActiveManaChangedClosure)
+	messagelayer.Tangle().Scheduler.SetAccessManaRetriever(accessManaRetriever)
+	messagelayer.Tangle().ApprovalWeightManager.SetConsensusManaRetriever(activeConsensusManaRetriever)
+	messagelayer.SetConsensusManaRetriever(consensusManaMapRetriever)
 }
 
 func logPledgeEvent(ev *mana.PledgedEvent) {
-	if ev.ManaType == mana.ConsensusMana {
+	switch ev.ManaType {
+	case mana.ConsensusMana:
+		activeManaChanged.Store(true)
 		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
 		consensusEventsLogsStorageSize.Inc()
+	case mana.AccessMana:
+		accessEventsLogStorage.Store(ev.ToPersistable()).Release()
 	}
 }
 
 func logRevokeEvent(ev *mana.RevokedEvent) {
 	if ev.ManaType == mana.ConsensusMana {
+		activeManaChanged.Store(true)
 		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
 		consensusEventsLogsStorageSize.Inc()
 	}
 }
 
+// onActiveNodesChanged marks the consensus mana of the active nodes as changed when a node joins or leaves them.
+func onActiveNodesChanged(*mana.ActivityEvent) {
+	activeManaChanged.Store(true)
+}
+
+// onMessageStored marks the issuer of the given message as active.
+func onMessageStored(messageID tangle.MessageID) {
+	messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		activityTracker.Update(identity.NewID(message.IssuerPublicKey()), message.IssuingTime(), clock.SyncedTime())
+	})
+}
+
 func onTransactionConfirmed(msgID tangle.MessageID) {
 	var tx *ledgerstate.Transaction
 	isTx := false
@@ -146,100 +212,82 @@ func onTransactionConfirmed(msgID tangle.MessageID) {
 		return
 	}
 
-	// holds all info mana pkg needs for correct mana calculations from the transaction
-	var txInfo *mana.TxInfo
-	// process transaction object to build txInfo
-	var totalAmount float64
-	var inputInfos []mana.InputInfo
-
-	// iterate over all inputs within the transaction
-	for _, input := range tx.Essence().Inputs() {
-		i := input.(*ledgerstate.UTXOInput)
-
-		var amount float64
-		var inputTimestamp time.Time
-		var accessManaNodeID identity.ID
-		var consensusManaNodeID identity.ID
-		var _inputInfo mana.InputInfo
-
-		messagelayer.Tangle().LedgerState.Output(i.ReferencedOutputID()).Consume(func(o ledgerstate.Output) {
-			// first, sum balances of the input, calculate total amount as well for later
-			o.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
-				amount += float64(balance)
-				totalAmount += amount
-				return true
-			})
-			// derive the transaction that created this input
-			inputTxID := o.ID().TransactionID()
-			// look into the transaction, we need timestamp and access & consensus pledge IDs
-			messagelayer.Tangle().LedgerState.Transaction(inputTxID).Consume(func(transaction *ledgerstate.Transaction) {
-				if transaction != nil {
-					inputTimestamp = transaction.Essence().Timestamp()
-					accessManaNodeID = transaction.Essence().AccessPledgeID()
-					consensusManaNodeID = transaction.Essence().ConsensusPledgeID()
-				}
-			})
-			// build InputInfo for this particular input in the transaction
-			_inputInfo = mana.InputInfo{
-				TimeStamp: inputTimestamp,
-				Amount:    amount,
-				PledgeID: map[mana.Type]identity.ID{
-					mana.AccessMana:    accessManaNodeID,
-					mana.ConsensusMana: consensusManaNodeID,
-				},
-				InputID: o.ID(),
-			}
-		})
-		inputInfos = append(inputInfos, _inputInfo)
-	}
+	bookTransaction(tx)
+}
+
+// bookTransaction logs the given confirmed transaction and books it into all mana vectors.
+func bookTransaction(tx *ledgerstate.Transaction) {
+	bookingMutex.Lock()
+	defer bookingMutex.Unlock()
+
+	logAndBookTransaction(tx)
+}
 
-	txInfo = &mana.TxInfo{
-		TimeStamp:     tx.Essence().Timestamp(),
-		TransactionID: tx.ID(),
-		TotalBalance:  totalAmount,
-		PledgeID: map[mana.Type]identity.ID{
-			mana.AccessMana:    tx.Essence().AccessPledgeID(),
-			mana.ConsensusMana: tx.Essence().ConsensusPledgeID(),
-		},
-		InputInfos: inputInfos,
+// logAndBookTransaction logs the given confirmed transaction and books it into all mana vectors (the bookingMutex needs
+// to be held by the caller).
+func logAndBookTransaction(tx *ledgerstate.Transaction) {
+	index, err := vectorStorage.LogBookedTransaction(tx.ID())
+	if err != nil {
+		log.Errorf("failed to log booked transaction: %w", err)
 	}
+
+	// holds all info mana pkg needs for correct mana calculations from the transaction
+	txInfo := mana.NewTxInfo(tx, messagelayer.Tangle().LedgerState)
 	// book in all mana vectors.
 	for _, baseManaVector := range baseManaVectors {
 		baseManaVector.Book(txInfo)
 	}
+
+	if err == nil {
+		lastBookedIndex = index
+		lastBookedTransactionID = tx.ID()
+	}
 }
 
 func run(_ *node.Plugin) {
-	// mana calculation coefficients can be set from config
-	ema1 := config.Node().Float64(CfgEmaCoefficient1)
-	ema2 := config.Node().Float64(CfgEmaCoefficient2)
-	dec := config.Node().Float64(CfgDecay)
 	pruneInterval := config.Node().Duration(CfgPruneConsensusEventLogsInterval)
 	vectorsCleanUpInterval := config.Node().Duration(CfgVectorsCleanupInterval)
+	checkpointInterval := config.Node().Duration(CfgVectorsCheckpointInterval)
 
-	mana.SetCoefficients(ema1, ema2, dec)
 	if err := daemon.BackgroundWorker("Mana", func(shutdownSignal <-chan struct{}) {
 		defer log.Infof("Stopping %s ... done", PluginName)
 		ticker := time.NewTicker(pruneInterval)
 		defer ticker.Stop()
 		cleanupTicker := time.NewTicker(vectorsCleanUpInterval)
 		defer cleanupTicker.Stop()
-		readStoredManaVectors()
-		pruneStorages()
+		checkpointTicker := time.NewTicker(checkpointInterval)
+		defer checkpointTicker.Stop()
+		activityTicker := time.NewTicker(activityPruningInterval)
+		defer activityTicker.Stop()
+		reevaluationTicker := time.NewTicker(confirmationReevaluationInterval)
+		defer reevaluationTicker.Stop()
 		for {
 			select {
 			case <-shutdownSignal:
 				log.Infof("Stopping %s ...", PluginName)
 				mana.Events().Pledged.Detach(onPledgeEventClosure)
-				mana.Events().Pledged.Detach(onRevokeEventClosure)
+				mana.Events().Revoked.Detach(onRevokeEventClosure)
+				activityTracker.Events.NodeJoined.Detach(onActiveManaChangedClosure)
+				activityTracker.Events.NodeLeft.Detach(onActiveManaChangedClosure)
 				messagelayer.Tangle().ConsensusManager.Events.TransactionConfirmed.Detach(onTransactionConfirmedClosure)
-				storeManaVectors()
+				messagelayer.Tangle().Storage.Events.MessageStored.Detach(onMessageStoredClosure)
+				writeCheckpoint()
 				shutdownStorages()
 				return
 			case <-ticker.C:
 				pruneConsensusEventLogsStorage()
+				pruneAccessEventLogsStorage()
 			case <-cleanupTicker.C:
 				cleanupManaVectors()
+			case <-checkpointTicker.C:
+				writeCheckpoint()
+			case <-activityTicker.C:
+				activityTracker.Prune(clock.SyncedTime())
+			case <-reevaluationTicker.C:
+				// Markers and Branches can reach the threshold without new supporters if the active mana changes
+				if activeManaChanged.CAS(true, false) {
+					messagelayer.Tangle().ApprovalWeightManager.ReevaluateConfirmations()
+				}
 			}
 		}
 	}, shutdown.PriorityMana); err != nil {
@@ -247,41 +295,128 @@ func run(_ *node.Plugin) {
 	}
 }
 
-func readStoredManaVectors() {
-	for vectorType := range baseManaVectors {
-		storages[vectorType].ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-			cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-			cachedPbm.Consume(func(p *mana.PersistableBaseMana) {
-				err := baseManaVectors[vectorType].FromPersistable(p)
-				if err != nil {
-					log.Errorf("error while restoring %s mana vector: %w", vectorType.String(), err)
-				}
-			})
-			return true
-		})
+// restoreManaVectors loads the mana vectors of the last checkpoint and replays the confirmed transactions that were
+// booked after it.
+func restoreManaVectors() {
+	bookingMutex.Lock()
+	defer bookingMutex.Unlock()
+
+	checkpoint, err := vectorStorage.Checkpoint()
+	if err != nil {
+		log.Errorf("error while loading mana checkpoint: %w", err)
+		return
 	}
-}
+	// the persisted vectors can not be updated with different mana functions than they were computed with
+	if !checkpoint.Timestamp.IsZero() {
+		accessManaFunction := mana.GetManaFunction(mana.AccessMana).Name()
+		consensusManaFunction := mana.GetManaFunction(mana.ConsensusMana).Name()
+		if checkpoint.AccessManaFunction != accessManaFunction || checkpoint.ConsensusManaFunction != consensusManaFunction {
+			log.Panicf("mana vectors were computed with the %s access and %s consensus mana functions, but %s and %s are configured: rebuild them with the mana-rebuild tool or delete the database",
+				checkpoint.AccessManaFunction, checkpoint.ConsensusManaFunction, accessManaFunction, consensusManaFunction)
+		}
+	}
+	if err = vectorStorage.LoadVectors(baseManaVectors); err != nil {
+		log.Errorf("error while restoring mana vectors: %w", err)
+		return
+	}
+	lastBookedIndex = checkpoint.LastBookedIndex
+	lastBookedTransactionID = checkpoint.LastBookedTransactionID
 
-func storeManaVectors() {
-	for vectorType, baseManaVector := range baseManaVectors {
-		persitables := baseManaVector.ToPersistables()
-		for _, p := range persitables {
-			storages[vectorType].Store(p).Release()
+	// a node without any persisted mana starts from the initial mana of the genesis snapshot
+	seeded := checkpoint.Timestamp.IsZero() && baseManaVectors[mana.AccessMana].Size() == 0 && baseManaVectors[mana.ConsensusMana].Size() == 0
+	if seeded {
+		manaPledges := messagelayer.SnapshotManaPledges()
+		mana.LoadSnapshotPledges(baseManaVectors, manaPledges)
+		log.Infof("seeded mana vectors with %d mana pledges of the snapshot", len(manaPledges))
+	}
+
+	// the events of the replayed transactions were logged when they were booked for the first time
+	mana.Events().Pledged.Detach(onPledgeEventClosure)
+	mana.Events().Revoked.Detach(onRevokeEventClosure)
+
+	loggedTransactions := make(map[ledgerstate.TransactionID]types.Empty)
+	replayedTransactions := 0
+	if err = vectorStorage.ForEachBookedTransaction(checkpoint.LastBookedIndex, func(index uint64, transactionID ledgerstate.TransactionID) bool {
+		if !messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+			txInfo := mana.NewTxInfo(transaction, messagelayer.Tangle().LedgerState)
+			for _, baseManaVector := range baseManaVectors {
+				baseManaVector.Book(txInfo)
+			}
+			replayedTransactions++
+		}) {
+			log.Errorf("failed to replay booked transaction with %s: transaction not found", transactionID)
 		}
+
+		loggedTransactions[transactionID] = types.Void
+		lastBookedIndex = index
+		lastBookedTransactionID = transactionID
+		return true
+	}); err != nil {
+		log.Errorf("error while replaying booked transactions: %w", err)
+	}
+
+	mana.Events().Pledged.Attach(onPledgeEventClosure)
+	mana.Events().Revoked.Attach(onRevokeEventClosure)
+
+	// transactions that were confirmed while the node crashed before it logged them are booked like new confirmations
+	// (vectors that were persisted without a checkpoint might already contain all of them)
+	var missedTransactions []*ledgerstate.Transaction
+	if !checkpoint.Timestamp.IsZero() || seeded {
+		missedTransactions = missedConfirmedTransactions(checkpoint.Timestamp, loggedTransactions)
 	}
+	for _, transaction := range missedTransactions {
+		logAndBookTransaction(transaction)
+	}
+
+	log.Infof("restored mana vectors from checkpoint %d, replayed %d logged and booked %d missed confirmed transactions", checkpoint.LastBookedIndex, replayedTransactions, len(missedTransactions))
+}
+
+// missedConfirmedTransactions returns the confirmed transactions of the ledger state that are newer than the given
+// checkpoint time and that are not part of the given logged transactions, in the order of their timestamps.
+func missedConfirmedTransactions(checkpointTime time.Time, loggedTransactions map[ledgerstate.TransactionID]types.Empty) (transactions []*ledgerstate.Transaction) {
+	messagelayer.Tangle().LedgerState.ForEachTransaction(func(transaction *ledgerstate.Transaction) {
+		if !transaction.Essence().Timestamp().After(checkpointTime) {
+			return
+		}
+		if _, logged := loggedTransactions[transaction.ID()]; logged {
+			return
+		}
+		if inclusionState, err := messagelayer.Tangle().LedgerState.TransactionInclusionState(transaction.ID()); err != nil || inclusionState != ledgerstate.Confirmed {
+			return
+		}
+
+		transactions = append(transactions, transaction)
+	})
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].Essence().Timestamp().Equal(transactions[j].Essence().Timestamp()) {
+			return transactions[i].Essence().Timestamp().Before(transactions[j].Essence().Timestamp())
+		}
+		return bytes.Compare(transactions[i].ID().Bytes(), transactions[j].ID().Bytes()) < 0
+	})
+
+	return transactions
 }
 
-func pruneStorages() {
-	for vectorType := range baseManaVectors {
-		_ = storages[vectorType].Prune()
+// writeCheckpoint persists the mana vectors together with the last booked transaction.
+func writeCheckpoint() {
+	bookingMutex.Lock()
+	defer bookingMutex.Unlock()
+
+	if err := vectorStorage.WriteCheckpoint(baseManaVectors, &mana.Checkpoint{
+		LastBookedIndex:         lastBookedIndex,
+		LastBookedTransactionID: lastBookedTransactionID,
+		Timestamp:               time.Now(),
+		AccessManaFunction:      mana.GetManaFunction(mana.AccessMana).Name(),
+		ConsensusManaFunction:   mana.GetManaFunction(mana.ConsensusMana).Name(),
+	}); err != nil {
+		log.Errorf("error while writing mana checkpoint: %w", err)
 	}
 }
 
 func shutdownStorages() {
-	for vectorType := range baseManaVectors {
-		storages[vectorType].Shutdown()
-	}
 	consensusEventsLogStorage.Shutdown()
+	accessEventsLogStorage.Shutdown()
 	consensusBaseManaPastVectorStorage.Shutdown()
 	consensusBaseManaPastVectorMetadataStorage.Shutdown()
 }
@@ -313,6 +448,31 @@ func GetAccessMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (float64
 	return baseManaVectors[mana.AccessMana].GetMana(nodeID, optionalUpdateTime...)
 }
 
+// accessManaRetriever returns the access mana of the given node for the Scheduler (0 if it can not be retrieved).
+func accessManaRetriever(nodeID identity.ID) float64 {
+	accessMana, _, err := GetAccessMana(nodeID)
+	if err != nil {
+		return 0
+	}
+	return accessMana
+}
+
+// activeConsensusManaRetriever returns the consensus mana of the active nodes for the ApprovalWeightManager (nil if it
+// can not be retrieved).
+func activeConsensusManaRetriever() map[identity.ID]float64 {
+	activeConsensusManaMap, _, err := ActiveConsensusManaVector()
+	if err != nil {
+		return nil
+	}
+	return activeConsensusManaMap
+}
+
+// consensusManaMapRetriever returns the consensus mana of all nodes for the weighting of the FPC opinion givers.
+func consensusManaMapRetriever() (map[identity.ID]float64, error) {
+	consensusManaMap, _, err := GetManaMap(mana.ConsensusMana)
+	return consensusManaMap, err
+}
+
 // GetConsensusMana returns the consensus mana of the node specified.
 func GetConsensusMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
 	if !QueryAllowed() {
@@ -410,6 +570,43 @@ func GetOnlineNodes(manaType mana.Type) (onlineNodesMana []mana.Node, t time.Tim
 	return
 }
 
+// ActiveConsensusManaVector returns the consensus mana of the nodes that issued a message within the activity window.
+func ActiveConsensusManaVector() (mana.NodeMap, time.Time, error) {
+	if !QueryAllowed() {
+		return mana.NodeMap{}, time.Now(), ErrQueryNotAllowed
+	}
+	consensusManaMap, t, err := baseManaVectors[mana.ConsensusMana].GetManaMap()
+	if err != nil {
+		return mana.NodeMap{}, t, err
+	}
+
+	activeConsensusManaMap := make(mana.NodeMap)
+	for _, nodeID := range activityTracker.ActiveNodes() {
+		if consensusMana, exists := consensusManaMap[nodeID]; exists && consensusMana > 0 {
+			activeConsensusManaMap[nodeID] = consensusMana
+		}
+	}
+	return activeConsensusManaMap, t, nil
+}
+
+// TotalActiveConsensusMana returns the sum of the consensus mana of the nodes that issued a message within the activity
+// window.
+func TotalActiveConsensusMana() (totalActiveConsensusMana float64, t time.Time, err error) {
+	activeConsensusManaMap, t, err := ActiveConsensusManaVector()
+	if err != nil {
+		return 0, t, err
+	}
+	for _, consensusMana := range activeConsensusManaMap {
+		totalActiveConsensusMana += consensusMana
+	}
+	return totalActiveConsensusMana, t, nil
+}
+
+// ActivityEvents returns the events that are triggered when nodes join or leave the set of active nodes.
+func ActivityEvents() *mana.ActivityTrackerEvents {
+	return activityTracker.Events
+}
+
 func verifyPledgeNodes() error {
 	access := AllowedPledge{
 		IsFilterEnabled: config.Node().Bool(CfgAllowedAccessFilterEnabled),
@@ -477,7 +674,7 @@ func PendingManaOnOutput(outputID ledgerstate.OutputID) (float64, time.Time) {
 
 // GetPendingMana returns the mana pledged by spending a `value` output that sat for `n` duration.
 func GetPendingMana(value float64, n time.Duration) float64 {
-	return value * (1 - math.Pow(math.E, -mana.Decay*(n.Seconds())))
+	return mana.GetManaFunction(mana.AccessMana).Accrue(value, n)
 }
 
 // GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
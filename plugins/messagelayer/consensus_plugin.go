@@ -8,16 +8,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/hive.go/autopeering/discover"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/drng"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/metrics"
 	"github.com/iotaledger/goshimmer/packages/prng"
@@ -45,6 +46,15 @@ var (
 	voterServer         *votenet.VoterServer
 	registry            *statement.Registry
 	registryOnce        sync.Once
+
+	// consensusManaRetriever is used to retrieve the consensus mana of the opinion givers.
+	consensusManaRetriever = func() (map[identity.ID]float64, error) { return nil, nil }
+
+	// drngInstance is the dRNG whose collective beacons provide the randomness of the FPC rounds.
+	drngInstance *drng.DRNG
+
+	connectionPool     *votenet.ConnectionPool
+	connectionPoolOnce sync.Once
 )
 
 // ConsensusPlugin returns the consensus plugin.
@@ -60,9 +70,10 @@ func configureConsensusPlugin(plugin *node.Plugin) {
 	configureFPC(plugin)
 
 	// subscribe to FCOB events
-	ConsensusMechanism().Events.Vote.Attach(events.NewClosure(func(id string, initOpn opinion.Opinion) {
-		if err := Voter().Vote(id, vote.ConflictType, initOpn); err != nil {
+	ConsensusMechanism().Events.Vote.Attach(events.NewClosure(func(id string, objectType vote.ObjectType, initOpn opinion.Opinion) {
+		if err := Voter().Vote(id, objectType, initOpn); err != nil {
 			plugin.LogWarnf("FPC vote: %s", err)
+			ConsensusMechanism().ProcessVoteFailure(&vote.OpinionEvent{ID: id, Opinion: initOpn, Ctx: vote.Context{Type: objectType}})
 		}
 	}))
 	ConsensusMechanism().Events.Error.Attach(events.NewClosure(func(err error) {
@@ -80,11 +91,27 @@ func runConsensusPlugin(plugin *node.Plugin) {
 // Voter returns the DRNGRoundBasedVoter instance used by the FPC plugin.
 func Voter() vote.DRNGRoundBasedVoter {
 	voterOnce.Do(func() {
-		voter = fpc.New(OpinionGiverFunc)
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = FPCParameters.QuerySampleSize
+		paras.MaxOpinionGiverWeight = FPCParameters.MaxOpinionGiverWeight
+		voter = fpc.New(OpinionGiverFunc, paras)
 	})
 	return voter
 }
 
+// ConnectionPool returns the pool of the connections that are used to query the opinions of other peers.
+func ConnectionPool() *votenet.ConnectionPool {
+	connectionPoolOnce.Do(func() {
+		connectionPool = votenet.NewConnectionPool(votenet.IdleTimeout(time.Duration(FPCParameters.ConnectionIdleTimeout) * time.Second))
+	})
+	return connectionPool
+}
+
+// SetDRNG sets the dRNG instance whose collective beacons provide the randomness of the FPC rounds.
+func SetDRNG(instance *drng.DRNG) {
+	drngInstance = instance
+}
+
 // Registry returns the registry.
 func Registry() *statement.Registry {
 	registryOnce.Do(func() {
@@ -110,25 +137,37 @@ func configureFPC(plugin *node.Plugin) {
 		}
 	}
 
+	// close the pooled connections of the peers that were removed by the autopeering
+	discovery.Discovery().Events().PeerDeleted.Attach(events.NewClosure(func(ev *discover.DeletedEvent) {
+		ConnectionPool().Evict(ev.Peer.ID())
+	}))
+
 	Voter().Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
 		if StatementParameters.WriteStatement {
 			makeStatement(roundStats)
 		}
 		peersQueried := len(roundStats.QueriedOpinions)
 		voteContextsCount := len(roundStats.ActiveVoteContexts)
-		plugin.LogDebugf("executed round with rand %0.4f for %d vote contexts on %d peers, took %v", roundStats.RandUsed, voteContextsCount, peersQueried, roundStats.Duration)
+		plugin.LogDebugf("executed round with rand %0.4f (%s) for %d vote contexts on %d peers, took %v", roundStats.RandUsed, roundStats.RandSource, voteContextsCount, peersQueried, roundStats.Duration)
 	}))
 
 	Voter().Events().Finalized.Attach(events.NewClosure(ConsensusMechanism().ProcessVote))
 	Voter().Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
-		if ev.Ctx.Type == vote.ConflictType {
+		switch ev.Ctx.Type {
+		case vote.ConflictType:
 			plugin.LogInfof("FPC finalized for transaction with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
+		case vote.TimestampType:
+			plugin.LogInfof("FPC finalized for timestamp of message with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
 		}
 	}))
 
+	Voter().Events().Failed.Attach(events.NewClosure(ConsensusMechanism().ProcessVoteFailure))
 	Voter().Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
-		if ev.Ctx.Type == vote.ConflictType {
+		switch ev.Ctx.Type {
+		case vote.ConflictType:
 			plugin.LogWarnf("FPC failed for transaction with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
+		case vote.TimestampType:
+			plugin.LogWarnf("FPC failed for timestamp of message with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
 		}
 	}))
 }
@@ -174,11 +213,59 @@ func runFPC(plugin *node.Plugin) {
 		unixTsPRNG := prng.NewUnixTimestampPRNG(FPCParameters.RoundInterval)
 		unixTsPRNG.Start()
 		defer unixTsPRNG.Stop()
+		defer ConnectionPool().Shutdown()
+
+		// the rounds are driven by the round interval and the dRNG beacons only provide their randomness
+		var latestBeacon struct {
+			sync.Mutex
+			randomness float64
+			received   time.Time
+		}
+		drngEnabled := drngInstance != nil && FPCParameters.DRNGInstanceID != 0
+		if drngEnabled {
+			onRandomness := events.NewClosure(func(state *drng.State) {
+				if state.Committee().InstanceID != uint32(FPCParameters.DRNGInstanceID) || len(state.Randomness().Randomness) < 8 {
+					return
+				}
+
+				latestBeacon.Lock()
+				defer latestBeacon.Unlock()
+				latestBeacon.randomness = state.Randomness().Float64()
+				latestBeacon.received = time.Now()
+			})
+			drngInstance.Events.Randomness.Attach(onRandomness)
+			defer drngInstance.Events.Randomness.Detach(onRandomness)
+		} else {
+			plugin.LogInfof("no dRNG instance configured - FPC rounds use the Unix timestamp PRNG")
+		}
+
+		beaconTimeout := time.Duration(FPCParameters.DRNGBeaconTimeout) * time.Second
+		usingFallback := false
 	exit:
 		for {
 			select {
 			case r := <-unixTsPRNG.C():
-				if err := voter.Round(r); err != nil {
+				randomness, randomnessSource := r, vote.UnixTimestampRandomness
+				if drngEnabled {
+					latestBeacon.Lock()
+					beaconRandomness, beaconReceived := latestBeacon.randomness, latestBeacon.received
+					latestBeacon.Unlock()
+
+					// the Unix timestamp PRNG is only used as a fallback if the dRNG beacons stop arriving
+					switch {
+					case time.Since(beaconReceived) < beaconTimeout:
+						if usingFallback {
+							plugin.LogInfof("received dRNG beacon - FPC rounds use the dRNG randomness again")
+							usingFallback = false
+						}
+						randomness, randomnessSource = beaconRandomness, vote.DRNGRandomness
+					case !usingFallback:
+						plugin.LogWarnf("no dRNG beacon received for %v - FPC rounds fall back to the Unix timestamp PRNG", beaconTimeout)
+						usingFallback = true
+					}
+				}
+
+				if err := voter.Round(randomness, randomnessSource); err != nil {
 					plugin.LogWarnf("unable to execute FPC round: %s", err)
 				}
 			case <-shutdownSignal:
@@ -215,6 +302,7 @@ func runFPC(plugin *node.Plugin) {
 // OpinionGiver is a wrapper for both statements and peers.
 type OpinionGiver struct {
 	id   identity.ID
+	mana float64
 	view *statement.View
 	pog  *PeerOpinionGiver
 }
@@ -242,7 +330,17 @@ func (o *OpinionGiver) ID() identity.ID {
 	return o.id
 }
 
-// OpinionGiverFunc returns a slice of opinion givers.
+// Mana returns the consensus mana of the underlying Peer.
+func (o *OpinionGiver) Mana() float64 {
+	return o.mana
+}
+
+// SetConsensusManaRetriever sets the function that is used to retrieve the consensus mana of the opinion givers.
+func SetConsensusManaRetriever(retriever func() (map[identity.ID]float64, error)) {
+	consensusManaRetriever = retriever
+}
+
+// OpinionGiverFunc returns a slice of opinion givers that are weighted by their consensus mana.
 func OpinionGiverFunc() (givers []opinion.OpinionGiver, err error) {
 	opinionGiversMap := make(map[identity.ID]*OpinionGiver)
 	opinionGivers := make([]opinion.OpinionGiver, 0)
@@ -268,7 +366,14 @@ func OpinionGiverFunc() (givers []opinion.OpinionGiver, err error) {
 		opinionGiversMap[p.ID()].pog = &PeerOpinionGiver{p: p}
 	}
 
+	// the opinion givers are sampled uniformly if the consensus mana is not available
+	consensusMana, err := consensusManaRetriever()
+	if err != nil {
+		plugin.LogDebugf("failed to retrieve the consensus mana of the opinion givers: %s", err)
+	}
+
 	for _, v := range opinionGiversMap {
+		v.mana = consensusMana[v.id]
 		opinionGivers = append(opinionGivers, v)
 	}
 
@@ -290,19 +395,9 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, ti
 		return nil, fmt.Errorf("unable to query opinions, PeerOpinionGiver is nil")
 	}
 
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-
-	// connect to the FPC service
-	conn, err := grpc.Dial(pog.Address(), opts...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to connect to FPC service: %w", err)
-	}
-	defer conn.Close()
-
-	client := votenet.NewVoterQueryClient(conn)
 	query := &votenet.QueryRequest{ConflictIDs: conflictIDs, TimestampIDs: timestampIDs}
-	reply, err := client.Opinion(ctx, query)
+	queryStart := time.Now()
+	reply, err := ConnectionPool().Query(ctx, pog.p.ID(), pog.Address(), query)
 	if err != nil {
 		metrics.Events().QueryReplyError.Trigger(&metrics.QueryReplyErrorEvent{
 			ID:           pog.p.ID().String(),
@@ -311,6 +406,11 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, ti
 		return nil, fmt.Errorf("unable to query opinions: %w", err)
 	}
 
+	metrics.Events().QueryReplyReceived.Trigger(&metrics.QueryReplyReceivedEvent{
+		ID:           pog.p.ID().String(),
+		OpinionCount: len(conflictIDs) + len(timestampIDs),
+		Latency:      time.Since(queryStart),
+	})
 	metrics.Events().FPCInboundBytes.Trigger(uint64(proto.Size(reply)))
 	metrics.Events().FPCOutboundBytes.Trigger(uint64(proto.Size(query)))
 
@@ -342,8 +442,19 @@ func (pog *PeerOpinionGiver) Address() string {
 func OpinionRetriever(id string, objectType vote.ObjectType) opinion.Opinion {
 	switch objectType {
 	case vote.TimestampType:
-		// TODO: implement
-		return opinion.Like
+		messageID, err := tangle.NewMessageID(id)
+		if err != nil {
+			plugin.LogErrorf("received invalid vote request for timestamp of message '%s'", id)
+
+			return opinion.Unknown
+		}
+
+		timestampOpinion := ConsensusMechanism().TimestampOpinion(messageID)
+		if timestampOpinion == nil {
+			return opinion.Unknown
+		}
+
+		return timestampOpinion.Value
 	default: // conflict type
 		transactionID, err := ledgerstate.TransactionIDFromBase58(id)
 		if err != nil {
@@ -14,9 +14,95 @@ var Parameters = struct {
 		GenesisNode string `default:"Gm7W191NDnqyF7KJycZqK7V6ENLwqxTwoKQN4SmpkB24" usage:"the node (base58 public key) that is allowed to attach to the genesis message"`
 	}
 
+	// TipSelection contains parameters related to the selection of the strong parents of new messages.
+	TipSelection struct {
+		// Strategy defines the TipSelectionStrategy that is used by the TipManager.
+		Strategy string `default:"uniform" usage:"the tip selection strategy (uniform, young or heaviestBranch)"`
+
+		// MaxTipAge defines the maximum age of the tips that are selected by the young tip selection (in seconds).
+		MaxTipAge int `default:"0" usage:"the maximum age of the tips selected by the young tip selection strategy [s] (0 uses the default)"`
+	}
+
+	// LocalSnapshot contains parameters related to the pruning of the Tangle and the creation of local snapshots.
+	LocalSnapshot struct {
+		// File is the path to the local snapshot file.
+		File string `default:"./localsnapshot.bin" usage:"the path to the local snapshot file (loaded instead of the genesis snapshot if it exists)"`
+
+		// Interval defines the time between two local snapshots (in minutes).
+		Interval int `default:"0" usage:"the interval in which local snapshots are created and the Tangle is pruned [min] (0 disables pruning)"`
+
+		// PruningDelay defines the age of the confirmed messages that are pruned (in minutes).
+		PruningDelay int `default:"1440" usage:"the age of the confirmed messages that are pruned [min]"`
+	}
+
+	// StateCommitment contains parameters related to the commitment to the confirmed unspent outputs.
+	StateCommitment struct {
+		// EpochInterval defines the length of the epochs at whose end the state root is committed (in seconds).
+		EpochInterval int `default:"60" usage:"the length of the epochs at whose end the ledger state root is committed [s] (0 disables epoch commitments)"`
+
+		// CommitDelay defines the time after the end of an epoch until its state root is committed (in seconds). It should
+		// exceed the time it takes to confirm the transactions of the epoch, as later confirmed transactions revise the
+		// already published commitment of their epoch.
+		CommitDelay int `default:"60" usage:"the time after the end of an epoch until its ledger state root is committed [s]"`
+	}
+
+	// AddressHistory contains parameters related to the index of the transactions that credit or debit an address.
+	AddressHistory struct {
+		// Enabled defines if the transaction history of addresses is indexed.
+		Enabled bool `default:"false" usage:"if the transactions that credit or debit an address are indexed"`
+	}
+
+	// DustProtection contains parameters related to the rules that prevent the ledger state from being bloated with dust.
+	DustProtection struct {
+		// MinDeposit defines the minimum amount of tokens that every output needs to hold.
+		MinDeposit int `default:"1" usage:"the minimum amount of tokens that every output needs to hold (0 disables the check)"`
+
+		// DustThreshold defines the amount of tokens below which an output is considered to be dust. Transactions that
+		// create dust outputs without sending the dust allowance for them to the same address are invalid.
+		DustThreshold int `default:"10" usage:"the amount of tokens below which an output is considered to be dust (0 disables the dust limit per address)"`
+
+		// DustAllowanceDivisor defines how many tokens in non-dust outputs an address needs to hold per dust output.
+		DustAllowanceDivisor int `default:"100000" usage:"the amount of tokens in non-dust outputs that an address needs to hold per owned dust output"`
+
+		// MaxDustOutputsPerAddress defines the maximum number of dust outputs per address.
+		MaxDustOutputsPerAddress int `default:"100" usage:"the maximum number of dust outputs that an address can own (0 means no upper bound)"`
+	}
+
+	// BranchPruning contains parameters related to the removal of rejected branches from the ledger state.
+	BranchPruning struct {
+		// Delay defines the time after which a rejected branch and its transactions are pruned (in minutes).
+		Delay int `default:"0" usage:"the time after which rejected branches and their transactions are pruned [min] (0 disables pruning)"`
+	}
+
+	// Scheduler contains parameters related to the congestion control of the Scheduler.
+	Scheduler struct {
+		// Rate defines the minimum time between two scheduled messages (in milliseconds).
+		Rate int `default:"5" usage:"the minimum time between two scheduled messages [ms] (0 means no rate limit)"`
+
+		// MaxQueueSize defines the maximum number of messages that are buffered per issuer.
+		MaxQueueSize int `default:"1000" usage:"the maximum number of messages that are buffered per issuer"`
+
+		// MaxBufferTime defines the maximum time that a message waits in the buffer for its parents to be booked (in
+		// seconds).
+		MaxBufferTime int `default:"300" usage:"the maximum time that a message waits in the buffer for its parents to be booked [s]"`
+	}
+
+	// ApprovalWeight contains parameters related to the approval weight based confirmation.
+	ApprovalWeight struct {
+		// ConfirmationThreshold defines the share of the active consensus mana that needs to support a message.
+		ConfirmationThreshold float64 `default:"0.66" usage:"the share of the active consensus mana that needs to support a message or branch to confirm it"`
+	}
+
 	// FCOB contains parameters related to the fast consensus of barcelona.
 	FCOB struct {
 		AverageNetworkDelay int `default:"5" usage:"the avg. network delay to use for FCoB rules"`
+
+		// TimestampWindow defines the maximum difference between the issuing and the arrival time of a message that
+		// is considered honest (in seconds).
+		TimestampWindow int `default:"60" usage:"the time window in which the timestamp of a message is considered honest [s]"`
+
+		// GratuitousNetworkDelay defines the time after which all messages are assumed to be delivered (in seconds).
+		GratuitousNetworkDelay int `default:"15" usage:"the time after which all messages are assumed to be delivered [s]"`
 	}
 }{}
 
@@ -31,8 +117,21 @@ var FPCParameters = struct {
 	// RoundInterval defines how long a round lasts (in seconds).
 	RoundInterval int64 `default:"10" usage:"FPC round interval [s]"`
 
+	// DRNGInstanceID defines the dRNG committee whose collective beacons provide the randomness of the FPC rounds.
+	DRNGInstanceID int `default:"1" usage:"the instance ID of the dRNG that provides the randomness of the FPC rounds (1 = Pollen, 1339 = X-Team, 7438 = Community, 0 = Unix timestamp PRNG only)"`
+
+	// DRNGBeaconTimeout defines the time without dRNG beacons after which the Unix timestamp PRNG is used (in seconds).
+	DRNGBeaconTimeout int `default:"30" usage:"the time without dRNG beacons after which FPC falls back to the Unix timestamp PRNG [s]"`
+
 	// QuerySampleSize defines how many nodes will be queried each round.
 	QuerySampleSize int `default:"21" usage:"Size of the voting quorum (k)"`
+
+	// ConnectionIdleTimeout defines the time after which unused connections to other FPC services are closed (in seconds).
+	ConnectionIdleTimeout int `default:"60" usage:"the time after which unused connections to other FPC services are closed [s]"`
+
+	// MaxOpinionGiverWeight defines the maximum share of the total consensus mana that is taken into account for a
+	// single opinion giver.
+	MaxOpinionGiverWeight float64 `default:"0" usage:"the max share of the total consensus mana of a single opinion giver (0 disables the cap)"`
 }{}
 
 // StatementParameters contains the configuration parameters used by the FPC statements in the tangle.
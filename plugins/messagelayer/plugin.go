@@ -1,7 +1,9 @@
 package messagelayer
 
 import (
+	"bufio"
 	"errors"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -12,10 +14,12 @@ import (
 	"github.com/labstack/gommon/log"
 	"golang.org/x/xerrors"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/database"
 )
@@ -23,6 +27,15 @@ import (
 const (
 	// DefaultAverageNetworkDelay contains the default average time it takes for a network to propagate through gossip.
 	DefaultAverageNetworkDelay = 5 * time.Second
+
+	// UniformTipSelection is the name of the tip selection strategy that selects tips uniformly at random.
+	UniformTipSelection = "uniform"
+
+	// YoungTipSelection is the name of the tip selection strategy that prefers young tips.
+	YoungTipSelection = "young"
+
+	// HeaviestBranchTipSelection is the name of the tip selection strategy that selects tips from the heaviest branch.
+	HeaviestBranchTipSelection = "heaviestBranch"
 )
 
 // ErrMessageWasNotBookedInTime is returned if a message did not get booked
@@ -32,8 +45,9 @@ var ErrMessageWasNotBookedInTime = errors.New("message could not be booked in ti
 // region Plugin ///////////////////////////////////////////////////////////////////////////////////////////////////////
 
 var (
-	plugin     *node.Plugin
-	pluginOnce sync.Once
+	plugin              *node.Plugin
+	pluginOnce          sync.Once
+	snapshotManaPledges []*ledgerstate.ManaPledge
 )
 
 // Plugin gets the plugin instance.
@@ -60,22 +74,47 @@ func configure(plugin *node.Plugin) {
 		Tangle().ProcessGossipMessage(message.Bytes(), local.GetInstance().Peer)
 	}))
 
-	// read snapshot file
-	if Parameters.Snapshot.File != "" {
-		snapshot := ledgerstate.Snapshot{}
+	// read local snapshot file or fall back to the genesis snapshot
+	if _, err := os.Stat(Parameters.LocalSnapshot.File); err == nil {
+		snapshot := tangle.NewLocalSnapshot()
+		f, err := os.Open(Parameters.LocalSnapshot.File)
+		if err != nil {
+			plugin.Panic("can not open local snapshot file:", err)
+		}
+		if _, err := snapshot.ReadFrom(f); err != nil {
+			plugin.Panic("could not read local snapshot file:", err)
+		}
+		_ = f.Close()
+		Tangle().LoadLocalSnapshot(snapshot)
+		plugin.LogInfof("read local snapshot from %s", Parameters.LocalSnapshot.File)
+	} else if Parameters.Snapshot.File != "" {
 		f, err := os.Open(Parameters.Snapshot.File)
 		if err != nil {
 			plugin.Panic("can not open snapshot file:", err)
 		}
-		if _, err := snapshot.ReadFrom(f); err != nil {
+		snapshotReader, err := ledgerstate.NewSnapshotReader(bufio.NewReader(f))
+		if err != nil {
 			plugin.Panic("could not read snapshot file:", err)
 		}
-		Tangle().LedgerState.LoadSnapshot(snapshot)
-		plugin.LogInfof("read snapshot from %s", Parameters.Snapshot.File)
+		if header := snapshotReader.Header(); header.Version != ledgerstate.LegacySnapshotVersion && header.NetworkID != uint32(discovery.Parameters.NetworkVersion) {
+			plugin.Panicf("snapshot file was created for network %d instead of %d", header.NetworkID, discovery.Parameters.NetworkVersion)
+		}
+		if _, err = f.Seek(0, io.SeekStart); err != nil {
+			plugin.Panic("can not rewind snapshot file:", err)
+		}
+		header, outputCount, err := Tangle().LedgerState.LoadSnapshotStream(f)
+		if err != nil {
+			plugin.Panic("could not load snapshot file:", err)
+		}
+		_ = f.Close()
+		snapshotManaPledges = header.ManaPledges
+		plugin.LogInfof("read snapshot (version %d) with %d outputs and %d mana pledges from %s", header.Version, outputCount, len(header.ManaPledges), Parameters.Snapshot.File)
 	}
 
 	fcob.LikedThreshold = time.Duration(Parameters.FCOB.AverageNetworkDelay) * time.Second
 	fcob.LocallyFinalizedThreshold = time.Duration(Parameters.FCOB.AverageNetworkDelay*2) * time.Second
+	fcob.TimestampWindow = time.Duration(Parameters.FCOB.TimestampWindow) * time.Second
+	fcob.GratuitousNetworkDelay = time.Duration(Parameters.FCOB.GratuitousNetworkDelay) * time.Second
 }
 
 func run(*node.Plugin) {
@@ -85,6 +124,100 @@ func run(*node.Plugin) {
 	}, shutdown.PriorityTangle); err != nil {
 		log.Panicf("Failed to start as daemon: %s", err)
 	}
+
+	if Parameters.StateCommitment.EpochInterval > 0 {
+		if err := daemon.BackgroundWorker("StateCommitment", commitEpochs, shutdown.PriorityStateCommitment); err != nil {
+			log.Panicf("Failed to start as daemon: %s", err)
+		}
+	}
+
+	if Parameters.LocalSnapshot.Interval <= 0 {
+		return
+	}
+
+	if err := daemon.BackgroundWorker("LocalSnapshot", func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(time.Duration(Parameters.LocalSnapshot.Interval) * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := createLocalSnapshot(); err != nil {
+					plugin.LogErrorf("failed to create local snapshot: %s", err)
+				}
+			case <-shutdownSignal:
+				return
+			}
+		}
+	}, shutdown.PriorityLocalSnapshot); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// commitEpochs persists the state root of the confirmed unspent outputs of every epoch once the configured commit
+// delay after the end of the epoch has passed.
+func commitEpochs(shutdownSignal <-chan struct{}) {
+	epochDuration := time.Duration(Parameters.StateCommitment.EpochInterval) * time.Second
+	commitDelay := time.Duration(Parameters.StateCommitment.CommitDelay) * time.Second
+	for {
+		// commit all epochs that ended at least commitDelay ago (including the ones missed while the node was offline)
+		lastCommittableEpoch := ledgerstate.EpochIndexFromTime(clock.SyncedTime().Add(-commitDelay), epochDuration) - 1
+		nextEpoch := lastCommittableEpoch
+		if latestEpochCommitment, err := Tangle().LedgerState.StateCommitment().LatestEpochCommitment(); err == nil {
+			nextEpoch = latestEpochCommitment.EpochIndex() + 1
+		}
+		for epochIndex := nextEpoch; epochIndex <= lastCommittableEpoch; epochIndex++ {
+			epochCommitment, err := Tangle().LedgerState.CommitEpoch(epochIndex, epochDuration)
+			if err != nil {
+				plugin.LogErrorf("failed to commit epoch %d: %s", epochIndex, err)
+				break
+			}
+			plugin.LogDebugf("committed epoch %d with %s", epochIndex, epochCommitment.StateRoot())
+		}
+
+		timer := time.NewTimer((lastCommittableEpoch + 1).EndTime(epochDuration).Add(commitDelay).Sub(clock.SyncedTime()))
+		select {
+		case <-timer.C:
+		case <-shutdownSignal:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// createLocalSnapshot prunes the confirmed messages that are older than the configured pruning delay and writes the
+// resulting local snapshot to the configured file.
+func createLocalSnapshot() error {
+	boundary := time.Now().Add(-time.Duration(Parameters.LocalSnapshot.PruningDelay) * time.Minute)
+
+	// the snapshot is written before the messages are pruned, so that a node never ends up with a pruned database
+	// without a matching local snapshot
+	snapshot := Tangle().LocalSnapshot(boundary)
+	tmpFile := Parameters.LocalSnapshot.File + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return xerrors.Errorf("unable to create local snapshot file: %w", err)
+	}
+	if _, err = snapshot.WriteTo(f); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("unable to write local snapshot: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return xerrors.Errorf("unable to close local snapshot file: %w", err)
+	}
+	if err = os.Rename(tmpFile, Parameters.LocalSnapshot.File); err != nil {
+		return xerrors.Errorf("unable to replace local snapshot file: %w", err)
+	}
+
+	plugin.LogInfof("created local snapshot at %s and pruned %d messages", boundary, Tangle().PruneLocalSnapshot(snapshot))
+
+	return nil
+}
+
+// SnapshotManaPledges returns the initial mana pledges of the genesis snapshot that was loaded by the node. It returns
+// nil if the node was started from a local snapshot.
+func SnapshotManaPledges() []*ledgerstate.ManaPledge {
+	return snapshotManaPledges
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -105,6 +238,23 @@ func Tangle() *tangle.Tangle {
 			tangle.Width(Parameters.TangleWidth),
 			tangle.Consensus(ConsensusMechanism()),
 			tangle.GenesisNode(Parameters.Snapshot.GenesisNode),
+			tangle.SchedulerConfig(tangle.SchedulerParams{
+				Rate:          time.Duration(Parameters.Scheduler.Rate) * time.Millisecond,
+				MaxQueueSize:  Parameters.Scheduler.MaxQueueSize,
+				MaxBufferTime: time.Duration(Parameters.Scheduler.MaxBufferTime) * time.Second,
+			}),
+			tangle.ApprovalWeightConfig(tangle.ApprovalWeightParams{
+				ConfirmationThreshold: Parameters.ApprovalWeight.ConfirmationThreshold,
+			}),
+			tangle.TipSelection(tipSelectionStrategy()),
+			tangle.AddressHistory(Parameters.AddressHistory.Enabled),
+			tangle.DustProtectionConfig(ledgerstate.DustParams{
+				MinDeposit:               uint64(Parameters.DustProtection.MinDeposit),
+				DustThreshold:            uint64(Parameters.DustProtection.DustThreshold),
+				DustAllowanceDivisor:     uint64(Parameters.DustProtection.DustAllowanceDivisor),
+				MaxDustOutputsPerAddress: uint64(Parameters.DustProtection.MaxDustOutputsPerAddress),
+			}),
+			tangle.BranchPruning(time.Duration(Parameters.BranchPruning.Delay)*time.Minute),
 		)
 
 		tangleInstance.Setup()
@@ -113,6 +263,21 @@ func Tangle() *tangle.Tangle {
 	return tangleInstance
 }
 
+// tipSelectionStrategy returns the TipSelectionStrategy that is configured in the parameters.
+func tipSelectionStrategy() tangle.TipSelectionStrategy {
+	switch Parameters.TipSelection.Strategy {
+	case UniformTipSelection:
+		return tangle.NewUniformRandomTipSelection()
+	case YoungTipSelection:
+		return tangle.NewYoungTipSelection(time.Duration(Parameters.TipSelection.MaxTipAge) * time.Second)
+	case HeaviestBranchTipSelection:
+		return tangle.NewHeaviestBranchTipSelection()
+	}
+
+	log.Panicf("unknown tip selection strategy: %s", Parameters.TipSelection.Strategy)
+	return nil
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region ConsensusMechanism ///////////////////////////////////////////////////////////////////////////////////////////
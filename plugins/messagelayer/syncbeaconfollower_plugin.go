@@ -66,6 +66,18 @@ func SyncStatus() (bool, map[ed25519.PublicKey]Status) {
 	return Tangle().Synced(), beacons
 }
 
+// IsFollowedSyncBeacon returns true if the given message contains a sync beacon payload issued by one of the followed
+// nodes.
+func IsFollowedSyncBeacon(message *tangle.Message) bool {
+	if message.Payload().Type() != syncbeacon_payload.Type {
+		return false
+	}
+
+	_, followed := currentBeaconPubKeys[message.IssuerPublicKey()]
+
+	return followed
+}
+
 // configure plugin
 func configureSyncBeaconFollower(*node.Plugin) {
 	if SyncBeaconFollowerParameters.SyncPercentage < 0.5 || SyncBeaconFollowerParameters.SyncPercentage > 1.0 {
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"sync"
+	"time"
+
 	"github.com/iotaledger/hive.go/syncutils"
 	"go.uber.org/atomic"
 
@@ -8,6 +11,9 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote"
 )
 
+// peerQueryStatsRetention is the time after which the statistics of a peer that was not queried anymore are removed.
+const peerQueryStatsRetention = 10 * time.Minute
+
 var (
 	activeConflicts        atomic.Uint64
 	finalizedConflictCount atomic.Uint64
@@ -27,8 +33,24 @@ var (
 
 	// opinionQueryReplyErrorCount counts how many opinions we asked for but never heard back (multiple opinions in one query).
 	opinionQueryReplyErrorCount atomic.Uint64
+
+	// peerQueryStats contains the statistics of the queries sent to the individual peers.
+	peerQueryStats      = make(map[string]*PeerQueryStats)
+	peerQueryStatsMutex sync.RWMutex
 )
 
+// PeerQueryStats contains the statistics of the FPC queries that were sent to a single peer.
+type PeerQueryStats struct {
+	// Replies is the number of queries that were answered by the peer.
+	Replies uint64
+	// Failures is the number of queries that were not answered by the peer.
+	Failures uint64
+	// AverageLatency is the average time it took the peer to answer a query.
+	AverageLatency time.Duration
+	// LastQueried is the time when the last query to the peer was answered or failed.
+	LastQueried time.Time
+}
+
 // ActiveConflicts returns the number of currently active conflicts.
 func ActiveConflicts() uint64 {
 	return activeConflicts.Load()
@@ -72,6 +94,18 @@ func FPCOpinionQueryReplyErrors() uint64 {
 	return opinionQueryReplyErrorCount.Load()
 }
 
+// FPCPeerQueryStats returns the statistics of the queries that were sent to the individual peers (by their ID).
+func FPCPeerQueryStats() map[string]PeerQueryStats {
+	peerQueryStatsMutex.RLock()
+	defer peerQueryStatsMutex.RUnlock()
+
+	result := make(map[string]PeerQueryStats, len(peerQueryStats))
+	for peerID, stats := range peerQueryStats {
+		result[peerID] = *stats
+	}
+	return result
+}
+
 //// logic broken into "process..."  functions to be able to write unit tests ////
 
 func processRoundStats(stats *vote.RoundStats) {
@@ -105,4 +139,44 @@ func processQueryReplyError(ev *metrics.QueryReplyErrorEvent) {
 	queryReplyErrorCount.Inc()
 	// containing this many conflicts to give opinion about
 	opinionQueryReplyErrorCount.Add((uint64)(ev.OpinionCount))
+
+	peerQueryStatsMutex.Lock()
+	defer peerQueryStatsMutex.Unlock()
+	stats := queryStatsOfPeer(ev.ID)
+	stats.Failures++
+	stats.LastQueried = time.Now()
+}
+
+func processQueryReplyReceived(ev *metrics.QueryReplyReceivedEvent) {
+	peerQueryStatsMutex.Lock()
+	defer peerQueryStatsMutex.Unlock()
+
+	stats := queryStatsOfPeer(ev.ID)
+	stats.Replies++
+	// update the running average of the latency
+	stats.AverageLatency += (ev.Latency - stats.AverageLatency) / time.Duration(stats.Replies)
+	stats.LastQueried = time.Now()
+}
+
+// prunePeerQueryStats removes the statistics of the peers that were not queried within the retention, so that the
+// statistics do not grow with every peer that was ever queried.
+func prunePeerQueryStats() {
+	peerQueryStatsMutex.Lock()
+	defer peerQueryStatsMutex.Unlock()
+
+	for peerID, stats := range peerQueryStats {
+		if time.Since(stats.LastQueried) > peerQueryStatsRetention {
+			delete(peerQueryStats, peerID)
+		}
+	}
+}
+
+// queryStatsOfPeer returns the PeerQueryStats of the given peer (the peerQueryStatsMutex needs to be locked).
+func queryStatsOfPeer(peerID string) *PeerQueryStats {
+	stats, exists := peerQueryStats[peerID]
+	if !exists {
+		stats = &PeerQueryStats{}
+		peerQueryStats[peerID] = stats
+	}
+	return stats
 }
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/magiconair/properties/assert"
 
@@ -88,3 +89,26 @@ func TestQueryReplyError(t *testing.T) {
 	assert.Equal(t, FPCQueryReplyErrors(), (uint64)(2))
 	assert.Equal(t, FPCOpinionQueryReplyErrors(), (uint64)(10))
 }
+
+func TestPeerQueryStats(t *testing.T) {
+	processQueryReplyReceived(&metrics.QueryReplyReceivedEvent{ID: "peerA", OpinionCount: 2, Latency: 10 * time.Millisecond})
+	processQueryReplyReceived(&metrics.QueryReplyReceivedEvent{ID: "peerA", OpinionCount: 2, Latency: 30 * time.Millisecond})
+	processQueryReplyError(&metrics.QueryReplyErrorEvent{ID: "peerB", OpinionCount: 2})
+
+	stats := FPCPeerQueryStats()
+	assert.Equal(t, stats["peerA"].Replies, (uint64)(2))
+	assert.Equal(t, stats["peerA"].AverageLatency, 20*time.Millisecond)
+	assert.Equal(t, stats["peerB"].Failures, (uint64)(1))
+
+	// the statistics of peers that are not queried anymore are removed
+	peerQueryStatsMutex.Lock()
+	peerQueryStats["peerB"].LastQueried = time.Now().Add(-2 * peerQueryStatsRetention)
+	peerQueryStatsMutex.Unlock()
+	prunePeerQueryStats()
+
+	stats = FPCPeerQueryStats()
+	_, peerAExists := stats["peerA"]
+	_, peerBExists := stats["peerB"]
+	assert.Equal(t, peerAExists, true)
+	assert.Equal(t, peerBExists, false)
+}
@@ -73,6 +73,7 @@ func run(_ *node.Plugin) {
 				measureRequestQueueSize()
 				measureGossipTraffic()
 			}, 1*time.Second, shutdownSignal)
+			timeutil.NewTicker(prunePeerQueryStats, 1*time.Minute, shutdownSignal)
 		}
 
 		if config.Node().Bool(CfgMetricsGlobal) {
@@ -226,6 +227,9 @@ func registerLocalMetrics() {
 	metrics.Events().QueryReplyError.Attach(events.NewClosure(func(ev *metrics.QueryReplyErrorEvent) {
 		processQueryReplyError(ev)
 	}))
+	metrics.Events().QueryReplyReceived.Attach(events.NewClosure(func(ev *metrics.QueryReplyReceivedEvent) {
+		processQueryReplyReceived(ev)
+	}))
 
 	// mana pledge events
 	mana.Events().Pledged.Attach(events.NewClosure(func(ev *mana.PledgedEvent) {
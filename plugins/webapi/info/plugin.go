@@ -11,9 +11,11 @@ import (
 	"github.com/mr-tron/base58/base58"
 
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/banner"
+	"github.com/iotaledger/goshimmer/plugins/gossip"
 	manaPlugin "github.com/iotaledger/goshimmer/plugins/mana"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
@@ -52,6 +54,13 @@ func configure(_ *node.Plugin) {
 // 		"sent_time":1595528075204868900,
 // 		"synced":true
 // }]
+//  "sync":{
+// 		"active":true,
+// 		"target":"24Uq4UFQ7p5oLyjuXX32jHhNreo5hY9eo8Awh36RhdTHCwFMtct3SE2rhe3ceYz6rjKDjBs3usoHS3ujFEabP5ri",
+// 		"peer":"5bf4aa1d6c47e4ce",
+// 		"received":1500,
+// 		"total":4200
+// }
 // 	"identityID":"5bf4aa1d6c47e4ce",
 // 	"publickey":"CjUsn86jpFHWnSCx3NhWfU4Lk16mDdy1Hr7ERSTv3xn9",
 // 	"enabledplugins":[
@@ -107,6 +116,18 @@ func getInfo(c echo.Context) error {
 		})
 	}
 
+	syncStatus := gossip.Sync()
+	var syncProgress SyncProgress
+	if syncStatus.Target != tangle.EmptyMessageID {
+		syncProgress = SyncProgress{
+			Active:   syncStatus.Active,
+			Target:   syncStatus.Target.String(),
+			Peer:     syncStatus.Peer.String(),
+			Received: syncStatus.Received,
+			Total:    syncStatus.Total,
+		}
+	}
+
 	t := time.Now()
 	accessMana, tAccess, _ := manaPlugin.GetAccessMana(local.GetInstance().ID(), t)
 	consensusMana, tConsensus, _ := manaPlugin.GetConsensusMana(local.GetInstance().ID(), t)
@@ -122,6 +143,7 @@ func getInfo(c echo.Context) error {
 		NetworkVersion:          discovery.NetworkVersion(),
 		Synced:                  synced,
 		Beacons:                 beaconsStatus,
+		Sync:                    syncProgress,
 		IdentityID:              base58.Encode(local.GetInstance().Identity.ID().Bytes()),
 		IdentityIDShort:         local.GetInstance().Identity.ID().String(),
 		PublicKey:               local.GetInstance().PublicKey().String(),
@@ -145,6 +167,8 @@ type Response struct {
 	Synced bool `json:"synced"`
 	// sync beacons status
 	Beacons []Beacon `json:"beacons"`
+	// progress of the bulk synchronization of the past cone of a sync beacon
+	Sync SyncProgress `json:"sync"`
 	// identity ID of the node encoded in base58
 	IdentityID string `json:"identityID,omitempty"`
 	// identity ID of the node encoded in base58 and truncated to its first 8 bytes
@@ -177,6 +201,15 @@ type Beacon struct {
 	Synced    bool   `json:"synced"`
 }
 
+// SyncProgress contains the progress of the bulk synchronization of the past cone of a sync beacon.
+type SyncProgress struct {
+	Active   bool   `json:"active"`
+	Target   string `json:"target,omitempty"`
+	Peer     string `json:"peer,omitempty"`
+	Received int    `json:"received"`
+	Total    int    `json:"total"`
+}
+
 // Mana contains the different mana values of the node.
 type Mana struct {
 	Access             float64   `json:"access"`
@@ -28,15 +28,18 @@ func NewAddress(address ledgerstate.Address) *Address {
 
 // Output represents the JSON model of a ledgerstate.Output.
 type Output struct {
-	OutputID *OutputID         `json:"outputID,omitempty"`
-	Type     string            `json:"type"`
-	Balances map[string]uint64 `json:"balances"`
-	Address  string            `json:"address"`
+	OutputID         *OutputID         `json:"outputID,omitempty"`
+	Type             string            `json:"type"`
+	Balances         map[string]uint64 `json:"balances"`
+	Address          string            `json:"address"`
+	FallbackAddress  string            `json:"fallbackAddress,omitempty"`
+	FallbackDeadline int64             `json:"fallbackDeadline,omitempty"`
+	TimeLock         int64             `json:"timelock,omitempty"`
 }
 
 // NewOutput returns an Output from the given ledgerstate.Output.
-func NewOutput(output ledgerstate.Output) *Output {
-	return &Output{
+func NewOutput(output ledgerstate.Output) (jsonOutput *Output) {
+	jsonOutput = &Output{
 		OutputID: NewOutputID(output.ID()),
 		Type:     output.Type().String(),
 		Balances: func() (mappedBalances map[string]uint64) {
@@ -51,6 +54,18 @@ func NewOutput(output ledgerstate.Output) *Output {
 		}(),
 		Address: output.Address().Base58(),
 	}
+
+	if extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput); isExtendedLockedOutput {
+		if extendedLockedOutput.FallbackAddress() != nil {
+			jsonOutput.FallbackAddress = extendedLockedOutput.FallbackAddress().Base58()
+			jsonOutput.FallbackDeadline = extendedLockedOutput.FallbackDeadline().Unix()
+		}
+		if !extendedLockedOutput.TimeLock().IsZero() {
+			jsonOutput.TimeLock = extendedLockedOutput.TimeLock().Unix()
+		}
+	}
+
+	return jsonOutput
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -300,6 +315,9 @@ type UnlockBlock struct {
 	SignatureType   ledgerstate.SignatureType `json:"signatureType,omitempty"`
 	PublicKey       string                    `json:"publicKey,omitempty"`
 	Signature       string                    `json:"signature,omitempty"`
+	Threshold       uint8                     `json:"threshold,omitempty"`
+	PublicKeys      []string                  `json:"publicKeys,omitempty"`
+	Signatures      []string                  `json:"signatures,omitempty"`
 }
 
 // NewUnlockBlock returns an UnlockBlock from the given ledgerstate.UnlockBlock.
@@ -325,6 +343,18 @@ func NewUnlockBlock(unlockBlock ledgerstate.UnlockBlock) *UnlockBlock {
 	case ledgerstate.ReferenceUnlockBlockType:
 		referenceUnlockBlock, _, _ := ledgerstate.ReferenceUnlockBlockFromBytes(unlockBlock.Bytes())
 		result.ReferencedIndex = referenceUnlockBlock.ReferencedIndex()
+	case ledgerstate.AliasUnlockBlockType:
+		aliasUnlockBlock, _, _ := ledgerstate.AliasUnlockBlockFromBytes(unlockBlock.Bytes())
+		result.ReferencedIndex = aliasUnlockBlock.AliasInputIndex()
+	case ledgerstate.MultiSignatureUnlockBlockType:
+		multiSignatureUnlockBlock, _, _ := ledgerstate.MultiSignatureUnlockBlockFromBytes(unlockBlock.Bytes())
+		result.Threshold = multiSignatureUnlockBlock.Threshold()
+		for _, publicKey := range multiSignatureUnlockBlock.PublicKeys() {
+			result.PublicKeys = append(result.PublicKeys, publicKey.String())
+		}
+		for _, signature := range multiSignatureUnlockBlock.Signatures() {
+			result.Signatures = append(result.Signatures, signature.Base58())
+		}
 	}
 
 	return result
@@ -342,6 +372,7 @@ type TransactionMetadata struct {
 	SolidificationTime int64  `json:"solidificationTime"`
 	Finalized          bool   `json:"finalized"`
 	LazyBooked         bool   `json:"lazyBooked"`
+	InvalidReason      string `json:"invalidReason,omitempty"`
 }
 
 // NewTransactionMetadata returns the TransactionMetadata from the given ledgerstate.TransactionMetadata.
@@ -353,6 +384,57 @@ func NewTransactionMetadata(transactionMetadata *ledgerstate.TransactionMetadata
 		SolidificationTime: transactionMetadata.SolidificationTime().Unix(),
 		Finalized:          transactionMetadata.Finalized(),
 		LazyBooked:         transactionMetadata.LazyBooked(),
+		InvalidReason: func() string {
+			if transactionMetadata.InvalidReason() == ledgerstate.NoInvalidReason {
+				return ""
+			}
+
+			return transactionMetadata.InvalidReason().String()
+		}(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AssetMetadata ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AssetMetadata represents the JSON model of a ledgerstate.AssetMetadata.
+type AssetMetadata struct {
+	Name      string `json:"name"`
+	Symbol    string `json:"symbol"`
+	Precision uint8  `json:"precision"`
+	Supply    uint64 `json:"supply"`
+}
+
+// NewAssetMetadata returns an AssetMetadata from the given ledgerstate.AssetMetadata.
+func NewAssetMetadata(assetMetadata *ledgerstate.AssetMetadata) *AssetMetadata {
+	return &AssetMetadata{
+		Name:      assetMetadata.Name(),
+		Symbol:    assetMetadata.Symbol(),
+		Precision: assetMetadata.Precision(),
+		Supply:    assetMetadata.Supply(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EpochCommitment //////////////////////////////////////////////////////////////////////////////////////////////
+
+// EpochCommitment represents the JSON model of a ledgerstate.EpochCommitment.
+type EpochCommitment struct {
+	EpochIndex  uint64 `json:"epochIndex"`
+	StateRoot   string `json:"stateRoot"`
+	OutputCount uint64 `json:"outputCount"`
+	CommitTime  int64  `json:"commitTime"`
+}
+
+// NewEpochCommitment returns an EpochCommitment from the given ledgerstate.EpochCommitment.
+func NewEpochCommitment(epochCommitment *ledgerstate.EpochCommitment) *EpochCommitment {
+	return &EpochCommitment{
+		EpochIndex:  uint64(epochCommitment.EpochIndex()),
+		StateRoot:   epochCommitment.StateRoot().Base58(),
+		OutputCount: epochCommitment.OutputCount(),
+		CommitTime:  epochCommitment.CommitTime().Unix(),
 	}
 }
 
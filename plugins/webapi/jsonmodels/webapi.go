@@ -1,6 +1,8 @@
 package jsonmodels
 
 import (
+	"github.com/mr-tron/base58"
+
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 )
@@ -32,6 +34,53 @@ func NewGetAddressResponse(address ledgerstate.Address, outputs ledgerstate.Outp
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressHistoryResponse ////////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressHistoryResponse represents the JSON model of a response from the GetAddressHistory endpoint.
+type GetAddressHistoryResponse struct {
+	Address      *Address               `json:"address"`
+	Transactions []*AddressHistoryEntry `json:"transactions"`
+	NextCursor   string                 `json:"nextCursor,omitempty"`
+}
+
+// NewGetAddressHistoryResponse returns a GetAddressHistoryResponse from the given details.
+func NewGetAddressHistoryResponse(address ledgerstate.Address, entries []*AddressHistoryEntry, nextCursor *ledgerstate.AddressHistoryCursor) *GetAddressHistoryResponse {
+	response := &GetAddressHistoryResponse{
+		Address:      NewAddress(address),
+		Transactions: entries,
+	}
+	if response.Transactions == nil {
+		response.Transactions = make([]*AddressHistoryEntry, 0)
+	}
+	if nextCursor != nil {
+		response.NextCursor = nextCursor.Base58()
+	}
+
+	return response
+}
+
+// AddressHistoryEntry represents the JSON model of a Transaction in the history of an Address.
+type AddressHistoryEntry struct {
+	TransactionID  string `json:"transactionID"`
+	Timestamp      int64  `json:"timestamp"`
+	Credited       bool   `json:"credited"`
+	Debited        bool   `json:"debited"`
+	InclusionState string `json:"inclusionState"`
+}
+
+// NewAddressHistoryEntry returns an AddressHistoryEntry from the given details.
+func NewAddressHistoryEntry(addressTransactionMapping *ledgerstate.AddressTransactionMapping, inclusionState ledgerstate.InclusionState) *AddressHistoryEntry {
+	return &AddressHistoryEntry{
+		TransactionID:  addressTransactionMapping.TransactionID().Base58(),
+		Timestamp:      addressTransactionMapping.Timestamp().Unix(),
+		Credited:       addressTransactionMapping.Credited(),
+		Debited:        addressTransactionMapping.Debited(),
+		InclusionState: inclusionState.String(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetBranchChildrenResponse ////////////////////////////////////////////////////////////////////////////////////
 
 // GetBranchChildrenResponse represents the JSON model of a response from the GetBranchChildren endpoint.
@@ -82,6 +131,36 @@ func NewGetBranchConflictsResponse(branchID ledgerstate.BranchID, branchIDsPerCo
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetColorResponse /////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColorResponse represents the JSON model of a response from the GetColor endpoint.
+type GetColorResponse struct {
+	Color                string         `json:"color"`
+	TotalSupply          uint64         `json:"totalSupply"`
+	BurnedSupply         uint64         `json:"burnedSupply"`
+	CirculatingSupply    uint64         `json:"circulatingSupply"`
+	MintingTransactionID string         `json:"mintingTransactionID,omitempty"`
+	Metadata             *AssetMetadata `json:"metadata,omitempty"`
+}
+
+// NewGetColorResponse returns a GetColorResponse from the given details.
+func NewGetColorResponse(colorSupply *ledgerstate.ColorSupply, colorMetadata *ledgerstate.ColorMetadata) *GetColorResponse {
+	response := &GetColorResponse{
+		Color:             colorSupply.Color.Base58(),
+		TotalSupply:       colorSupply.TotalSupply,
+		BurnedSupply:      colorSupply.BurnedSupply,
+		CirculatingSupply: colorSupply.CirculatingSupply,
+	}
+	if colorMetadata != nil {
+		response.MintingTransactionID = colorMetadata.TransactionID().Base58()
+		response.Metadata = NewAssetMetadata(colorMetadata.AssetMetadata())
+	}
+
+	return response
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetOutputConsumersResponse ///////////////////////////////////////////////////////////////////////////////////
 
 // GetOutputConsumersResponse represents the JSON model of a response from the GetOutputConsumers endpoint.
@@ -109,6 +188,53 @@ func NewGetOutputConsumersResponse(outputID ledgerstate.OutputID, consumers []*l
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetStateCommitmentResponse ///////////////////////////////////////////////////////////////////////////////////
+
+// GetStateCommitmentResponse represents the JSON model of a response from the GetStateCommitment endpoint.
+type GetStateCommitmentResponse struct {
+	StateRoot             string           `json:"stateRoot"`
+	OutputCount           uint64           `json:"outputCount"`
+	LatestEpochCommitment *EpochCommitment `json:"latestEpochCommitment,omitempty"`
+}
+
+// NewGetStateCommitmentResponse returns a GetStateCommitmentResponse from the given details.
+func NewGetStateCommitmentResponse(stateRoot ledgerstate.StateRoot, outputCount uint64, latestEpochCommitment *ledgerstate.EpochCommitment) *GetStateCommitmentResponse {
+	response := &GetStateCommitmentResponse{
+		StateRoot:   stateRoot.Base58(),
+		OutputCount: outputCount,
+	}
+	if latestEpochCommitment != nil {
+		response.LatestEpochCommitment = NewEpochCommitment(latestEpochCommitment)
+	}
+
+	return response
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetStateInclusionProofResponse ///////////////////////////////////////////////////////////////////////////////
+
+// GetStateInclusionProofResponse represents the JSON model of a response from the GetStateInclusionProof endpoint. It
+// contains everything that is needed to verify the inclusion of the Output in the StateRoot.
+type GetStateInclusionProofResponse struct {
+	Output      *Output `json:"output"`
+	OutputBytes string  `json:"outputBytes"`
+	StateRoot   string  `json:"stateRoot"`
+	Proof       string  `json:"proof"`
+}
+
+// NewGetStateInclusionProofResponse returns a GetStateInclusionProofResponse from the given details.
+func NewGetStateInclusionProofResponse(output ledgerstate.Output, stateRoot ledgerstate.StateRoot, proof *ledgerstate.StateInclusionProof) *GetStateInclusionProofResponse {
+	return &GetStateInclusionProofResponse{
+		Output:      NewOutput(output),
+		OutputBytes: base58.Encode(output.Bytes()),
+		StateRoot:   stateRoot.Base58(),
+		Proof:       proof.Base58(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetTransactionAttachmentsResponse ////////////////////////////////////////////////////////////////////////////
 
 // GetTransactionAttachmentsResponse represents the JSON model of a response from the GetTransactionAttachments endpoint.
@@ -132,6 +258,114 @@ func NewGetTransactionAttachmentsResponse(transactionID ledgerstate.TransactionI
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region PostTransactionValidationRequest /////////////////////////////////////////////////////////////////////////////
+
+// PostTransactionValidationRequest represents the JSON model of a request to the PostTransactionValidation endpoint.
+type PostTransactionValidationRequest struct {
+	TransactionBytes []byte `json:"txn_bytes"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PostTransactionValidationResponse ////////////////////////////////////////////////////////////////////////////
+
+// PostTransactionValidationResponse represents the JSON model of a response from the PostTransactionValidation
+// endpoint.
+type PostTransactionValidationResponse struct {
+	TransactionID              string                 `json:"transactionID"`
+	Valid                      bool                   `json:"valid"`
+	Inputs                     []*InputValidation     `json:"inputs"`
+	InputsSolid                bool                   `json:"inputsSolid"`
+	BalancesValid              bool                   `json:"balancesValid"`
+	UnlockBlocksValid          bool                   `json:"unlockBlocksValid"`
+	AliasOutputsValid          bool                   `json:"aliasOutputsValid"`
+	PastConeValid              bool                   `json:"pastConeValid"`
+	BranchesConflicting        bool                   `json:"branchesConflicting"`
+	DustProtectionError        string                 `json:"dustProtectionError,omitempty"`
+	TimestampValid             bool                   `json:"timestampValid"`
+	AccessManaPledgeAllowed    bool                   `json:"accessManaPledgeAllowed"`
+	ConsensusManaPledgeAllowed bool                   `json:"consensusManaPledgeAllowed"`
+	Conflicts                  []*TransactionConflict `json:"conflicts"`
+}
+
+// NewPostTransactionValidationResponse returns a PostTransactionValidationResponse from the given details.
+func NewPostTransactionValidationResponse(validation *ledgerstate.TransactionValidation, timestampValid, accessManaPledgeAllowed, consensusManaPledgeAllowed bool) *PostTransactionValidationResponse {
+	response := &PostTransactionValidationResponse{
+		TransactionID:              validation.TransactionID.Base58(),
+		Valid:                      validation.Valid() && timestampValid && accessManaPledgeAllowed && consensusManaPledgeAllowed,
+		Inputs:                     make([]*InputValidation, len(validation.Inputs)),
+		InputsSolid:                validation.InputsSolid,
+		BalancesValid:              validation.BalancesValid,
+		UnlockBlocksValid:          validation.UnlockBlocksValid,
+		AliasOutputsValid:          validation.AliasOutputsValid,
+		PastConeValid:              validation.PastConeValid,
+		BranchesConflicting:        validation.BranchesConflicting,
+		TimestampValid:             timestampValid,
+		AccessManaPledgeAllowed:    accessManaPledgeAllowed,
+		ConsensusManaPledgeAllowed: consensusManaPledgeAllowed,
+		Conflicts:                  make([]*TransactionConflict, len(validation.Conflicts)),
+	}
+	for i, inputValidation := range validation.Inputs {
+		response.Inputs[i] = NewInputValidation(inputValidation)
+	}
+	if validation.DustProtectionError != nil {
+		response.DustProtectionError = validation.DustProtectionError.Error()
+	}
+	for i, conflict := range validation.Conflicts {
+		response.Conflicts[i] = NewTransactionConflict(conflict)
+	}
+
+	return response
+}
+
+// InputValidation represents the JSON model of a ledgerstate.InputValidation.
+type InputValidation struct {
+	OutputID                 *OutputID `json:"outputID"`
+	Solid                    bool      `json:"solid"`
+	BranchID                 string    `json:"branchID,omitempty"`
+	BranchInclusionState     string    `json:"branchInclusionState,omitempty"`
+	BranchMonotonicallyLiked bool      `json:"branchMonotonicallyLiked"`
+	ConsumerCount            int       `json:"consumerCount"`
+	UnlockBlockValid         bool      `json:"unlockBlockValid"`
+}
+
+// NewInputValidation returns an InputValidation from the given ledgerstate.InputValidation.
+func NewInputValidation(inputValidation *ledgerstate.InputValidation) *InputValidation {
+	result := &InputValidation{
+		OutputID:                 NewOutputID(inputValidation.OutputID),
+		Solid:                    inputValidation.Solid,
+		BranchMonotonicallyLiked: inputValidation.BranchMonotonicallyLiked,
+		ConsumerCount:            inputValidation.ConsumerCount,
+		UnlockBlockValid:         inputValidation.UnlockBlockValid,
+	}
+	if inputValidation.Solid {
+		result.BranchID = inputValidation.BranchID.Base58()
+		result.BranchInclusionState = inputValidation.BranchInclusionState.String()
+	}
+
+	return result
+}
+
+// TransactionConflict represents the JSON model of a ledgerstate.TransactionConflict.
+type TransactionConflict struct {
+	OutputID       *OutputID `json:"outputID"`
+	TransactionID  string    `json:"transactionID"`
+	BranchID       string    `json:"branchID"`
+	InclusionState string    `json:"inclusionState"`
+}
+
+// NewTransactionConflict returns a TransactionConflict from the given ledgerstate.TransactionConflict.
+func NewTransactionConflict(conflict *ledgerstate.TransactionConflict) *TransactionConflict {
+	return &TransactionConflict{
+		OutputID:       NewOutputID(conflict.OutputID),
+		TransactionID:  conflict.TransactionID.Base58(),
+		BranchID:       conflict.BranchID.Base58(),
+		InclusionState: conflict.InclusionState.String(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PostPayloadRequest ///////////////////////////////////////////////////////////////////////////////////////////
 
 // PostPayloadRequest represents the JSON model of a PostPayload request.
@@ -3,14 +3,20 @@ package ledgerstate
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 	"golang.org/x/xerrors"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/mana"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
@@ -32,15 +38,21 @@ func Plugin() *node.Plugin {
 		plugin = node.NewPlugin("WebAPI ledgerstate Endpoint", node.Enabled, func(*node.Plugin) {
 			webapi.Server().GET("ledgerstate/addresses/:address", GetAddress)
 			webapi.Server().GET("ledgerstate/addresses/:address/unspentOutputs", GetAddressUnspentOutputs)
+			webapi.Server().GET("ledgerstate/addresses/:address/history", GetAddressHistory)
 			webapi.Server().GET("ledgerstate/branches/:branchID", GetBranch)
 			webapi.Server().GET("ledgerstate/branches/:branchID/children", GetBranchChildren)
 			webapi.Server().GET("ledgerstate/branches/:branchID/conflicts", GetBranchConflicts)
+			webapi.Server().GET("ledgerstate/colors/:color", GetColor)
+			webapi.Server().GET("ledgerstate/commitment", GetStateCommitment)
+			webapi.Server().GET("ledgerstate/commitment/epochs/:epochIndex", GetEpochCommitment)
+			webapi.Server().GET("ledgerstate/commitment/proofs/:outputID", GetStateInclusionProof)
 			webapi.Server().GET("ledgerstate/outputs/:outputID", GetOutput)
 			webapi.Server().GET("ledgerstate/outputs/:outputID/consumers", GetOutputConsumers)
 			webapi.Server().GET("ledgerstate/outputs/:outputID/metadata", GetOutputMetadata)
 			webapi.Server().GET("ledgerstate/transactions/:transactionID", GetTransaction)
 			webapi.Server().GET("ledgerstate/transactions/:transactionID/metadata", GetTransactionMetadata)
 			webapi.Server().GET("ledgerstate/transactions/:transactionID/attachments", GetTransactionAttachments)
+			webapi.Server().POST("ledgerstate/transactions/validate", PostTransactionValidation)
 		})
 	})
 
@@ -89,6 +101,63 @@ func GetAddressUnspentOutputs(c echo.Context) error {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressHistory ////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// defaultAddressHistoryLimit defines the number of history entries that are returned if no limit is requested.
+	defaultAddressHistoryLimit = 100
+
+	// maxAddressHistoryLimit defines the maximum number of history entries that are returned in a single page.
+	maxAddressHistoryLimit = 1000
+)
+
+// GetAddressHistory is the handler for the /ledgerstate/addresses/:address/history endpoint. It supports the optional
+// query parameters "cursor" (the nextCursor of the previous page) and "limit" (the size of the page).
+func GetAddressHistory(c echo.Context) error {
+	if !messagelayer.Tangle().LedgerState.AddressHistoryEnabled() {
+		return c.JSON(http.StatusServiceUnavailable, jsonmodels.NewErrorResponse(xerrors.New("the address history is disabled on this node")))
+	}
+
+	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	var cursor *ledgerstate.AddressHistoryCursor
+	if cursorParam := c.QueryParam("cursor"); cursorParam != "" {
+		parsedCursor, cursorErr := ledgerstate.AddressHistoryCursorFromBase58(cursorParam)
+		if cursorErr != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(cursorErr))
+		}
+		cursor = &parsedCursor
+	}
+
+	limit := defaultAddressHistoryLimit
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit <= 0 || limit > maxAddressHistoryLimit {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(xerrors.Errorf("limit must be a number between 1 and %d", maxAddressHistoryLimit)))
+		}
+	}
+
+	addressTransactionMappings, nextCursor, err := messagelayer.Tangle().LedgerState.AddressTransactionHistory(address, cursor, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	entries := make([]*jsonmodels.AddressHistoryEntry, len(addressTransactionMappings))
+	for i, addressTransactionMapping := range addressTransactionMappings {
+		inclusionState, inclusionStateErr := messagelayer.Tangle().LedgerState.TransactionInclusionState(addressTransactionMapping.TransactionID())
+		if inclusionStateErr != nil {
+			return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(inclusionStateErr))
+		}
+
+		entries[i] = jsonmodels.NewAddressHistoryEntry(addressTransactionMapping, inclusionState)
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetAddressHistoryResponse(address, entries, nextCursor))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetBranch ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetBranch is the handler for the /ledgerstate/branch/:branchID endpoint.
@@ -159,6 +228,118 @@ func GetBranchConflicts(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetColor /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColor is the handler for the /ledgerstate/colors/:color endpoint. It returns the total, burned and circulating
+// supply of the Color and the AssetMetadata that was attached to its minting Transaction.
+func GetColor(c echo.Context) (err error) {
+	color, err := ledgerstate.ColorFromBase58EncodedString(c.Param("color"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	if color == ledgerstate.ColorIOTA || color == ledgerstate.ColorMint {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(fmt.Errorf("supply of %s is not tracked", color)))
+	}
+
+	colorSupply, err := messagelayer.Tangle().LedgerState.ColorSupply(color)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	cachedColorMetadata := messagelayer.Tangle().LedgerState.ColorMetadata(color)
+	defer cachedColorMetadata.Release()
+	colorMetadata := cachedColorMetadata.Unwrap()
+
+	if colorSupply.TotalSupply == 0 && colorSupply.BurnedSupply == 0 && colorMetadata == nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(fmt.Errorf("failed to load Color %s", color)))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetColorResponse(colorSupply, colorMetadata))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetStateCommitment ///////////////////////////////////////////////////////////////////////////////////////////
+
+// GetStateCommitment is the handler for the /ledgerstate/commitment endpoint.
+func GetStateCommitment(c echo.Context) (err error) {
+	stateCommitment := messagelayer.Tangle().LedgerState.StateCommitment()
+	stateRoot, err := stateCommitment.Root()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	outputCount, err := stateCommitment.OutputCount()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	latestEpochCommitment, err := stateCommitment.LatestEpochCommitment()
+	if err != nil && !xerrors.Is(err, kvstore.ErrKeyNotFound) {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetStateCommitmentResponse(stateRoot, outputCount, latestEpochCommitment))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetEpochCommitment ///////////////////////////////////////////////////////////////////////////////////////////
+
+// GetEpochCommitment is the handler for the /ledgerstate/commitment/epochs/:epochIndex endpoint.
+func GetEpochCommitment(c echo.Context) (err error) {
+	epochIndex, err := strconv.ParseUint(c.Param("epochIndex"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	epochCommitment, err := messagelayer.Tangle().LedgerState.StateCommitment().EpochCommitment(ledgerstate.EpochIndex(epochIndex))
+	if err != nil {
+		if xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(xerrors.Errorf("epoch %d was not committed", epochIndex)))
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewEpochCommitment(epochCommitment))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetStateInclusionProof ///////////////////////////////////////////////////////////////////////////////////////
+
+// GetStateInclusionProof is the handler for the /ledgerstate/commitment/proofs/:outputID endpoint. The proof refers to
+// the current StateRoot which is returned together with the proof.
+func GetStateInclusionProof(c echo.Context) (err error) {
+	outputID, err := ledgerstate.OutputIDFromBase58(c.Param("outputID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	stateCommitment := messagelayer.Tangle().LedgerState.StateCommitment()
+	contained, err := stateCommitment.Contains(outputID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	if !contained {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(xerrors.Errorf("%s is not a confirmed unspent Output", outputID)))
+	}
+
+	var output ledgerstate.Output
+	if !messagelayer.Tangle().LedgerState.Output(outputID).Consume(func(loadedOutput ledgerstate.Output) {
+		output = loadedOutput
+	}) {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(xerrors.Errorf("failed to load Output with %s", outputID)))
+	}
+
+	proof, stateRoot, err := stateCommitment.InclusionProof(outputID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetStateInclusionProofResponse(output, stateRoot, proof))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetOutput ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetOutput is the handler for the /ledgerstate/outputs/:outputID endpoint.
@@ -277,6 +458,43 @@ func GetTransactionAttachments(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region PostTransactionValidation ////////////////////////////////////////////////////////////////////////////////////
+
+// PostTransactionValidation is the handler for the /ledgerstate/transactions/validate endpoint. It runs the posted
+// Transaction through all checks of the node without issuing or booking it.
+func PostTransactionValidation(c echo.Context) error {
+	var request jsonmodels.PostTransactionValidationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	transaction, _, err := ledgerstate.TransactionFromBytes(request.TransactionBytes)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	validation, err := messagelayer.Tangle().LedgerState.ValidateTransaction(transaction)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewPostTransactionValidationResponse(
+		validation,
+		!transaction.Essence().Timestamp().Before(clock.SyncedTime().Add(-tangle.MaxReattachmentTimeMin)),
+		manaPledgeAllowed(mana.AccessMana, transaction.Essence().AccessPledgeID()),
+		manaPledgeAllowed(mana.ConsensusMana, transaction.Essence().ConsensusPledgeID()),
+	))
+}
+
+// manaPledgeAllowed checks if the node accepts Transactions that pledge the given type of mana to the given node.
+func manaPledgeAllowed(manaType mana.Type, nodeID identity.ID) bool {
+	allowedPledgeNodes := manaPlugin.GetAllowedPledgeNodes(manaType)
+
+	return !allowedPledgeNodes.IsFilterEnabled || allowedPledgeNodes.Allowed.Has(nodeID)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region branchIDFromContext //////////////////////////////////////////////////////////////////////////////////////////
 
 // branchIDFromContext determines the BranchID from the branchID parameter in an echo.Context. It expects it to either
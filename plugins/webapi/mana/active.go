@@ -0,0 +1,42 @@
+package mana
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/mana"
+)
+
+// getActiveConsensusHandler handles the request.
+func getActiveConsensusHandler(c echo.Context) error {
+	activeConsensusMana, t, err := manaPlugin.ActiveConsensusManaVector()
+	if err != nil {
+		return c.JSON(http.StatusNotFound, GetActiveConsensusResponse{Error: err.Error()})
+	}
+
+	activeList := activeConsensusMana.ToNodeStrList()
+	sort.Slice(activeList, func(i, j int) bool {
+		return activeList[i].Mana > activeList[j].Mana
+	})
+	var totalMana float64
+	for _, nodeStr := range activeList {
+		totalMana += nodeStr.Mana
+	}
+
+	return c.JSON(http.StatusOK, GetActiveConsensusResponse{
+		Active:    activeList,
+		TotalMana: totalMana,
+		Timestamp: t.Unix(),
+	})
+}
+
+// GetActiveConsensusResponse is the response to an active consensus mana request.
+type GetActiveConsensusResponse struct {
+	Active    []mana.NodeStr `json:"active"`
+	TotalMana float64        `json:"totalMana"`
+	Error     string         `json:"error,omitempty"`
+	Timestamp int64          `json:"timestamp"`
+}
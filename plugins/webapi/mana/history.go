@@ -0,0 +1,130 @@
+package mana
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/mana"
+)
+
+const (
+	// defaultHistoryRange is the range of a mana history that is requested without a start time.
+	defaultHistoryRange = 24 * time.Hour
+	// defaultHistoryInterval is the sampling interval of a mana history that is requested without an interval.
+	defaultHistoryInterval = 5 * time.Minute
+)
+
+// getManaHistoryHandler handles the request.
+func getManaHistoryHandler(c echo.Context) error {
+	if !manaPlugin.QueryAllowed() {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: manaPlugin.ErrQueryNotAllowed.Error()})
+	}
+
+	var req GetManaHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: err.Error()})
+	}
+	manaType, err := mana.TypeFromString(req.ManaType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: err.Error()})
+	}
+	var nodeIDs []identity.ID
+	for _, nodeID := range req.NodeIDs {
+		_nodeID, err := mana.IDFromStr(nodeID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: err.Error()})
+		}
+		nodeIDs = append(nodeIDs, _nodeID)
+	}
+
+	endTime := time.Now()
+	if req.EndTime != 0 {
+		endTime = time.Unix(req.EndTime, 0)
+	}
+	startTime := endTime.Add(-defaultHistoryRange)
+	if req.StartTime != 0 {
+		startTime = time.Unix(req.StartTime, 0)
+	}
+	if endTime.Before(startTime) {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: "time interval mismatch. endTime cannot be before startTime"})
+	}
+	interval := defaultHistoryInterval
+	if req.Interval != 0 {
+		interval = time.Duration(req.Interval) * time.Second
+	}
+	if interval <= 0 {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: "interval must be positive"})
+	}
+	// the history is sampled at multiples of the interval
+	startTime, endTime = startTime.Truncate(interval), endTime.Truncate(interval)
+
+	history, err := manaPlugin.GetManaHistory(manaType, nodeIDs, startTime, endTime, interval)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetManaHistoryResponse{Error: err.Error()})
+	}
+
+	historyJSON := make([]*NodeHistoryJSON, 0, len(history))
+	for nodeID, samples := range history {
+		samplesJSON := make([]*HistorySampleJSON, len(samples))
+		for i, sample := range samples {
+			samplesJSON[i] = &HistorySampleJSON{
+				Timestamp: sample.Time.Unix(),
+				Mana:      sample.Mana,
+			}
+		}
+		historyJSON = append(historyJSON, &NodeHistoryJSON{
+			ShortNodeID: nodeID.String(),
+			NodeID:      base58.Encode(nodeID.Bytes()),
+			Samples:     samplesJSON,
+		})
+	}
+	sort.Slice(historyJSON, func(i, j int) bool {
+		return historyJSON[i].NodeID < historyJSON[j].NodeID
+	})
+
+	return c.JSON(http.StatusOK, GetManaHistoryResponse{
+		ManaType:  manaType.String(),
+		History:   historyJSON,
+		StartTime: startTime.Unix(),
+		EndTime:   endTime.Unix(),
+		Interval:  int64(interval / time.Second),
+	})
+}
+
+// NodeHistoryJSON is the mana history of a node in JSON.
+type NodeHistoryJSON struct {
+	ShortNodeID string               `json:"shortNodeID"`
+	NodeID      string               `json:"nodeID"`
+	Samples     []*HistorySampleJSON `json:"samples"`
+}
+
+// HistorySampleJSON is the effective mana of a node at a point in time in JSON.
+type HistorySampleJSON struct {
+	Timestamp int64   `json:"timestamp"`
+	Mana      float64 `json:"mana"`
+}
+
+// GetManaHistoryRequest is the request for the mana history of nodes.
+type GetManaHistoryRequest struct {
+	NodeIDs   []string `json:"nodeIDs"`
+	ManaType  string   `json:"manaType"`
+	StartTime int64    `json:"startTime"`
+	EndTime   int64    `json:"endTime"`
+	Interval  int64    `json:"interval"`
+}
+
+// GetManaHistoryResponse is the response to a mana history request.
+type GetManaHistoryResponse struct {
+	ManaType  string             `json:"manaType"`
+	History   []*NodeHistoryJSON `json:"history"`
+	Error     string             `json:"error,omitempty"`
+	StartTime int64              `json:"startTime"`
+	EndTime   int64              `json:"endTime"`
+	Interval  int64              `json:"interval"`
+}
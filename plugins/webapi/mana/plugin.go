@@ -33,8 +33,10 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("/mana/percentile", getPercentileHandler)
 	webapi.Server().GET("/mana/access/online", getOnlineAccessHandler)
 	webapi.Server().GET("/mana/consensus/online", getOnlineConsensusHandler)
+	webapi.Server().GET("/mana/consensus/active", getActiveConsensusHandler)
 	webapi.Server().GET("/mana/pending", GetPendingMana)
 	webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
 	webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
 	webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	webapi.Server().GET("/mana/history", getManaHistoryHandler)
 }
@@ -111,10 +111,38 @@ type Metadata struct {
 
 // OutputID holds the output id and its inclusion state
 type OutputID struct {
-	ID             string         `json:"id"`
-	Balances       []Balance      `json:"balances"`
-	InclusionState InclusionState `json:"inclusion_state"`
-	Metadata       Metadata       `json:"output_metadata"`
+	ID             string                 `json:"id"`
+	Type           ledgerstate.OutputType `json:"type"`
+	Address        string                 `json:"address"`
+	Balances       []Balance              `json:"balances"`
+	InclusionState InclusionState         `json:"inclusion_state"`
+	Metadata       Metadata               `json:"output_metadata"`
+	Conditions     *OutputConditions      `json:"conditions,omitempty"`
+}
+
+// OutputConditions holds the conditions of an ExtendedLockedOutput that define which address can unlock it when.
+type OutputConditions struct {
+	FallbackAddress  string    `json:"fallback_address,omitempty"`
+	FallbackDeadline time.Time `json:"fallback_deadline"`
+	TimeLock         time.Time `json:"timelock"`
+}
+
+// NewOutputConditions returns the OutputConditions of the given Output (nil if it is not an ExtendedLockedOutput).
+func NewOutputConditions(output ledgerstate.Output) *OutputConditions {
+	extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput)
+	if !isExtendedLockedOutput {
+		return nil
+	}
+
+	conditions := &OutputConditions{
+		FallbackDeadline: extendedLockedOutput.FallbackDeadline(),
+		TimeLock:         extendedLockedOutput.TimeLock(),
+	}
+	if extendedLockedOutput.FallbackAddress() != nil {
+		conditions.FallbackAddress = extendedLockedOutput.FallbackAddress().Base58()
+	}
+
+	return conditions
 }
 
 // UnspentOutput holds the address and the corresponding unspent output ids
@@ -159,4 +187,7 @@ type UnlockBlock struct {
 	SignatureType   ledgerstate.SignatureType   `json:"signatureType,omitempty"`
 	PublicKey       string                      `json:"publicKey,omitempty"`
 	Signature       string                      `json:"signature,omitempty"`
+	Threshold       uint8                       `json:"threshold,omitempty"`
+	PublicKeys      []string                    `json:"publicKeys,omitempty"`
+	Signatures      []string                    `json:"signatures,omitempty"`
 }
@@ -66,12 +66,17 @@ func sendTransactionByJSONHandler(c echo.Context) error {
 		})
 	}
 	if !ledgerstate.TransactionBalancesValid(consumedOutputs, tx.Essence().Outputs()) {
-		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: "sum of consumed and spent balances is not 0"})
+		return c.JSON(http.StatusBadRequest, SendTransactionByJSONResponse{Error: "sum of consumed and spent balances is not 0"})
 	}
 
 	// check unlock blocks validity
 	if !ledgerstate.UnlockBlocksValid(consumedOutputs, tx) {
-		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: "spending of referenced consumedOutputs is not authorized"})
+		return c.JSON(http.StatusBadRequest, SendTransactionByJSONResponse{Error: "spending of referenced consumedOutputs is not authorized"})
+	}
+
+	// check alias transitions validity
+	if !ledgerstate.AliasOutputsValid(consumedOutputs, tx.Essence().Outputs()) {
+		return c.JSON(http.StatusBadRequest, SendTransactionByJSONResponse{Error: "created AliasOutputs are not valid transitions of their chains"})
 	}
 
 	// check if transaction is too old
@@ -88,7 +93,7 @@ func sendTransactionByJSONHandler(c echo.Context) error {
 	issueTransaction := func() (*tangle.Message, error) {
 		msg, e := messagelayer.Tangle().IssuePayload(tx)
 		if e != nil {
-			return nil, c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: e.Error()})
+			return nil, c.JSON(http.StatusBadRequest, SendTransactionByJSONResponse{Error: e.Error()})
 		}
 		return msg, nil
 	}
@@ -180,6 +185,16 @@ func NewTransactionFromJSON(request SendTransactionByJSONRequest) (*ledgerstate.
 	// add signatures
 	unlockBlocks := make([]ledgerstate.UnlockBlock, len(txEssence.Inputs()))
 	for i, signature := range request.Signatures {
+		if signature.Type == ledgerstate.MultiSignatureUnlockBlockType {
+			unlockBlock, err := multiSignatureUnlockBlockFromJSON(signature)
+			if err != nil {
+				return nil, err
+			}
+
+			unlockBlocks[i] = unlockBlock
+			continue
+		}
+
 		switch ledgerstate.SignatureType(signature.Type) {
 		case ledgerstate.ED25519SignatureType:
 			pubKeyBytes, err := base58.Decode(signature.PublicKey)
@@ -215,6 +230,38 @@ func NewTransactionFromJSON(request SendTransactionByJSONRequest) (*ledgerstate.
 	return ledgerstate.NewTransaction(txEssence, unlockBlocks), nil
 }
 
+// multiSignatureUnlockBlockFromJSON parses a MultiSignatureUnlockBlock from the given JSON model.
+func multiSignatureUnlockBlockFromJSON(unlockBlock UnlockBlock) (*ledgerstate.MultiSignatureUnlockBlock, error) {
+	publicKeys := make([]ed25519.PublicKey, len(unlockBlock.PublicKeys))
+	for i, base58PublicKey := range unlockBlock.PublicKeys {
+		pubKeyBytes, err := base58.Decode(base58PublicKey)
+		if err != nil || len(pubKeyBytes) != ed25519.PublicKeySize {
+			return nil, ErrMalformedPublicKey
+		}
+		copy(publicKeys[i][:], pubKeyBytes)
+	}
+
+	signatures := make([]*ledgerstate.ED25519Signature, len(unlockBlock.Signatures))
+	for i, base58Signature := range unlockBlock.Signatures {
+		sig, err := ledgerstate.SignatureFromBase58EncodedString(base58Signature)
+		if err != nil {
+			return nil, ErrMalformedSignature
+		}
+		ed25519Signature, isED25519Signature := sig.(*ledgerstate.ED25519Signature)
+		if !isED25519Signature {
+			return nil, ErrSignatureVersion
+		}
+		signatures[i] = ed25519Signature
+	}
+
+	multiSignatureUnlockBlock, err := ledgerstate.NewMultiSignatureUnlockBlock(unlockBlock.Threshold, publicKeys, signatures...)
+	if err != nil {
+		return nil, ErrMalformedSignature
+	}
+
+	return multiSignatureUnlockBlock, nil
+}
+
 // SendTransactionByJSONRequest holds the transaction object(json) to send.
 // e.g.,
 // {
@@ -229,7 +276,14 @@ func NewTransactionFromJSON(request SendTransactionByJSONRequest) (*ledgerstate.
 // 		   "color": string
 // 	   }[];
 // 	 }[],
-// 	 "signature": []string
+// 	 "signatures": {
+// 	   "type": number,
+// 	   "publicKey": string,
+// 	   "signature": string,
+// 	   "threshold": number,
+// 	   "publicKeys": string[],
+// 	   "signatures": string[]
+// 	 }[]
 //  }
 type SendTransactionByJSONRequest struct {
 	Inputs        []string      `json:"inputs"`
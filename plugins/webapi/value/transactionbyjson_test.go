@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -109,3 +110,56 @@ func TestNewTransactionFromJSON(t *testing.T) {
 	assert.Equal(t, tx.Essence().Inputs(), txFromJSON.Essence().Inputs())
 	assert.Equal(t, tx.Essence().Outputs().Bytes(), txFromJSON.Essence().Outputs().Bytes())
 }
+
+func TestNewTransactionFromJSON_MultiSignature(t *testing.T) {
+	mySeed := walletseed.NewSeed()
+	myOutputID := "2ZU8TNkVVGKmbFqifhejufMqpaKcSMAUvGadW4igVXB87rP"
+
+	// create a threshold address that requires 2 of the 3 keys of the seed
+	keyPairs := []ed25519.KeyPair{*mySeed.KeyPair(0), *mySeed.KeyPair(1), *mySeed.KeyPair(2)}
+	publicKeys := []ed25519.PublicKey{keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey}
+	thresholdAddress, err := ledgerstate.NewThresholdAddress(2, publicKeys)
+	require.NoError(t, err)
+
+	pledge, _ := identity.RandomID()
+	pledgeID := make([]byte, hex.EncodedLen(len(pledge.Bytes())))
+	_ = hex.Encode(pledgeID, pledge.Bytes())
+
+	data := []byte("essence")
+	sig1 := ledgerstate.NewED25519Signature(keyPairs[0].PublicKey, keyPairs[0].PrivateKey.Sign(data))
+	sig2 := ledgerstate.NewED25519Signature(keyPairs[2].PublicKey, keyPairs[2].PrivateKey.Sign(data))
+	unlockBlock, err := ledgerstate.NewMultiSignatureUnlockBlock(2, publicKeys, sig1, sig2)
+	require.NoError(t, err)
+
+	req := SendTransactionByJSONRequest{
+		Inputs: []string{myOutputID},
+		Outputs: []Output{
+			{
+				Type:     ledgerstate.SigLockedSingleOutputType,
+				Address:  thresholdAddress.Base58(),
+				Balances: []Balance{{Value: 100, Color: "IOTA"}},
+			},
+		},
+		Signatures: []UnlockBlock{
+			{
+				Type:       ledgerstate.MultiSignatureUnlockBlockType,
+				Threshold:  2,
+				PublicKeys: []string{publicKeys[2].String(), publicKeys[1].String(), publicKeys[0].String()},
+				Signatures: []string{sig1.Base58(), sig2.Base58()},
+			},
+		},
+		AManaPledgeID: string(pledgeID),
+		CManaPledgeID: string(pledgeID),
+		Payload:       payload.NewGenericDataPayload([]byte("some data")).Bytes(),
+	}
+
+	txFromJSON, err := NewTransactionFromJSON(req)
+	require.NoError(t, err)
+	assert.Equal(t, unlockBlock.Bytes(), txFromJSON.UnlockBlocks()[0].Bytes())
+	assert.Equal(t, thresholdAddress.Bytes(), txFromJSON.Essence().Outputs()[0].Address().Bytes())
+
+	// malformed public keys are rejected
+	req.Signatures[0].PublicKeys[0] = "invalid"
+	_, err = NewTransactionFromJSON(req)
+	assert.Equal(t, ErrMalformedPublicKey, err)
+}
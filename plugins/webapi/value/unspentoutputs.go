@@ -65,9 +65,12 @@ func unspentOutputsHandler(c echo.Context) error {
 					})
 					outputids = append(outputids, OutputID{
 						ID:             output.ID().Base58(),
+						Type:           output.Type(),
+						Address:        output.Address().Base58(),
 						Balances:       b,
 						InclusionState: inclusionState,
 						Metadata:       Metadata{Timestamp: timestamp},
+						Conditions:     NewOutputConditions(output),
 					})
 				}
 			})
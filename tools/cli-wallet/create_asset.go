@@ -18,6 +18,7 @@ func execCreateAssetCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	amountPtr := command.Uint64("amount", 0, "the amount of tokens to be created")
 	namePtr := command.String("name", "", "the name of the tokens to create")
 	symbolPtr := command.String("symbol", "", "the symbol of the tokens to create")
+	precisionPtr := command.Uint("precision", 0, "the amount of decimal places that are shown for the tokens")
 
 	err := command.Parse(os.Args[2:])
 	if err != nil {
@@ -31,14 +32,19 @@ func execCreateAssetCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command)
 	}
 
+	if *precisionPtr > 255 {
+		printUsage(command, "the precision can not be larger than 255")
+	}
+
 	if *namePtr == "" {
 		printUsage(command, "you need to provide a name for you asset")
 	}
 
 	assetColor, err := cliWallet.CreateAsset(wallet.Asset{
-		Name:   *namePtr,
-		Symbol: *symbolPtr,
-		Amount: *amountPtr,
+		Name:      *namePtr,
+		Symbol:    *symbolPtr,
+		Precision: int(*precisionPtr),
+		Amount:    *amountPtr,
 	})
 	if err != nil {
 		printUsage(command, err.Error())
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execDestroyAssetCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	amountPtr := command.Uint64("amount", 0, "the amount of tokens to be destroyed")
+	colorPtr := command.String("color", "", "color of the tokens to destroy")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *amountPtr == 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+
+	color, err := ledgerstate.ColorFromBase58EncodedString(*colorPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if _, err = cliWallet.DestroyAssets(color, *amountPtr); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Destroying " + strconv.FormatUint(*amountPtr, 10) + " tokens with the color '" + color.String() + "' ...   [DONE]")
+}
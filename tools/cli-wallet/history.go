@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+func execHistoryCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	history, err := cliWallet.TransactionHistory()
+	if err != nil {
+		printUsage(nil, err.Error())
+	}
+
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	defer w.Flush()
+
+	// print header
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "STATUS", "TIME", "DIRECTION", "TRANSACTION ID")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "------", "-------------------", "---------", "--------------------------------------------")
+
+	// print empty if no transactions were found
+	if len(history) == 0 {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>")
+
+		return
+	}
+
+	for _, entry := range history {
+		status := "[PEND]"
+		if entry.InclusionState.Confirmed {
+			status = "[ OK ]"
+		} else if entry.InclusionState.Rejected {
+			status = "[REJ ]"
+		}
+
+		direction := "IN"
+		if entry.Debited() {
+			direction = "OUT"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status, entry.Timestamp.Format(time.RFC3339), direction, entry.TransactionID.Base58())
+	}
+}
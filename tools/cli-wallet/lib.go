@@ -134,10 +134,14 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("COMMANDS:")
 		fmt.Println("  balance")
 		fmt.Println("        show the balances held by this wallet")
+		fmt.Println("  history")
+		fmt.Println("        show the transactions that credit or debit the addresses of this wallet")
 		fmt.Println("  send-funds")
 		fmt.Println("        initiate a value transfer")
 		fmt.Println("  create-asset")
 		fmt.Println("        create an asset in the form of colored coins")
+		fmt.Println("  destroy-asset")
+		fmt.Println("        destroy colored coins by uncoloring them back to IOTA")
 		fmt.Println("  address")
 		fmt.Println("        start the address manager of this wallet")
 		fmt.Println("  request-funds")
@@ -37,22 +37,28 @@ func main() {
 	balanceCommand := flag.NewFlagSet("balance", flag.ExitOnError)
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
+	destroyAssetCommand := flag.NewFlagSet("destroy-asset", flag.ExitOnError)
 	addressCommand := flag.NewFlagSet("address", flag.ExitOnError)
 	requestFaucetFundsCommand := flag.NewFlagSet("request-funds", flag.ExitOnError)
 	serverStatusCommand := flag.NewFlagSet("server-status", flag.ExitOnError)
 	allowedPledgeIDCommand := flag.NewFlagSet("pledge-id", flag.ExitOnError)
 	pendingManaCommand := flag.NewFlagSet("pending-mana", flag.ExitOnError)
+	historyCommand := flag.NewFlagSet("history", flag.ExitOnError)
 
 	// switch logic according to provided sub command
 	switch os.Args[1] {
 	case "balance":
 		execBalanceCommand(balanceCommand, wallet)
+	case "history":
+		execHistoryCommand(historyCommand, wallet)
 	case "address":
 		execAddressCommand(addressCommand, wallet)
 	case "send-funds":
 		execSendFundsCommand(sendFundsCommand, wallet)
 	case "create-asset":
 		execCreateAssetCommand(createAssetCommand, wallet)
+	case "destroy-asset":
+		execDestroyAssetCommand(destroyAssetCommand, wallet)
 	case "request-funds":
 		execRequestFundsCommand(requestFaucetFundsCommand, wallet)
 	case "pledge-id":
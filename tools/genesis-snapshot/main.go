@@ -1,9 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	"github.com/iotaledger/hive.go/bitmask"
 	"github.com/mr-tron/base58"
@@ -21,6 +24,8 @@ const (
 	cfgGenesisTokenAmount   = "token-amount"
 	cfgSnapshotFileName     = "snapshot-file"
 	cfgSnapshotGenesisSeed  = "seed"
+	cfgSnapshotNetworkID    = "network-id"
+	cfgManaPledgesFileName  = "mana-pledges-file"
 	defaultSnapshotFileName = "./snapshot.bin"
 )
 
@@ -28,6 +33,8 @@ func init() {
 	flag.Int(cfgGenesisTokenAmount, 1000000000000000, "the amount of tokens to add to the genesis output")
 	flag.String(cfgSnapshotFileName, defaultSnapshotFileName, "the name of the generated snapshot file")
 	flag.String(cfgSnapshotGenesisSeed, "", "the genesis seed")
+	flag.Uint32(cfgSnapshotNetworkID, 22, "the network version of the autopeering that the snapshot is created for")
+	flag.String(cfgManaPledgesFileName, "", "the name of the JSON file that contains the initial mana pledges to the nodes")
 }
 
 func main() {
@@ -72,25 +79,122 @@ func main() {
 	log.Printf("-> output id (base58): %s", ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
 	log.Printf("-> token amount: %d", genesisTokenAmount)
 
-	snapshot := ledgerstate.Snapshot{
-		ledgerstate.GenesisTransactionID: {
-			genesisAddress: ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: uint64(genesisTokenAmount)}),
-		},
+	snapshotTime := time.Now()
+	manaPledges, err := readManaPledges(viper.GetString(cfgManaPledgesFileName), snapshotTime)
+	if err != nil {
+		log.Fatal("unable to read mana pledges: ", err)
+	}
+	for _, manaPledge := range manaPledges {
+		log.Printf("-> mana pledge to %s: %d access mana, %d consensus mana", manaPledge.NodeID, manaPledge.AccessMana, manaPledge.ConsensusMana)
+	}
+	outputs, outputPledges, err := genesisOutputs(genesisAddress, uint64(genesisTokenAmount), manaPledges)
+	if err != nil {
+		log.Fatal("unable to create genesis outputs: ", err)
 	}
 
-	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE, 0666)
+	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		log.Fatal("unable to create snapshot file", err)
 	}
 	defer f.Close()
 
-	if _, err = snapshot.WriteTo(f); err != nil {
-		log.Fatal("unable to write snapshot content to file", err)
+	snapshotWriter, err := ledgerstate.NewSnapshotWriter(f, viper.GetUint32(cfgSnapshotNetworkID), snapshotTime, manaPledges...)
+	if err != nil {
+		log.Fatal("unable to write snapshot header to file", err)
+	}
+	for i, output := range outputs {
+		outputMetadata := ledgerstate.NewOutputMetadata(output.ID())
+		outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+		outputMetadata.SetSolid(true)
+		outputMetadata.SetFinalized(true)
+		if err = snapshotWriter.WriteOutput(output, outputMetadata, outputPledges[i]); err != nil {
+			log.Fatal("unable to write snapshot content to file", err)
+		}
+	}
+	header, err := snapshotWriter.Close()
+	if err != nil {
+		log.Fatal("unable to finalize snapshot file", err)
 	}
 
+	log.Printf("-> network id: %d", header.NetworkID)
+	log.Printf("-> ledger root (base58): %s", header.LedgerRoot.Base58())
 	log.Printf("created %s, bye", snapshotFileName)
 }
 
+// genesisOutputs splits the genesis tokens into the outputs of the genesis transaction. The consensus mana of each mana
+// pledge is backed by an output that is pledged to the node, so that spending it revokes the mana again, and the
+// remaining tokens are held by the genesis output, which does not pledge any mana.
+func genesisOutputs(genesisAddress ledgerstate.Address, genesisTokenAmount uint64, manaPledges []*ledgerstate.ManaPledge) (outputs ledgerstate.Outputs, outputPledges []*ledgerstate.OutputPledge, err error) {
+	remainingTokenAmount := genesisTokenAmount
+	for _, manaPledge := range manaPledges {
+		if manaPledge.ConsensusMana == 0 {
+			continue
+		}
+		if manaPledge.ConsensusMana > remainingTokenAmount {
+			return nil, nil, fmt.Errorf("the consensus mana pledges exceed the %d genesis tokens", genesisTokenAmount)
+		}
+		remainingTokenAmount -= manaPledge.ConsensusMana
+
+		output := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: manaPledge.ConsensusMana}), genesisAddress)
+		outputs = append(outputs, output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(len(outputs)+1))))
+		outputPledges = append(outputPledges, &ledgerstate.OutputPledge{
+			AccessPledgeID:    manaPledge.NodeID,
+			ConsensusPledgeID: manaPledge.NodeID,
+			Timestamp:         manaPledge.Timestamp,
+		})
+	}
+
+	genesisOutput := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: remainingTokenAmount}), genesisAddress)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
+
+	return append(ledgerstate.Outputs{genesisOutput}, outputs...), append([]*ledgerstate.OutputPledge{nil}, outputPledges...), nil
+}
+
+// manaPledgeJSON is the JSON representation of a mana pledge in the mana pledges file, which contains a list of them.
+// The node ID is base58 encoded and the optional timestamp (in unix seconds) defaults to the time of the snapshot.
+type manaPledgeJSON struct {
+	NodeID        string `json:"nodeID"`
+	AccessMana    uint64 `json:"accessMana"`
+	ConsensusMana uint64 `json:"consensusMana"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+// readManaPledges reads the mana pledges from the given JSON file. It returns no pledges if no file is given.
+func readManaPledges(fileName string, snapshotTime time.Time) (manaPledges []*ledgerstate.ManaPledge, err error) {
+	if fileName == "" {
+		return nil, nil
+	}
+
+	fileContent, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", fileName, err)
+	}
+	var manaPledgesJSON []manaPledgeJSON
+	if err = json.Unmarshal(fileContent, &manaPledgesJSON); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s: %w", fileName, err)
+	}
+
+	for _, manaPledgeJSON := range manaPledgesJSON {
+		nodeID, idErr := mana.IDFromStr(manaPledgeJSON.NodeID)
+		if idErr != nil {
+			return nil, idErr
+		}
+		timestamp := snapshotTime
+		if manaPledgeJSON.Timestamp != 0 {
+			timestamp = time.Unix(manaPledgeJSON.Timestamp, 0)
+		}
+
+		manaPledges = append(manaPledges, &ledgerstate.ManaPledge{
+			NodeID:        nodeID,
+			AccessMana:    manaPledgeJSON.AccessMana,
+			ConsensusMana: manaPledgeJSON.ConsensusMana,
+			Timestamp:     timestamp,
+		})
+	}
+
+	return manaPledges, nil
+}
+
 type mockConnector struct {
 	outputs map[address.Address]map[ledgerstate.OutputID]*wallet.Output
 }
@@ -142,3 +246,7 @@ func (connector *mockConnector) SendTransaction(tx *ledgerstate.Transaction) (er
 func (connector *mockConnector) GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error) {
 	return
 }
+
+func (connector *mockConnector) TransactionHistory(addresses ...address.Address) (history wallet.TransactionHistory, err error) {
+	return
+}
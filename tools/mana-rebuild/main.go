@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+const (
+	cfgDatabaseDir           = "db"
+	cfgSnapshotFileName      = "snapshot-file"
+	cfgEmaCoefficient1       = "ema-coefficient1"
+	cfgEmaCoefficient2       = "ema-coefficient2"
+	cfgDecay                 = "decay"
+	cfgAccessManaFunction    = "access-mana-function"
+	cfgConsensusManaFunction = "consensus-mana-function"
+	cfgEnableResearchVectors = "research-vectors"
+)
+
+func init() {
+	flag.String(cfgDatabaseDir, "mainnetdb", "path to the database folder of the (stopped) node")
+	flag.String(cfgSnapshotFileName, "./snapshot.bin", "path to the snapshot file that the node was bootstrapped from")
+	flag.Float64(cfgEmaCoefficient1, 0.00003209, "coefficient used for Effective Base Mana 1 (moving average) calculation")
+	flag.Float64(cfgEmaCoefficient2, 0.0057762265, "coefficient used for Effective Base Mana 2 (moving average) calculation")
+	flag.Float64(cfgDecay, 0.00003209, "decay coefficient used for Base Mana 2 calculation")
+	flag.String(cfgAccessManaFunction, mana.EMAManaFunctionName, "function used for the access mana calculation")
+	flag.String(cfgConsensusManaFunction, mana.EMAManaFunctionName, "function used for the consensus mana calculation")
+	flag.Bool(cfgEnableResearchVectors, false, "rebuild the mana research vectors as well")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	db, err := database.NewDB(viper.GetString(cfgDatabaseDir))
+	if err != nil {
+		log.Fatalf("unable to open the database: %s", err)
+	}
+	store := db.NewStore()
+	branchDAG := ledgerstate.NewBranchDAG(store)
+	utxoDAG := ledgerstate.NewUTXODAG(store, branchDAG)
+	defer func() {
+		utxoDAG.Shutdown()
+		branchDAG.Shutdown()
+		if err = db.Close(); err != nil {
+			log.Printf("unable to close the database: %s", err)
+		}
+	}()
+
+	snapshotHeader, err := verifySnapshot(utxoDAG, viper.GetString(cfgSnapshotFileName))
+	if err != nil {
+		log.Fatalf("unable to verify the snapshot: %s", err)
+	}
+	log.Printf("verified %d snapshot outputs in the ledger state", snapshotHeader.OutputCount)
+
+	mana.SetCoefficients(viper.GetFloat64(cfgEmaCoefficient1), viper.GetFloat64(cfgEmaCoefficient2), viper.GetFloat64(cfgDecay))
+	for manaType, manaFunctionName := range map[mana.Type]string{
+		mana.AccessMana:    viper.GetString(cfgAccessManaFunction),
+		mana.ConsensusMana: viper.GetString(cfgConsensusManaFunction),
+	} {
+		manaFunction, manaFunctionErr := mana.NewManaFunction(manaFunctionName, manaType)
+		if manaFunctionErr != nil {
+			log.Fatalf("unable to configure the mana functions: %s", manaFunctionErr)
+		}
+		if manaFunctionErr = mana.SetManaFunction(manaType, manaFunction); manaFunctionErr != nil {
+			log.Fatalf("unable to configure the mana functions: %s", manaFunctionErr)
+		}
+	}
+	baseManaVectors := make(map[mana.Type]mana.BaseManaVector)
+	baseManaVectors[mana.AccessMana], _ = mana.NewBaseManaVector(mana.AccessMana)
+	baseManaVectors[mana.ConsensusMana], _ = mana.NewBaseManaVector(mana.ConsensusMana)
+	if viper.GetBool(cfgEnableResearchVectors) {
+		baseManaVectors[mana.ResearchAccess], _ = mana.NewResearchBaseManaVector(mana.WeightedMana, mana.AccessMana, mana.Mixed)
+		baseManaVectors[mana.ResearchConsensus], _ = mana.NewResearchBaseManaVector(mana.WeightedMana, mana.ConsensusMana, mana.Mixed)
+	}
+	mana.LoadSnapshotPledges(baseManaVectors, snapshotHeader.ManaPledges)
+	log.Printf("seeded %d mana pledges of the snapshot", len(snapshotHeader.ManaPledges))
+
+	confirmedTransactions := confirmedTransactions(utxoDAG)
+	for _, transaction := range confirmedTransactions {
+		txInfo := mana.NewTxInfo(transaction, utxoDAG)
+		for _, baseManaVector := range baseManaVectors {
+			baseManaVector.Book(txInfo)
+		}
+	}
+	log.Printf("booked %d confirmed transactions", len(confirmedTransactions))
+
+	if err = writeCheckpoint(store.WithRealm([]byte{database.PrefixMana}), baseManaVectors); err != nil {
+		log.Fatalf("unable to persist the mana vectors: %s", err)
+	}
+	for vectorType, baseManaVector := range baseManaVectors {
+		log.Printf("-> %s mana vector: %d nodes", vectorType.String(), baseManaVector.Size())
+	}
+	log.Printf("rebuilt mana vectors in %s, bye", viper.GetString(cfgDatabaseDir))
+}
+
+// verifySnapshot makes sure that the ledger state in the database was bootstrapped from the given snapshot and returns
+// the header of the snapshot.
+func verifySnapshot(utxoDAG *ledgerstate.UTXODAG, snapshotFileName string) (header ledgerstate.SnapshotHeader, err error) {
+	f, err := os.Open(snapshotFileName)
+	if err != nil {
+		return header, fmt.Errorf("unable to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	snapshotReader, err := ledgerstate.NewSnapshotReader(bufio.NewReader(f))
+	if err != nil {
+		return header, fmt.Errorf("unable to read snapshot header: %w", err)
+	}
+	header = snapshotReader.Header()
+
+	for {
+		output, _, _, readErr := snapshotReader.Next()
+		if errors.Is(readErr, io.EOF) {
+			return header, nil
+		}
+		if readErr != nil {
+			return header, fmt.Errorf("unable to read snapshot output: %w", readErr)
+		}
+
+		if !utxoDAG.Output(output.ID()).Consume(func(ledgerstate.Output) {}) {
+			return header, fmt.Errorf("snapshot output %s is missing in the ledger state of the database", output.ID())
+		}
+	}
+}
+
+// confirmedTransactions returns the confirmed transactions of the ledger state in the order of their timestamps.
+func confirmedTransactions(utxoDAG *ledgerstate.UTXODAG) (transactions []*ledgerstate.Transaction) {
+	utxoDAG.ForEachTransaction(func(transaction *ledgerstate.Transaction) {
+		if inclusionState, err := utxoDAG.InclusionState(transaction.ID()); err == nil && inclusionState == ledgerstate.Confirmed {
+			transactions = append(transactions, transaction)
+		}
+	})
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if !transactions[i].Essence().Timestamp().Equal(transactions[j].Essence().Timestamp()) {
+			return transactions[i].Essence().Timestamp().Before(transactions[j].Essence().Timestamp())
+		}
+		return bytes.Compare(transactions[i].ID().Bytes(), transactions[j].ID().Bytes()) < 0
+	})
+
+	return
+}
+
+// writeCheckpoint replaces the persisted mana vectors with the rebuilt ones. The checkpoint covers all of the logged
+// transactions, so the node does not replay them on its next start.
+func writeCheckpoint(store kvstore.KVStore, baseManaVectors map[mana.Type]mana.BaseManaVector) (err error) {
+	vectorStorage, err := mana.NewVectorStorage(store)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := vectorStorage.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if err = vectorStorage.ForEachBookedTransaction(checkpoint.LastBookedIndex, func(index uint64, transactionID ledgerstate.TransactionID) bool {
+		checkpoint.LastBookedIndex = index
+		checkpoint.LastBookedTransactionID = transactionID
+		return true
+	}); err != nil {
+		return err
+	}
+	checkpoint.Timestamp = time.Now()
+	checkpoint.AccessManaFunction = mana.GetManaFunction(mana.AccessMana).Name()
+	checkpoint.ConsensusManaFunction = mana.GetManaFunction(mana.ConsensusMana).Name()
+
+	return vectorStorage.WriteCheckpoint(baseManaVectors, checkpoint)
+}